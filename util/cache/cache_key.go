@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"k8s.io/utils/dump"
+
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+)
+
+const digestPrefix = "sha256:"
+
+// RenderKey identifies a render result by what produced it rather than by a
+// caller-chosen name: the source identity, a digest of the source content and
+// a hash of the merged values. Any change to the chart, git revision, files or
+// values yields a different key, so cached results never go stale.
+type RenderKey struct {
+	// Source identifies the source, e.g. "helm:oci://registry/chart" or "yaml:manifests/".
+	Source string
+
+	// ContentDigest is a digest of the source content, e.g. a chart digest,
+	// a git commit SHA, or the result of DigestFS.
+	ContentDigest string
+
+	// ValuesHash is the hash of the merged values, see ValuesHash.
+	ValuesHash string
+}
+
+// String returns the canonical string form of the key used for cache storage,
+// <len>:<source>@<len>:<digest>#<values hash>. The source and digest are
+// length-prefixed, so that keys stay distinct when they contain the
+// separators, as OCI references do.
+func (k RenderKey) String() string {
+	return fmt.Sprintf("%d:%s@%d:%s#%s", len(k.Source), k.Source, len(k.ContentDigest), k.ContentDigest, k.ValuesHash)
+}
+
+// NewRenderKey builds a RenderKey hashing the given merged values.
+func NewRenderKey(source string, contentDigest string, values map[string]any) RenderKey {
+	return RenderKey{
+		Source:        source,
+		ContentDigest: contentDigest,
+		ValuesHash:    ValuesHash(values),
+	}
+}
+
+// ValuesHash computes a deterministic SHA-256 hash of a values tree.
+// Map key order does not affect the result. Nil and empty values hash the same.
+func ValuesHash(values map[string]any) string {
+	if len(values) == 0 {
+		values = map[string]any{}
+	}
+
+	hasher := sha256.New()
+	_, _ = io.WriteString(hasher, dump.ForHash(values))
+
+	return digestPrefix + hex.EncodeToString(hasher.Sum(nil))
+}
+
+// DigestFS computes a deterministic SHA-256 digest of all regular files in
+// fsys, covering both file paths and contents. Files are visited in lexical
+// order, so the digest only changes when the content changes. Symbolic links
+// to files are followed; other links, such as links to directories, are
+// covered by their target.
+func DigestFS(fsys fs.FS) (string, error) {
+	if fsys == nil {
+		return "", utilerrors.ErrFsRequired
+	}
+
+	hasher := sha256.New()
+
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			return digestLink(hasher, fsys, path)
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		return digestFile(hasher, fsys, path)
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to compute digest: %w", err)
+	}
+
+	return digestPrefix + hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// digestFile writes the path and content of the file at path to w.
+func digestFile(w io.Writer, fsys fs.FS, path string) error {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", path, err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	// Paths are NUL-terminated so that path/content boundaries are unambiguous.
+	_, _ = io.WriteString(w, path+"\x00")
+
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	_, _ = io.WriteString(w, "\x00")
+
+	return nil
+}
+
+// digestLink writes the symbolic link at path to w: the path and content of
+// the file it points to, or else its path and target.
+func digestLink(w io.Writer, fsys fs.FS, path string) error {
+	if info, err := fs.Stat(fsys, path); err == nil && info.Mode().IsRegular() {
+		return digestFile(w, fsys, path)
+	}
+
+	target, err := fs.ReadLink(fsys, path)
+	if err != nil {
+		return fmt.Errorf("unable to read link %s: %w", path, err)
+	}
+
+	// The arrow marks the target, so that a link differs from a file of the
+	// same path.
+	_, _ = io.WriteString(w, path+"\x00->"+target+"\x00")
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package cache_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/cache"
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testKeySource       = "helm:oci://registry.example.com/charts/web"
+	testKeyDigest       = "sha256:0123456789abcdef"
+	testChartYAML       = "apiVersion: v2\nname: web\nversion: 1.0.0\n"
+	testDeploymentYAML  = "kind: Deployment\n"
+	testDeploymentYAML2 = "kind: StatefulSet\n"
+)
+
+func TestValuesHash(t *testing.T) {
+	t.Run("is independent of map construction order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		a := map[string]any{"replicas": 2, "image": map[string]any{"tag": "1.0", "repository": "nginx"}}
+		b := map[string]any{"image": map[string]any{"repository": "nginx", "tag": "1.0"}, "replicas": 2}
+
+		g.Expect(cache.ValuesHash(a)).Should(Equal(cache.ValuesHash(b)))
+		g.Expect(cache.ValuesHash(a)).Should(HavePrefix("sha256:"))
+	})
+
+	t.Run("changes when values change", func(t *testing.T) {
+		g := NewWithT(t)
+
+		a := map[string]any{"replicas": 2}
+		b := map[string]any{"replicas": 3}
+
+		g.Expect(cache.ValuesHash(a)).ShouldNot(Equal(cache.ValuesHash(b)))
+	})
+
+	t.Run("treats nil and empty values the same", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(cache.ValuesHash(nil)).Should(Equal(cache.ValuesHash(map[string]any{})))
+	})
+}
+
+func TestDigestFS(t *testing.T) {
+	t.Run("is deterministic", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fsys := fstest.MapFS{
+			"Chart.yaml":                &fstest.MapFile{Data: []byte(testChartYAML)},
+			"templates/deployment.yaml": &fstest.MapFile{Data: []byte(testDeploymentYAML)},
+		}
+
+		d1, err := cache.DigestFS(fsys)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		d2, err := cache.DigestFS(fsys)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(d1).Should(Equal(d2))
+		g.Expect(d1).Should(HavePrefix("sha256:"))
+	})
+
+	t.Run("changes with file content and paths", func(t *testing.T) {
+		g := NewWithT(t)
+
+		base, err := cache.DigestFS(fstest.MapFS{
+			"templates/deployment.yaml": &fstest.MapFile{Data: []byte(testDeploymentYAML)},
+		})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		changedContent, err := cache.DigestFS(fstest.MapFS{
+			"templates/deployment.yaml": &fstest.MapFile{Data: []byte(testDeploymentYAML2)},
+		})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		renamed, err := cache.DigestFS(fstest.MapFS{
+			"templates/workload.yaml": &fstest.MapFile{Data: []byte(testDeploymentYAML)},
+		})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(base).ShouldNot(Equal(changedContent))
+		g.Expect(base).ShouldNot(Equal(renamed))
+	})
+
+	t.Run("follows links to files", func(t *testing.T) {
+		g := NewWithT(t)
+
+		base, err := cache.DigestFS(fstest.MapFS{
+			"files/deployment.yaml":     &fstest.MapFile{Data: []byte(testDeploymentYAML)},
+			"templates/deployment.yaml": &fstest.MapFile{Data: []byte("../files/deployment.yaml"), Mode: fs.ModeSymlink},
+		})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		changedTarget, err := cache.DigestFS(fstest.MapFS{
+			"files/deployment.yaml":     &fstest.MapFile{Data: []byte(testDeploymentYAML2)},
+			"templates/deployment.yaml": &fstest.MapFile{Data: []byte("../files/deployment.yaml"), Mode: fs.ModeSymlink},
+		})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(base).ShouldNot(Equal(changedTarget))
+	})
+
+	t.Run("covers the target of links to directories", func(t *testing.T) {
+		g := NewWithT(t)
+
+		digest := func(target string) string {
+			d, err := cache.DigestFS(fstest.MapFS{
+				"v1/deployment.yaml": &fstest.MapFile{Data: []byte(testDeploymentYAML)},
+				"v2/deployment.yaml": &fstest.MapFile{Data: []byte(testDeploymentYAML2)},
+				"templates":          &fstest.MapFile{Data: []byte(target), Mode: fs.ModeSymlink},
+			})
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			return d
+		}
+
+		g.Expect(digest("v1")).ShouldNot(Equal(digest("v2")))
+	})
+
+	t.Run("requires a filesystem", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := cache.DigestFS(nil)
+
+		g.Expect(err).Should(MatchError(utilerrors.ErrFsRequired))
+	})
+}
+
+func TestRenderKey(t *testing.T) {
+	t.Run("works as a cache key", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := cache.NewRenderCache()
+		key := cache.NewRenderKey(testKeySource, testKeyDigest, map[string]any{"replicas": 2})
+		result := []unstructured.Unstructured{{Object: map[string]any{"kind": "Deployment"}}}
+
+		c.Set(key, result)
+
+		cached, found := c.Get(cache.NewRenderKey(testKeySource, testKeyDigest, map[string]any{"replicas": 2}))
+		g.Expect(found).Should(BeTrue())
+		g.Expect(cached).Should(HaveLen(1))
+
+		_, found = c.Get(cache.NewRenderKey(testKeySource, testKeyDigest, map[string]any{"replicas": 3}))
+		g.Expect(found).Should(BeFalse())
+
+		_, found = c.Get(cache.NewRenderKey(testKeySource, "sha256:other", map[string]any{"replicas": 2}))
+		g.Expect(found).Should(BeFalse())
+	})
+
+	t.Run("uses its string form with the default key func", func(t *testing.T) {
+		g := NewWithT(t)
+
+		key := cache.RenderKey{Source: "yaml:manifests", ContentDigest: "sha256:abc", ValuesHash: "sha256:def"}
+
+		g.Expect(cache.DefaultKeyFunc(key)).Should(Equal("14:yaml:manifests@10:sha256:abc#sha256:def"))
+	})
+
+	t.Run("keeps keys with separators in their fields apart", func(t *testing.T) {
+		g := NewWithT(t)
+
+		a := cache.RenderKey{Source: "helm:oci://registry/chart@sha256", ContentDigest: "abc", ValuesHash: "sha256:def"}
+		b := cache.RenderKey{Source: "helm:oci://registry/chart", ContentDigest: "sha256@abc", ValuesHash: "sha256:def"}
+		c := cache.RenderKey{Source: "helm:oci://registry/chart", ContentDigest: "sha256@abc#sha256", ValuesHash: ":def"}
+
+		g.Expect(a.String()).ShouldNot(Equal(b.String()))
+		g.Expect(b.String()).ShouldNot(Equal(c.String()))
+	})
+}
@@ -12,6 +12,8 @@ func DefaultKeyFunc(key any) string {
 	switch k := key.(type) {
 	case string:
 		return k
+	case RenderKey:
+		return k.String()
 	case []byte:
 		return string(k)
 	case int:
@@ -0,0 +1,367 @@
+package cache
+
+import (
+	"cmp"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+)
+
+const (
+	diskTempPrefix = ".tmp-"
+
+	// diskTempMaxAge is the age after which a temporary directory is
+	// considered left over by an interrupted write rather than being filled
+	// by another process.
+	diskTempMaxAge = 24 * time.Hour
+)
+
+var (
+	// ErrInvalidDiskKey is returned when a disk cache key is not a valid digest.
+	ErrInvalidDiskKey = errors.New("invalid disk cache key")
+
+	diskKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:-]*$`)
+)
+
+// DiskCache is a size-bounded on-disk cache for downloaded artifacts such as
+// chart archives, git checkouts and fetched URLs. Entries are directories keyed
+// by content digest; when the total size exceeds the configured cap, the least
+// recently used entries are evicted.
+//
+// Recency is tracked through the modification time of the entry directories,
+// so the LRU order survives process restarts. A DiskCache is safe for
+// concurrent use within a process. Processes sharing a directory see each
+// other's entries and may store the same entry concurrently, but eviction is
+// only coordinated per process: an entry may be evicted by another process
+// while it is in use.
+type DiskCache struct {
+	mu      sync.Mutex
+	dir     string
+	maxSize int64
+	entries map[string]*diskEntry
+	size    int64
+}
+
+type diskEntry struct {
+	key      string
+	size     int64
+	lastUsed time.Time
+}
+
+// NewDiskCache opens, creating it if needed, a disk cache rooted at dir.
+// Existing entries are indexed and leftovers of interrupted writes, temporary
+// directories older than a day, are removed.
+func NewDiskCache(dir string, opts ...DiskOption) (*DiskCache, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, utilerrors.ErrPathEmpty
+	}
+
+	options := DiskOptions{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create disk cache directory %s: %w", dir, err)
+	}
+
+	c := &DiskCache{
+		dir:     dir,
+		maxSize: options.MaxSize,
+		entries: make(map[string]*diskEntry),
+	}
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Get returns the directory holding the entry for key and marks the entry as
+// recently used.
+func (c *DiskCache) Get(key string) (string, bool) {
+	name, err := diskEntryName(key)
+	if err != nil {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[name]
+	if !ok {
+		// Another process may have stored the entry.
+		if e, ok = c.index(name); !ok {
+			return "", false
+		}
+	}
+
+	path := filepath.Join(c.dir, name)
+
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		// The entry was removed behind our back.
+		c.remove(e)
+
+		return "", false
+	}
+
+	e.lastUsed = now
+
+	return path, true
+}
+
+// Put stores a new entry for key. The fill function populates an empty
+// directory with the entry content; the directory is moved into the cache only
+// once fill succeeds, so readers never observe partial entries. If the entry
+// already exists, fill is not called. Put returns the directory of the entry.
+func (c *DiskCache) Put(key string, fill func(dir string) error) (string, error) {
+	if path, ok := c.Get(key); ok {
+		return path, nil
+	}
+
+	name, err := diskEntryName(key)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.MkdirTemp(c.dir, diskTempPrefix)
+	if err != nil {
+		return "", fmt.Errorf("unable to create disk cache entry: %w", err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(tmp)
+	}()
+
+	if err := fill(tmp); err != nil {
+		return "", fmt.Errorf("unable to fill disk cache entry %s: %w", key, err)
+	}
+
+	size, err := dirSize(tmp)
+	if err != nil {
+		return "", fmt.Errorf("unable to size disk cache entry %s: %w", key, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := filepath.Join(c.dir, name)
+
+	// Another writer may have stored the same entry while fill was running.
+	if _, ok := c.entries[name]; ok {
+		return path, nil
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		// Another process stored the same entry while fill was running.
+		if _, ok := c.index(name); ok {
+			return path, nil
+		}
+
+		return "", fmt.Errorf("unable to store disk cache entry %s: %w", key, err)
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		return "", fmt.Errorf("unable to store disk cache entry %s: %w", key, err)
+	}
+
+	e := &diskEntry{key: name, size: size, lastUsed: now}
+	c.entries[name] = e
+	c.size += size
+
+	c.evict(e)
+
+	return path, nil
+}
+
+// Size returns the total size in bytes of the cached entries.
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
+
+// Purge removes all entries from the cache directory, including those stored
+// by other processes. Temporary directories are kept, as they may be being
+// filled by another process.
+func (c *DiskCache) Purge() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("unable to purge disk cache: %w", err)
+	}
+
+	var errs []error
+
+	for _, item := range items {
+		if !item.IsDir() || strings.HasPrefix(item.Name(), diskTempPrefix) {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(c.dir, item.Name())); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Entries that could not be removed stay indexed.
+	for _, e := range c.entries {
+		if _, err := os.Stat(filepath.Join(c.dir, e.key)); errors.Is(err, os.ErrNotExist) {
+			c.remove(e)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("unable to purge disk cache: %w", err)
+	}
+
+	return nil
+}
+
+// evict removes least recently used entries until the cache fits its size
+// cap. The keep entry is never evicted, so an entry larger than the cap still
+// lives until the next Put.
+func (c *DiskCache) evict(keep *diskEntry) {
+	if c.maxSize <= 0 || c.size <= c.maxSize {
+		return
+	}
+
+	candidates := make([]*diskEntry, 0, len(c.entries))
+	for _, e := range c.entries {
+		if e != keep {
+			candidates = append(candidates, e)
+		}
+	}
+
+	slices.SortFunc(candidates, func(a *diskEntry, b *diskEntry) int {
+		return cmp.Or(a.lastUsed.Compare(b.lastUsed), cmp.Compare(a.key, b.key))
+	})
+
+	for _, e := range candidates {
+		if c.size <= c.maxSize {
+			return
+		}
+
+		if err := os.RemoveAll(filepath.Join(c.dir, e.key)); err != nil {
+			continue
+		}
+
+		c.remove(e)
+	}
+}
+
+// index adds the entry stored on disk under name, e.g. by another process, to
+// the index.
+func (c *DiskCache) index(name string) (*diskEntry, bool) {
+	path := filepath.Join(c.dir, name)
+
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return nil, false
+	}
+
+	size, err := dirSize(path)
+	if err != nil {
+		return nil, false
+	}
+
+	e := &diskEntry{key: name, size: size, lastUsed: info.ModTime()}
+	c.entries[name] = e
+	c.size += size
+
+	return e, true
+}
+
+func (c *DiskCache) remove(e *diskEntry) {
+	delete(c.entries, e.key)
+	c.size -= e.size
+}
+
+func (c *DiskCache) load() error {
+	items, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("unable to read disk cache directory %s: %w", c.dir, err)
+	}
+
+	for _, item := range items {
+		path := filepath.Join(c.dir, item.Name())
+
+		if !item.IsDir() {
+			continue
+		}
+
+		info, err := item.Info()
+		if err != nil {
+			return fmt.Errorf("unable to read disk cache entry %s: %w", path, err)
+		}
+
+		// Temporary directories are removed only once stale, as they may be
+		// being filled by another process.
+		if strings.HasPrefix(item.Name(), diskTempPrefix) {
+			if time.Since(info.ModTime()) > diskTempMaxAge {
+				_ = os.RemoveAll(path)
+			}
+
+			continue
+		}
+
+		size, err := dirSize(path)
+		if err != nil {
+			return fmt.Errorf("unable to size disk cache entry %s: %w", path, err)
+		}
+
+		c.entries[item.Name()] = &diskEntry{key: item.Name(), size: size, lastUsed: info.ModTime()}
+		c.size += size
+	}
+
+	return nil
+}
+
+// diskEntryName maps a digest key to a directory name. The key is hex encoded,
+// as colons are not valid in file names on every platform and file names are
+// case-insensitive on some, so that distinct keys never share a directory.
+func diskEntryName(key string) (string, error) {
+	if !diskKeyPattern.MatchString(key) || strings.Contains(key, "..") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidDiskKey, key)
+	}
+
+	return hex.EncodeToString([]byte(key)), nil
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
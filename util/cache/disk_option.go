@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// DiskOption is a generic option for DiskCache.
+type DiskOption = util.Option[DiskOptions]
+
+// DiskOptions is a struct-based option that can set disk cache options.
+type DiskOptions struct {
+	// MaxSize is the size cap in bytes of the cache; zero means unbounded.
+	MaxSize int64
+}
+
+// ApplyTo applies the disk cache options to the target configuration.
+func (opts DiskOptions) ApplyTo(target *DiskOptions) {
+	if opts.MaxSize > 0 {
+		target.MaxSize = opts.MaxSize
+	}
+}
+
+// WithMaxSize sets the size cap in bytes above which least recently used
+// entries are evicted.
+func WithMaxSize(size int64) DiskOption {
+	return util.FunctionalOption[DiskOptions](func(opts *DiskOptions) {
+		opts.MaxSize = size
+	})
+}
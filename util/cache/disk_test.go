@@ -0,0 +1,297 @@
+package cache_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util/cache"
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testDiskKeyA = "sha256:aaaa"
+	testDiskKeyB = "sha256:bbbb"
+	testDiskKeyC = "sha256:cccc"
+	testDiskFile = "chart.tgz"
+)
+
+var errTestFill = errors.New("download failed")
+
+// writeFile returns a fill function writing size bytes to a single file.
+func writeFile(size int) func(dir string) error {
+	return func(dir string) error {
+		return os.WriteFile(filepath.Join(dir, testDiskFile), []byte(strings.Repeat("x", size)), 0o600)
+	}
+}
+
+// age sets the recency of an entry, as if it was last used d ago.
+func age(g *WithT, path string, d time.Duration) {
+	past := time.Now().Add(-d)
+	g.Expect(os.Chtimes(path, past, past)).Should(Succeed())
+}
+
+func TestNewDiskCache(t *testing.T) {
+	t.Run("rejects an empty directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := cache.NewDiskCache("")
+
+		g.Expect(err).Should(MatchError(utilerrors.ErrPathEmpty))
+	})
+
+	t.Run("indexes existing entries and removes partial writes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		c, err := cache.NewDiskCache(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = c.Put(testDiskKeyA, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(os.Mkdir(filepath.Join(dir, ".tmp-leftover"), 0o755)).Should(Succeed())
+		g.Expect(os.Mkdir(filepath.Join(dir, ".tmp-filling"), 0o755)).Should(Succeed())
+		age(g, filepath.Join(dir, ".tmp-leftover"), 48*time.Hour)
+
+		reopened, err := cache.NewDiskCache(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(reopened.Size()).Should(Equal(int64(10)))
+		g.Expect(filepath.Join(dir, ".tmp-leftover")).ShouldNot(BeADirectory())
+		g.Expect(filepath.Join(dir, ".tmp-filling")).Should(BeADirectory())
+
+		path, ok := reopened.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeTrue())
+		g.Expect(filepath.Join(path, testDiskFile)).Should(BeARegularFile())
+	})
+}
+
+func TestDiskCache(t *testing.T) {
+	t.Run("stores and returns entries", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c, err := cache.NewDiskCache(t.TempDir())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, ok := c.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeFalse())
+
+		path, err := c.Put(testDiskKeyA, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(filepath.Join(path, testDiskFile)).Should(BeARegularFile())
+
+		got, ok := c.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeTrue())
+		g.Expect(got).Should(Equal(path))
+		g.Expect(c.Size()).Should(Equal(int64(10)))
+	})
+
+	t.Run("does not refill existing entries", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c, err := cache.NewDiskCache(t.TempDir())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = c.Put(testDiskKeyA, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		calls := 0
+		_, err = c.Put(testDiskKeyA, func(dir string) error {
+			calls++
+
+			return writeFile(20)(dir)
+		})
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(calls).Should(Equal(0))
+		g.Expect(c.Size()).Should(Equal(int64(10)))
+	})
+
+	t.Run("discards entries whose fill fails", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		c, err := cache.NewDiskCache(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = c.Put(testDiskKeyA, func(string) error { return errTestFill })
+		g.Expect(err).Should(MatchError(errTestFill))
+
+		_, ok := c.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeFalse())
+
+		items, err := os.ReadDir(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(items).Should(BeEmpty())
+	})
+
+	t.Run("rejects keys escaping the cache directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c, err := cache.NewDiskCache(t.TempDir())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		for _, key := range []string{"", "../etc", "a/b", ".hidden", "sha256:..x"} {
+			_, err := c.Put(key, writeFile(1))
+			g.Expect(err).Should(MatchError(cache.ErrInvalidDiskKey), key)
+		}
+	})
+
+	t.Run("keeps keys differing only in punctuation or case apart", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c, err := cache.NewDiskCache(t.TempDir())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		pathA, err := c.Put("sha256:abc", writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		pathB, err := c.Put("sha256-abc", writeFile(20))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		pathC, err := c.Put("SHA256:ABC", writeFile(30))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(pathA).ShouldNot(Equal(pathB))
+		g.Expect(pathA).ShouldNot(Equal(pathC))
+		g.Expect(c.Size()).Should(Equal(int64(60)))
+	})
+
+	t.Run("shares entries between caches on the same directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		first, err := cache.NewDiskCache(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		second, err := cache.NewDiskCache(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		path, err := first.Put(testDiskKeyA, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		got, ok := second.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeTrue())
+		g.Expect(got).Should(Equal(path))
+		g.Expect(second.Size()).Should(Equal(int64(10)))
+	})
+
+	t.Run("uses the entry stored by another cache while filling", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		first, err := cache.NewDiskCache(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		second, err := cache.NewDiskCache(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		var stored string
+
+		path, err := second.Put(testDiskKeyA, func(dir string) error {
+			var err error
+
+			stored, err = first.Put(testDiskKeyA, writeFile(10))
+			if err != nil {
+				return err
+			}
+
+			return writeFile(20)(dir)
+		})
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(path).Should(Equal(stored))
+		g.Expect(second.Size()).Should(Equal(int64(10)))
+
+		items, err := os.ReadDir(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(items).Should(HaveLen(1))
+	})
+
+	t.Run("evicts least recently used entries above the size cap", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c, err := cache.NewDiskCache(t.TempDir(), cache.WithMaxSize(25))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		pathA, err := c.Put(testDiskKeyA, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		pathB, err := c.Put(testDiskKeyB, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		age(g, pathA, 2*time.Hour)
+		age(g, pathB, time.Hour)
+
+		// Touching A makes B the least recently used entry.
+		_, ok := c.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeTrue())
+
+		_, err = c.Put(testDiskKeyC, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, ok = c.Get(testDiskKeyB)
+		g.Expect(ok).Should(BeFalse())
+		g.Expect(pathB).ShouldNot(BeADirectory())
+
+		_, ok = c.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeTrue())
+		_, ok = c.Get(testDiskKeyC)
+		g.Expect(ok).Should(BeTrue())
+		g.Expect(c.Size()).Should(Equal(int64(20)))
+	})
+
+	t.Run("keeps an entry larger than the cap", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c, err := cache.NewDiskCache(t.TempDir(), cache.WithMaxSize(5))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = c.Put(testDiskKeyA, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, ok := c.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeTrue())
+	})
+
+	t.Run("purges all entries", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c, err := cache.NewDiskCache(t.TempDir())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		pathA, err := c.Put(testDiskKeyA, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		_, err = c.Put(testDiskKeyB, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(c.Purge()).Should(Succeed())
+		g.Expect(c.Size()).Should(BeZero())
+		g.Expect(pathA).ShouldNot(BeADirectory())
+
+		_, ok := c.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeFalse())
+	})
+
+	t.Run("purges entries stored by other processes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		first, err := cache.NewDiskCache(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		second, err := cache.NewDiskCache(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		path, err := first.Put(testDiskKeyA, writeFile(10))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(second.Purge()).Should(Succeed())
+		g.Expect(path).ShouldNot(BeADirectory())
+
+		_, ok := second.Get(testDiskKeyA)
+		g.Expect(ok).Should(BeFalse())
+	})
+}
@@ -0,0 +1,227 @@
+// Package diff compares two rendered sets of Kubernetes objects.
+package diff
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// ErrDuplicateObject is returned when a set contains the same object twice.
+var ErrDuplicateObject = errors.New("duplicate object")
+
+// ChangeType describes how an object differs between two sets.
+type ChangeType string
+
+const (
+	// Added marks an object only present in the second set.
+	Added ChangeType = "added"
+
+	// Removed marks an object only present in the first set.
+	Removed ChangeType = "removed"
+
+	// Changed marks an object present in both sets with different content.
+	Changed ChangeType = "changed"
+)
+
+// Change is the difference for a single object.
+type Change struct {
+	// Key identifies the object.
+	Key k8s.ResourceKey
+
+	// Type is the kind of change.
+	Type ChangeType
+
+	// Before is the object in the first set; nil when Added.
+	Before *unstructured.Unstructured
+
+	// After is the object in the second set; nil when Removed.
+	After *unstructured.Unstructured
+
+	// Paths lists the changed fields of a Changed object, such as
+	// "spec.replicas" or "spec.template.spec.containers[0].image".
+	Paths []string
+
+	// Fields holds the changed fields of a Changed object with their values,
+	// in the order of Paths.
+	Fields []FieldChange
+}
+
+// FieldChange is a changed field of an object. Its values are those of the
+// compared objects, not copies.
+type FieldChange struct {
+	// Path locates the field, e.g. "spec.replicas".
+	Path string `json:"path" yaml:"path"`
+
+	// Before is the value in the first set; nil when the field was added.
+	Before any `json:"before,omitempty" yaml:"before,omitempty"`
+
+	// After is the value in the second set; nil when the field was removed.
+	After any `json:"after,omitempty" yaml:"after,omitempty"`
+
+	// Sensitive reports that the values were redacted, e.g. Secret data in
+	// a Plan.
+	Sensitive bool `json:"sensitive,omitempty" yaml:"sensitive,omitempty"`
+}
+
+// Result holds the differences between two sets, ordered by object key.
+type Result struct {
+	Changes []Change
+}
+
+// Empty reports whether the two sets are identical.
+func (r Result) Empty() bool {
+	return len(r.Changes) == 0
+}
+
+// Of returns the changes of the given type.
+func (r Result) Of(changeType ChangeType) []Change {
+	var result []Change
+
+	for _, c := range r.Changes {
+		if c.Type == changeType {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// Objects compares two sets of objects. Objects are matched by
+// k8s.ResourceKey, so moving an object to another API version of its group is
+// reported as a content change rather than a removal plus an addition.
+//
+// Content is compared semantically: the order of map keys and of objects in
+// the sets does not matter, while list order does.
+func Objects(before []unstructured.Unstructured, after []unstructured.Unstructured) (Result, error) {
+	beforeByKey, err := index(before)
+	if err != nil {
+		return Result{}, fmt.Errorf("before: %w", err)
+	}
+
+	afterByKey, err := index(after)
+	if err != nil {
+		return Result{}, fmt.Errorf("after: %w", err)
+	}
+
+	result := Result{}
+
+	for key, b := range beforeByKey {
+		a, ok := afterByKey[key]
+		if !ok {
+			result.Changes = append(result.Changes, Change{Key: key, Type: Removed, Before: b})
+
+			continue
+		}
+
+		fields := changedFields("", b.Object, a.Object, nil)
+		if len(fields) > 0 {
+			result.Changes = append(result.Changes, Change{Key: key, Type: Changed, Before: b, After: a, Paths: fieldPaths(fields), Fields: fields})
+		}
+	}
+
+	for key, a := range afterByKey {
+		if _, ok := beforeByKey[key]; !ok {
+			result.Changes = append(result.Changes, Change{Key: key, Type: Added, After: a})
+		}
+	}
+
+	slices.SortFunc(result.Changes, func(x Change, y Change) int {
+		return cmp.Compare(x.Key.String(), y.Key.String())
+	})
+
+	return result, nil
+}
+
+func index(objects []unstructured.Unstructured) (map[k8s.ResourceKey]*unstructured.Unstructured, error) {
+	result := make(map[k8s.ResourceKey]*unstructured.Unstructured, len(objects))
+
+	for i := range objects {
+		key := k8s.KeyOf(&objects[i])
+		if _, ok := result[key]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateObject, key)
+		}
+
+		result[key] = &objects[i]
+	}
+
+	return result, nil
+}
+
+// changedFields appends to fields the fields at which a and b differ, sorted
+// by path.
+func changedFields(prefix string, a any, b any, fields []FieldChange) []FieldChange {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			return append(fields, FieldChange{Path: rootPath(prefix), Before: a, After: b})
+		}
+
+		keys := make([]string, 0, len(av)+len(bv))
+		for k := range av {
+			keys = append(keys, k)
+		}
+
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			child := k
+			if prefix != "" {
+				child = prefix + "." + k
+			}
+
+			fields = changedFields(child, av[k], bv[k], fields)
+		}
+
+		return fields
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return append(fields, FieldChange{Path: rootPath(prefix), Before: a, After: b})
+		}
+
+		for i := range av {
+			fields = changedFields(prefix+"["+strconv.Itoa(i)+"]", av[i], bv[i], fields)
+		}
+
+		return fields
+	default:
+		if !reflect.DeepEqual(a, b) {
+			return append(fields, FieldChange{Path: rootPath(prefix), Before: a, After: b})
+		}
+
+		return fields
+	}
+}
+
+// fieldPaths returns the paths of fields.
+func fieldPaths(fields []FieldChange) []string {
+	paths := make([]string, 0, len(fields))
+	for _, f := range fields {
+		paths = append(paths, f.Path)
+	}
+
+	return paths
+}
+
+func rootPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+
+	return path
+}
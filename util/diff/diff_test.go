@@ -0,0 +1,179 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/diff"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const beforeYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: apps
+data:
+  a: "1"
+  b: "2"
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.0
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: legacy
+  namespace: apps
+`
+
+const afterYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  namespace: apps
+  name: config
+data:
+  b: "2"
+  a: "1"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  namespace: apps
+`
+
+const duplicateYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`
+
+func TestObjects(t *testing.T) {
+	t.Run("reports added, removed and changed objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		before, err := k8s.DecodeYAML([]byte(beforeYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		after, err := k8s.DecodeYAML([]byte(afterYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Objects(before, after)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result.Empty()).Should(BeFalse())
+		g.Expect(result.Changes).Should(HaveLen(3))
+
+		changed := result.Of(diff.Changed)
+		g.Expect(changed).Should(HaveLen(1))
+		g.Expect(changed[0].Key.String()).Should(Equal("apps/Deployment/apps/web"))
+		g.Expect(changed[0].Paths).Should(Equal([]string{
+			"spec.replicas",
+			"spec.template.spec.containers[0].image",
+		}))
+
+		added := result.Of(diff.Added)
+		g.Expect(added).Should(HaveLen(1))
+		g.Expect(added[0].Key.String()).Should(Equal("core/Secret/apps/credentials"))
+		g.Expect(added[0].Before).Should(BeNil())
+
+		removed := result.Of(diff.Removed)
+		g.Expect(removed).Should(HaveLen(1))
+		g.Expect(removed[0].Key.String()).Should(Equal("core/Service/apps/legacy"))
+		g.Expect(removed[0].After).Should(BeNil())
+	})
+
+	t.Run("orders changes by key", func(t *testing.T) {
+		g := NewWithT(t)
+
+		before, err := k8s.DecodeYAML([]byte(beforeYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		after, err := k8s.DecodeYAML([]byte(afterYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Objects(before, after)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		keys := make([]string, 0, len(result.Changes))
+		for _, c := range result.Changes {
+			keys = append(keys, c.Key.String())
+		}
+
+		g.Expect(keys).Should(Equal([]string{
+			"apps/Deployment/apps/web",
+			"core/Secret/apps/credentials",
+			"core/Service/apps/legacy",
+		}))
+	})
+
+	t.Run("reports no changes for identical sets", func(t *testing.T) {
+		g := NewWithT(t)
+
+		before, err := k8s.DecodeYAML([]byte(beforeYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Objects(before, before)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result.Empty()).Should(BeTrue())
+	})
+
+	t.Run("reports an added field", func(t *testing.T) {
+		g := NewWithT(t)
+
+		before, err := k8s.DecodeYAML([]byte(beforeYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		after, err := k8s.DecodeYAML([]byte(beforeYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		after[0].SetLabels(map[string]string{"team": "platform"})
+
+		result, err := diff.Objects(before, after)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result.Changes).Should(HaveLen(1))
+		g.Expect(result.Changes[0].Paths).Should(Equal([]string{"metadata.labels"}))
+	})
+
+	t.Run("rejects duplicate objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		duplicates, err := k8s.DecodeYAML([]byte(duplicateYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = diff.Objects(duplicates, nil)
+		g.Expect(err).Should(MatchError(diff.ErrDuplicateObject))
+	})
+}
@@ -0,0 +1,204 @@
+package diff
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// serverFields are the fields populated by the API server, never compared by
+// Live.
+var serverFields = []string{
+	"status",
+	"metadata.uid",
+	"metadata.resourceVersion",
+	"metadata.generation",
+	"metadata.creationTimestamp",
+	"metadata.deletionTimestamp",
+	"metadata.deletionGracePeriodSeconds",
+	"metadata.managedFields",
+	"metadata.selfLink",
+	"metadata.annotations.kubectl.kubernetes.io/last-applied-configuration",
+	"metadata.annotations.deployment.kubernetes.io/revision",
+}
+
+// Live compares objects read from the target cluster with rendered objects,
+// like kubectl diff: rendered objects missing from live are Added, live
+// objects missing from rendered are Removed, and objects whose fields differ
+// are Changed. Pass as live the objects of the previous render, e.g. listed in
+// its inventory, for Removed to report the objects an apply would prune.
+//
+// Fields populated by the API server, such as status, the UID or managed
+// fields, are never compared, nor are the fields of WithIgnoredFields rules.
+// By default only the fields set in the rendered objects are compared, since
+// the API server defaults the others; with WithFullComparison, all fields are
+// compared, which is accurate when the rendered objects are the results of a
+// server-side apply dry-run.
+func Live(live []unstructured.Unstructured, rendered []unstructured.Unstructured, opts ...LiveOption) (Result, error) {
+	options := LiveOptions{}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	liveByKey, err := index(live)
+	if err != nil {
+		return Result{}, fmt.Errorf("live: %w", err)
+	}
+
+	renderedByKey, err := index(rendered)
+	if err != nil {
+		return Result{}, fmt.Errorf("rendered: %w", err)
+	}
+
+	result := Result{}
+
+	for key, l := range liveByKey {
+		if _, ok := renderedByKey[key]; !ok {
+			result.Changes = append(result.Changes, Change{Key: key, Type: Removed, Before: l})
+		}
+	}
+
+	for key, r := range renderedByKey {
+		l, ok := liveByKey[key]
+		if !ok {
+			result.Changes = append(result.Changes, Change{Key: key, Type: Added, After: r})
+
+			continue
+		}
+
+		ignored := slices.Concat(serverFields, options.ignored(r.GetKind()))
+		before := prune("", l.Object, ignored)
+		after := prune("", r.Object, ignored)
+
+		var fields []FieldChange
+		if options.Full {
+			fields = changedFields("", before, after, nil)
+		} else {
+			fields = renderedFields("", before, after, nil)
+		}
+
+		if len(fields) > 0 {
+			result.Changes = append(result.Changes, Change{Key: key, Type: Changed, Before: l, After: r, Paths: fieldPaths(fields), Fields: fields})
+		}
+	}
+
+	slices.SortFunc(result.Changes, func(x Change, y Change) int {
+		return cmp.Compare(x.Key.String(), y.Key.String())
+	})
+
+	return result, nil
+}
+
+// ignored returns the paths ignored for objects of kind.
+func (opts LiveOptions) ignored(kind string) []string {
+	var paths []string
+
+	for _, rule := range opts.Ignore {
+		if rule.Kind == "" || rule.Kind == kind {
+			paths = append(paths, rule.Paths...)
+		}
+	}
+
+	return paths
+}
+
+// prune returns a copy of value at prefix without the ignored fields, nor the
+// maps left empty by their removal.
+func prune(prefix string, value any, ignored []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+
+		for k, child := range v {
+			path := k
+			if prefix != "" {
+				path = prefix + "." + k
+			}
+
+			if slices.Contains(ignored, path) {
+				continue
+			}
+
+			pruned := prune(path, child, ignored)
+			if emptied(child, pruned) {
+				continue
+			}
+
+			result[k] = pruned
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(v))
+
+		for i := range v {
+			result[i] = prune(prefix+"["+strconv.Itoa(i)+"]", v[i], ignored)
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// emptied reports whether pruning left a non-empty map empty.
+func emptied(value any, pruned any) bool {
+	before, _ := value.(map[string]any)
+	after, _ := pruned.(map[string]any)
+
+	return len(before) > 0 && after != nil && len(after) == 0
+}
+
+// renderedFields appends to fields the fields set in rendered that differ in
+// live, sorted by path.
+func renderedFields(prefix string, live any, rendered any, fields []FieldChange) []FieldChange {
+	switch rv := rendered.(type) {
+	case map[string]any:
+		lv, ok := live.(map[string]any)
+		if !ok {
+			return append(fields, FieldChange{Path: rootPath(prefix), Before: live, After: rendered})
+		}
+
+		keys := make([]string, 0, len(rv))
+		for k := range rv {
+			keys = append(keys, k)
+		}
+
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			child := k
+			if prefix != "" {
+				child = prefix + "." + k
+			}
+
+			fields = renderedFields(child, lv[k], rv[k], fields)
+		}
+
+		return fields
+	case []any:
+		lv, ok := live.([]any)
+		if !ok || len(lv) != len(rv) {
+			return append(fields, FieldChange{Path: rootPath(prefix), Before: live, After: rendered})
+		}
+
+		for i := range rv {
+			fields = renderedFields(prefix+"["+strconv.Itoa(i)+"]", lv[i], rv[i], fields)
+		}
+
+		return fields
+	case nil:
+		return fields
+	default:
+		if !reflect.DeepEqual(live, rendered) {
+			return append(fields, FieldChange{Path: rootPath(prefix), Before: live, After: rendered})
+		}
+
+		return fields
+	}
+}
@@ -0,0 +1,55 @@
+package diff
+
+import (
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// LiveOption is a generic option for Live.
+type LiveOption = util.Option[LiveOptions]
+
+// IgnoreRule excludes fields from the comparison of live and rendered objects.
+type IgnoreRule struct {
+	// Kind restricts the rule to objects of a kind; empty matches all kinds.
+	Kind string
+
+	// Paths are the ignored fields and their children, e.g. "spec.replicas"
+	// or "metadata.annotations".
+	Paths []string
+}
+
+// LiveOptions is a struct-based option that can set live diff options.
+type LiveOptions struct {
+	// Ignore lists fields not compared, in addition to the fields populated
+	// by the API server.
+	Ignore []IgnoreRule
+
+	// Full compares all fields rather than only the fields set in the
+	// rendered objects.
+	Full bool
+}
+
+// ApplyTo applies the live diff options to the target configuration.
+func (opts LiveOptions) ApplyTo(target *LiveOptions) {
+	target.Ignore = append(target.Ignore, opts.Ignore...)
+
+	if opts.Full {
+		target.Full = true
+	}
+}
+
+// WithIgnoredFields ignores fields of the objects of kind, or of all objects
+// when kind is empty, e.g. the replicas of autoscaled Deployments.
+func WithIgnoredFields(kind string, paths ...string) LiveOption {
+	return util.FunctionalOption[LiveOptions](func(opts *LiveOptions) {
+		opts.Ignore = append(opts.Ignore, IgnoreRule{Kind: kind, Paths: paths})
+	})
+}
+
+// WithFullComparison compares all fields of the objects, for rendered objects
+// returned by a server-side apply dry-run, which hold every field the live
+// objects will have after applying.
+func WithFullComparison() LiveOption {
+	return util.FunctionalOption[LiveOptions](func(opts *LiveOptions) {
+		opts.Full = true
+	})
+}
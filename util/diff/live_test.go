@@ -0,0 +1,175 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/diff"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const liveYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+  uid: 6f1c
+  resourceVersion: "1042"
+  generation: 3
+  creationTimestamp: "2026-01-01T00:00:00Z"
+  annotations:
+    deployment.kubernetes.io/revision: "3"
+    kubectl.kubernetes.io/last-applied-configuration: "{}"
+  managedFields:
+  - manager: kubectl
+spec:
+  replicas: 5
+  progressDeadlineSeconds: 600
+  strategy:
+    type: RollingUpdate
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.0
+        imagePullPolicy: IfNotPresent
+status:
+  readyReplicas: 5
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: apps
+  uid: 9a2d
+data:
+  a: "1"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: legacy
+  namespace: apps
+`
+
+const renderedYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: apps
+data:
+  a: "1"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  namespace: apps
+`
+
+func TestLive(t *testing.T) {
+	t.Run("compares fields set in the rendered objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		live, err := k8s.DecodeYAML([]byte(liveYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		rendered, err := k8s.DecodeYAML([]byte(renderedYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Live(live, rendered)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result.Changes).Should(HaveLen(3))
+
+		changed := result.Of(diff.Changed)
+		g.Expect(changed).Should(HaveLen(1))
+		g.Expect(changed[0].Key.String()).Should(Equal("apps/Deployment/apps/web"))
+		g.Expect(changed[0].Paths).Should(Equal([]string{
+			"spec.replicas",
+			"spec.template.spec.containers[0].image",
+		}))
+
+		g.Expect(result.Of(diff.Added)[0].Key.String()).Should(Equal("core/Secret/apps/credentials"))
+		g.Expect(result.Of(diff.Removed)[0].Key.String()).Should(Equal("core/Service/apps/legacy"))
+	})
+
+	t.Run("skips ignored fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		live, err := k8s.DecodeYAML([]byte(liveYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		rendered, err := k8s.DecodeYAML([]byte(renderedYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Live(live, rendered, diff.WithIgnoredFields("Deployment", "spec.replicas"))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		changed := result.Of(diff.Changed)
+		g.Expect(changed).Should(HaveLen(1))
+		g.Expect(changed[0].Paths).Should(Equal([]string{"spec.template.spec.containers[0].image"}))
+	})
+
+	t.Run("applies ignore rules to their kind only", func(t *testing.T) {
+		g := NewWithT(t)
+
+		live, err := k8s.DecodeYAML([]byte(liveYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		rendered, err := k8s.DecodeYAML([]byte(renderedYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Live(live, rendered, diff.WithIgnoredFields("StatefulSet", "spec"))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result.Of(diff.Changed)[0].Paths).Should(HaveLen(2))
+	})
+
+	t.Run("compares all fields with full comparison", func(t *testing.T) {
+		g := NewWithT(t)
+
+		live, err := k8s.DecodeYAML([]byte(liveYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		rendered, err := k8s.DecodeYAML([]byte(renderedYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Live(live, rendered, diff.WithFullComparison())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		changed := result.Of(diff.Changed)
+		g.Expect(changed).Should(HaveLen(1))
+		g.Expect(changed[0].Paths).Should(Equal([]string{
+			"spec.progressDeadlineSeconds",
+			"spec.replicas",
+			"spec.strategy",
+			"spec.template.spec.containers[0].image",
+			"spec.template.spec.containers[0].imagePullPolicy",
+		}))
+	})
+
+	t.Run("rejects duplicate objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		duplicates, err := k8s.DecodeYAML([]byte(duplicateYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = diff.Live(duplicates, nil)
+		g.Expect(err).Should(MatchError(diff.ErrDuplicateObject))
+	})
+}
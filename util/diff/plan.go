@@ -0,0 +1,239 @@
+package diff
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const yamlIndent = 2
+
+// secretDataFields are the fields of a Secret holding its sensitive data.
+var secretDataFields = []string{"data", "stringData"}
+
+// PlanEntry is an object an apply creates, updates or deletes.
+type PlanEntry struct {
+	Group     string `json:"group,omitempty"     yaml:"group,omitempty"`
+	Kind      string `json:"kind"                yaml:"kind"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      string `json:"name"                yaml:"name"`
+
+	// Changes lists the changed fields of an updated object with their
+	// current and planned values. The values of Secret data are redacted and
+	// only their paths are listed.
+	Changes []FieldChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// Key returns the ResourceKey of the entry.
+func (e PlanEntry) Key() k8s.ResourceKey {
+	return k8s.ResourceKey{
+		Group:     e.Group,
+		Kind:      e.Kind,
+		Namespace: e.Namespace,
+		Name:      e.Name,
+	}
+}
+
+// Plan summarizes what an apply would do, in the style of "terraform plan",
+// e.g. for CI to comment on pull requests.
+type Plan struct {
+	// Create lists the objects to create, in apply order.
+	Create []PlanEntry `json:"create" yaml:"create"`
+
+	// Update lists the objects to update, in apply order.
+	Update []PlanEntry `json:"update" yaml:"update"`
+
+	// Delete lists the objects to delete, in deletion order.
+	Delete []PlanEntry `json:"delete" yaml:"delete"`
+}
+
+// NewPlan returns the plan of an apply turning the first set of a diff into
+// the second: Added objects are created, Changed objects updated and Removed
+// objects deleted. With a Result of Live, it previews an apply of the
+// rendered objects, pruning the live objects missing from the render.
+//
+// Creates and updates follow the apply order (see k8s.ApplyPriority) and
+// deletes its reverse, with ties ordered by resource key.
+func NewPlan(result Result) Plan {
+	plan := Plan{
+		Create: []PlanEntry{},
+		Update: []PlanEntry{},
+		Delete: []PlanEntry{},
+	}
+
+	for _, c := range result.Changes {
+		entry := PlanEntry{
+			Group:     c.Key.Group,
+			Kind:      c.Key.Kind,
+			Namespace: c.Key.Namespace,
+			Name:      c.Key.Name,
+		}
+
+		switch c.Type {
+		case Added:
+			plan.Create = append(plan.Create, entry)
+		case Changed:
+			entry.Changes = planChanges(c.Key, c.Fields)
+			plan.Update = append(plan.Update, entry)
+		case Removed:
+			plan.Delete = append(plan.Delete, entry)
+		}
+	}
+
+	applyOrder := func(a PlanEntry, b PlanEntry) int {
+		return cmp.Or(
+			cmp.Compare(k8s.ApplyPriority(a.Kind), k8s.ApplyPriority(b.Kind)),
+			cmp.Compare(a.Key().String(), b.Key().String()),
+		)
+	}
+
+	slices.SortStableFunc(plan.Create, applyOrder)
+	slices.SortStableFunc(plan.Update, applyOrder)
+	slices.SortStableFunc(plan.Delete, func(a PlanEntry, b PlanEntry) int {
+		return applyOrder(b, a)
+	})
+
+	return plan
+}
+
+// planChanges returns fields with the values of sensitive fields, i.e. the
+// data of a Secret, redacted.
+func planChanges(key k8s.ResourceKey, fields []FieldChange) []FieldChange {
+	if key.Group != "" || key.Kind != "Secret" {
+		return fields
+	}
+
+	changes := make([]FieldChange, 0, len(fields))
+
+	for _, f := range fields {
+		if isSecretData(f.Path) {
+			f = FieldChange{Path: f.Path, Sensitive: true}
+		}
+
+		changes = append(changes, f)
+	}
+
+	return changes
+}
+
+// isSecretData reports whether path is, or is nested in, a data field of a
+// Secret.
+func isSecretData(path string) bool {
+	for _, field := range secretDataFields {
+		if path == field || strings.HasPrefix(path, field+".") || strings.HasPrefix(path, field+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Empty reports whether the apply changes nothing.
+func (p Plan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// String returns the counts of the plan, e.g.
+// "Plan: 1 to create, 2 to update, 0 to delete.".
+func (p Plan) String() string {
+	return fmt.Sprintf("Plan: %d to create, %d to update, %d to delete.", len(p.Create), len(p.Update), len(p.Delete))
+}
+
+// WriteJSON writes the plan to w as an indented JSON document with create,
+// update and delete lists, always present.
+func (p Plan) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(p); err != nil {
+		return fmt.Errorf("unable to write plan: %w", err)
+	}
+
+	return nil
+}
+
+// WriteYAML writes the plan to w as a YAML document with the fields of
+// WriteJSON.
+func (p Plan) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(yamlIndent)
+
+	if err := enc.Encode(p); err != nil {
+		return fmt.Errorf("unable to write plan: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("unable to write plan: %w", err)
+	}
+
+	return nil
+}
+
+// WriteMarkdown writes the plan to w as GitHub-flavored Markdown: the counts
+// followed by a table of the objects and, for updates, their changed fields
+// with the current and planned values as JSON, e.g. `spec.replicas`: `1` →
+// `3`. Redacted values are shown as (sensitive), as terraform plan does.
+func (p Plan) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	_, _ = fmt.Fprintf(&b, "%s\n", p)
+
+	if !p.Empty() {
+		b.WriteString("\n| Action | Object | Changes |\n|--------|--------|---------|\n")
+
+		writeRows := func(action string, entries []PlanEntry) {
+			for _, e := range entries {
+				changes := make([]string, 0, len(e.Changes))
+				for _, c := range e.Changes {
+					change := "`" + markdownCell(c.Path) + "`: "
+					if c.Sensitive {
+						change += "(sensitive)"
+					} else {
+						change += markdownValue(c.Before) + " → " + markdownValue(c.After)
+					}
+
+					changes = append(changes, change)
+				}
+
+				_, _ = fmt.Fprintf(&b, "| %s | %s | %s |\n", action, markdownCell(e.Key().String()), strings.Join(changes, "<br>"))
+			}
+		}
+
+		writeRows("create", p.Create)
+		writeRows("update", p.Update)
+		writeRows("delete", p.Delete)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("unable to write plan: %w", err)
+	}
+
+	return nil
+}
+
+// markdownValue formats a field value for a Markdown table cell.
+func markdownValue(value any) string {
+	if value == nil {
+		return "(unset)"
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		data = []byte(fmt.Sprint(value))
+	}
+
+	return "`" + markdownCell(string(data)) + "`"
+}
+
+// markdownCell escapes s for use in a Markdown table cell.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(s)
+}
@@ -0,0 +1,270 @@
+package diff_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/diff"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const expectedPlanMarkdown = "Plan: 1 to create, 1 to update, 1 to delete.\n" + `
+| Action | Object | Changes |
+|--------|--------|---------|
+| create | core/Secret/apps/credentials |  |
+| update | apps/Deployment/apps/web | ` + "`spec.replicas`: `1` → `3`<br>`spec.template.spec.containers[0].image`: `\"nginx:1.0\"` → `\"nginx:1.1\"`" + ` |
+| delete | core/Service/apps/legacy |  |
+`
+
+const expectedPlanYAML = `create:
+  - kind: Secret
+    namespace: apps
+    name: credentials
+update:
+  - group: apps
+    kind: Deployment
+    namespace: apps
+    name: web
+    changes:
+      - path: spec.replicas
+        before: 1
+        after: 3
+      - path: spec.template.spec.containers[0].image
+        before: nginx:1.0
+        after: nginx:1.1
+delete:
+  - kind: Service
+    namespace: apps
+    name: legacy
+`
+
+const expectedPlanJSON = `{
+  "create": [
+    {
+      "kind": "Secret",
+      "namespace": "apps",
+      "name": "credentials"
+    }
+  ],
+  "update": [
+    {
+      "group": "apps",
+      "kind": "Deployment",
+      "namespace": "apps",
+      "name": "web",
+      "changes": [
+        {
+          "path": "spec.replicas",
+          "before": 1,
+          "after": 3
+        },
+        {
+          "path": "spec.template.spec.containers[0].image",
+          "before": "nginx:1.0",
+          "after": "nginx:1.1"
+        }
+      ]
+    }
+  ],
+  "delete": [
+    {
+      "kind": "Service",
+      "namespace": "apps",
+      "name": "legacy"
+    }
+  ]
+}
+`
+
+const prunedYAML = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: legacy
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+  namespace: legacy
+`
+
+const unlabeledYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  mode: fast
+`
+
+const labeledYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  labels:
+    team: platform
+`
+
+const oldSecretYAML = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  labels:
+    rotation: "1"
+stringData:
+  password: hunter2
+`
+
+const newSecretYAML = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  labels:
+    rotation: "2"
+stringData:
+  password: s3cr3t
+data:
+  token: dG9rZW4=
+`
+
+func newTestPlan(g *WithT) diff.Plan {
+	before, err := k8s.DecodeYAML([]byte(beforeYAML))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	after, err := k8s.DecodeYAML([]byte(afterYAML))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	result, err := diff.Objects(before, after)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return diff.NewPlan(result)
+}
+
+func TestPlan(t *testing.T) {
+	t.Run("should group changes by action", func(t *testing.T) {
+		g := NewWithT(t)
+
+		plan := newTestPlan(g)
+
+		g.Expect(plan.Empty()).Should(BeFalse())
+		g.Expect(plan.String()).Should(Equal("Plan: 1 to create, 1 to update, 1 to delete."))
+		g.Expect(plan.Update).Should(HaveExactElements(HaveField("Changes", HaveExactElements(
+			diff.FieldChange{Path: "spec.replicas", Before: int64(1), After: int64(3)},
+			diff.FieldChange{Path: "spec.template.spec.containers[0].image", Before: "nginx:1.0", After: "nginx:1.1"},
+		))))
+	})
+
+	t.Run("should delete in reverse apply order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		pruned, err := k8s.DecodeYAML([]byte(prunedYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Objects(pruned, nil)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(diff.NewPlan(result).Delete).Should(HaveExactElements(
+			HaveField("Kind", "Widget"),
+			HaveField("Kind", "CustomResourceDefinition"),
+			HaveField("Kind", "Namespace"),
+		))
+	})
+
+	t.Run("should show unset values of added and removed fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		before, err := k8s.DecodeYAML([]byte(unlabeledYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		after, err := k8s.DecodeYAML([]byte(labeledYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Objects(before, after)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		g.Expect(diff.NewPlan(result).WriteMarkdown(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(ContainSubstring("`data`: `{\"mode\":\"fast\"}` → (unset)<br>`metadata.labels`: (unset) → `{\"team\":\"platform\"}`"))
+	})
+
+	t.Run("should redact Secret data", func(t *testing.T) {
+		g := NewWithT(t)
+
+		before, err := k8s.DecodeYAML([]byte(oldSecretYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		after, err := k8s.DecodeYAML([]byte(newSecretYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := diff.Objects(before, after)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		plan := diff.NewPlan(result)
+		g.Expect(plan.Update).Should(HaveLen(1))
+		g.Expect(plan.Update[0].Changes).Should(Equal([]diff.FieldChange{
+			{Path: "data", Sensitive: true},
+			{Path: "metadata.labels.rotation", Before: "1", After: "2"},
+			{Path: "stringData.password", Sensitive: true},
+		}))
+
+		var buf bytes.Buffer
+		g.Expect(plan.WriteMarkdown(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(ContainSubstring("`data`: (sensitive)<br>`metadata.labels.rotation`: `\"1\"` → `\"2\"`<br>`stringData.password`: (sensitive)"))
+		g.Expect(buf.String()).ShouldNot(ContainSubstring("hunter2"))
+		g.Expect(buf.String()).ShouldNot(ContainSubstring("s3cr3t"))
+
+		buf.Reset()
+		g.Expect(plan.WriteJSON(&buf)).Should(Succeed())
+		g.Expect(buf.String()).ShouldNot(ContainSubstring("s3cr3t"))
+		g.Expect(buf.String()).Should(ContainSubstring(`"sensitive": true`))
+
+		g.Expect(result.Changes[0].Fields).Should(ContainElement(HaveField("After", "s3cr3t")))
+	})
+
+	t.Run("should report empty plans", func(t *testing.T) {
+		g := NewWithT(t)
+
+		plan := diff.NewPlan(diff.Result{})
+
+		var buf bytes.Buffer
+		g.Expect(plan.WriteMarkdown(&buf)).Should(Succeed())
+
+		g.Expect(plan.Empty()).Should(BeTrue())
+		g.Expect(buf.String()).Should(Equal("Plan: 0 to create, 0 to update, 0 to delete.\n"))
+	})
+
+	t.Run("should write Markdown", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(newTestPlan(g).WriteMarkdown(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(Equal(expectedPlanMarkdown))
+	})
+
+	t.Run("should write YAML", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(newTestPlan(g).WriteYAML(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(Equal(expectedPlanYAML))
+	})
+
+	t.Run("should write JSON", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(newTestPlan(g).WriteJSON(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(Equal(expectedPlanJSON))
+	})
+}
@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stage identifies the pipeline step in which a failure happened.
+type Stage string
+
+const (
+	// StageRender is the rendering of a source.
+	StageRender Stage = "render"
+
+	// StageTransform is a transformer run on rendered objects.
+	StageTransform Stage = "transform"
+
+	// StageFilter is a filter run on rendered objects.
+	StageFilter Stage = "filter"
+
+	// StageValidate is a validation of rendered objects.
+	StageValidate Stage = "validate"
+)
+
+// StageError is a failure attributed to a source and a pipeline stage.
+type StageError struct {
+	// Source names the source the failure relates to; empty for failures not
+	// tied to a single source.
+	Source string
+
+	// Stage is the step that failed.
+	Stage Stage
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *StageError) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf("%s: %v", e.Stage, e.Err)
+	}
+
+	return fmt.Sprintf("%s %s: %v", e.Stage, e.Source, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// RenderError aggregates every failure of a render instead of only the first
+// one. errors.Is and errors.As match any of the aggregated errors.
+//
+// Example:
+//
+//	var errs utilerrors.RenderError
+//	for _, s := range sources {
+//	    if _, err := s.Render(ctx); err != nil {
+//	        errs.Add(s.Name(), utilerrors.StageRender, err)
+//	    }
+//	}
+//	return errs.ErrorOrNil()
+type RenderError struct {
+	// Errors are the failures in the order they were added.
+	Errors []*StageError
+}
+
+// Add records a failure; nil errors are ignored.
+func (e *RenderError) Add(source string, stage Stage, err error) {
+	if err == nil {
+		return
+	}
+
+	e.Errors = append(e.Errors, &StageError{Source: source, Stage: stage, Err: err})
+}
+
+// ErrorOrNil returns e if any failure was recorded, and nil otherwise.
+func (e *RenderError) ErrorOrNil() error {
+	if e == nil || len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+func (e *RenderError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "%d errors occurred:", len(e.Errors))
+	for _, err := range e.Errors {
+		sb.WriteString("\n\t* ")
+		sb.WriteString(err.Error())
+	}
+
+	return sb.String()
+}
+
+// Unwrap returns the aggregated errors.
+func (e *RenderError) Unwrap() []error {
+	result := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		result[i] = err
+	}
+
+	return result
+}
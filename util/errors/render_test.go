@@ -0,0 +1,58 @@
+package errors_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+
+	. "github.com/onsi/gomega"
+)
+
+var errTestTemplate = errors.New("template failed")
+
+func TestRenderError(t *testing.T) {
+	t.Run("is nil without failures", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var errs utilerrors.RenderError
+		errs.Add("web", utilerrors.StageRender, nil)
+
+		g.Expect(errs.ErrorOrNil()).Should(Succeed())
+	})
+
+	t.Run("formats a single failure", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var errs utilerrors.RenderError
+		errs.Add("web", utilerrors.StageRender, errTestTemplate)
+
+		g.Expect(errs.ErrorOrNil()).Should(MatchError("render web: template failed"))
+	})
+
+	t.Run("aggregates all failures", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var errs utilerrors.RenderError
+		errs.Add("web", utilerrors.StageRender, errTestTemplate)
+		errs.Add("", utilerrors.StageTransform, fs.ErrNotExist)
+
+		err := errs.ErrorOrNil()
+		g.Expect(err).Should(MatchError("2 errors occurred:\n\t* render web: template failed\n\t* transform: file does not exist"))
+		g.Expect(err).Should(MatchError(errTestTemplate))
+		g.Expect(err).Should(MatchError(fs.ErrNotExist))
+	})
+
+	t.Run("exposes source and stage through errors.As", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var errs utilerrors.RenderError
+		errs.Add("db", utilerrors.StageValidate, errTestTemplate)
+
+		var stageErr *utilerrors.StageError
+		g.Expect(errors.As(errs.ErrorOrNil(), &stageErr)).Should(BeTrue())
+		g.Expect(stageErr.Source).Should(Equal("db"))
+		g.Expect(stageErr.Stage).Should(Equal(utilerrors.StageValidate))
+	})
+}
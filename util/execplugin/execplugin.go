@@ -0,0 +1,152 @@
+// Package execplugin renders objects by invoking an external binary.
+//
+// The protocol between the caller and the plugin is:
+//
+//   - stdin receives the values as a single JSON object
+//   - stdout must contain the rendered objects as multi-document YAML
+//   - on failure the plugin exits with a non-zero code and may write
+//     diagnostics to stderr, one JSON object per line in the form
+//     {"message": "...", "field": "..."}; other lines are kept as plain messages
+package execplugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/process"
+)
+
+// ErrPluginFailed is returned, wrapped in an *Error, when a plugin exits with
+// a non-zero code or times out.
+var ErrPluginFailed = errors.New("plugin failed")
+
+// Diagnostic is a structured error reported by a plugin on stderr.
+type Diagnostic struct {
+	// Message describes the problem.
+	Message string `json:"message"`
+
+	// Field optionally points at the offending value, e.g. "image.tag".
+	Field string `json:"field,omitempty"`
+}
+
+// Error is returned when a plugin fails.
+type Error struct {
+	// Plugin is the path of the plugin binary.
+	Plugin string
+
+	// Diagnostics are the problems reported by the plugin on stderr.
+	Diagnostics []Diagnostic
+
+	err error
+}
+
+func (e *Error) Error() string {
+	msgs := make([]string, 0, len(e.Diagnostics))
+	for _, d := range e.Diagnostics {
+		if d.Field != "" {
+			msgs = append(msgs, d.Field+": "+d.Message)
+		} else {
+			msgs = append(msgs, d.Message)
+		}
+	}
+
+	if len(msgs) == 0 {
+		return fmt.Sprintf("plugin %s failed: %v", e.Plugin, e.err)
+	}
+
+	return fmt.Sprintf("plugin %s failed: %v: %s", e.Plugin, e.err, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns ErrPluginFailed together with the underlying process error.
+func (e *Error) Unwrap() []error {
+	return []error{ErrPluginFailed, e.err}
+}
+
+// Source renders objects by running a plugin binary.
+type Source struct {
+	path string
+	opts Options
+}
+
+// New creates a Source running the binary at path.
+func New(path string, opts ...Option) (*Source, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, utilerrors.ErrPathEmpty
+	}
+
+	options := Options{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	return &Source{
+		path: path,
+		opts: options,
+	}, nil
+}
+
+// Name returns the path of the plugin binary.
+func (s *Source) Name() string {
+	return s.path
+}
+
+// Render runs the plugin with the given values and returns the objects it
+// emits. The plugin process does not inherit the environment of the caller:
+// it only sees the variables listed with WithEnvAllowlist and those set with
+// WithEnv.
+func (s *Source) Render(ctx context.Context, values map[string]any) ([]unstructured.Unstructured, error) {
+	if values == nil {
+		values = map[string]any{}
+	}
+
+	in, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: unable to encode values: %w", s.path, err)
+	}
+
+	out, err := process.Run(ctx, s.path, in, s.opts.Options)
+	if err != nil {
+		return nil, &Error{
+			Plugin:      s.path,
+			Diagnostics: parseDiagnostics(out.Stderr),
+			err:         err,
+		}
+	}
+
+	objects, err := k8s.DecodeYAML(out.Stdout)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: unable to decode output: %w", s.path, err)
+	}
+
+	return objects, nil
+}
+
+func parseDiagnostics(stderr []byte) []Diagnostic {
+	var result []Diagnostic
+
+	scanner := bufio.NewScanner(bytes.NewReader(stderr))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		d := Diagnostic{}
+		if err := json.Unmarshal([]byte(line), &d); err != nil || d.Message == "" {
+			d = Diagnostic{Message: line}
+		}
+
+		result = append(result, d)
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package execplugin
+
+import (
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+	"github.com/k8s-manifest-kit/pkg/util/process"
+)
+
+// Option is a generic option for Source.
+type Option = util.Option[Options]
+
+// Options is a struct-based option that can set multiple plugin options at once.
+type Options struct {
+	// Options are the arguments, environment, working directory and timeout
+	// of the plugin process.
+	process.Options
+}
+
+// ApplyTo applies the plugin options to the target configuration.
+func (opts Options) ApplyTo(target *Options) {
+	opts.Options.ApplyTo(&target.Options)
+}
+
+// WithArgs sets additional arguments passed to the plugin.
+func WithArgs(args ...string) Option {
+	return process.WithArgs[Options](args...)
+}
+
+// WithEnv sets an environment variable for the plugin.
+func WithEnv(key string, value string) Option {
+	return process.WithEnv[Options](key, value)
+}
+
+// WithEnvAllowlist passes the named variables of the calling process through
+// to the plugin.
+func WithEnvAllowlist(names ...string) Option {
+	return process.WithEnvAllowlist[Options](names...)
+}
+
+// WithWorkDir sets the working directory of the plugin process.
+func WithWorkDir(dir string) Option {
+	return process.WithWorkDir[Options](dir)
+}
+
+// WithTimeout bounds the duration of a single plugin run.
+func WithTimeout(timeout time.Duration) Option {
+	return process.WithTimeout[Options](timeout)
+}
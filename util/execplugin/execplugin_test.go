@@ -0,0 +1,139 @@
+package execplugin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+	"github.com/k8s-manifest-kit/pkg/util/execplugin"
+
+	. "github.com/onsi/gomega"
+)
+
+// Scripts emulating plugins; they only rely on shell builtins.
+const (
+	testShell = "/bin/sh"
+
+	// testValuesScript echoes the received values into a ConfigMap.
+	testValuesScript = `read -r values; printf '%s\n' \
+'apiVersion: v1' \
+'kind: ConfigMap' \
+'metadata:' \
+'  name: rendered' \
+'data:' \
+"  values: '$values'" \
+'---' \
+'apiVersion: v1' \
+'kind: Secret' \
+'metadata:' \
+'  name: secret'`
+
+	testEnvScript = `printf '%s\n' \
+'apiVersion: v1' \
+'kind: ConfigMap' \
+'metadata:' \
+"  name: cm-${ALLOWED:-unset}-${DENIED:-unset}-${EXPLICIT:-unset}"`
+
+	testPwdScript = `printf '%s\n' 'apiVersion: v1' 'kind: ConfigMap' 'metadata:' "  name: cm" "  annotations: {dir: '$PWD'}"`
+
+	testFailingScript = `echo '{"message": "must be set", "field": "image.tag"}' >&2
+echo 'plain failure' >&2
+exit 2`
+
+	testSleepScript = `while :; do :; done`
+)
+
+func TestNew(t *testing.T) {
+	t.Run("rejects an empty path", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := execplugin.New(" ")
+
+		g.Expect(err).Should(MatchError(utilerrors.ErrPathEmpty))
+	})
+}
+
+func TestRender(t *testing.T) {
+	t.Run("sends values as JSON and decodes YAML output", func(t *testing.T) {
+		g := NewWithT(t)
+
+		source, err := execplugin.New(testShell, execplugin.WithArgs("-c", testValuesScript))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(source.Name()).Should(Equal(testShell))
+
+		objects, err := source.Render(t.Context(), map[string]any{"replicas": 3})
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(objects).Should(HaveLen(2))
+		g.Expect(objects[0].Object).Should(HaveKeyWithValue("data", map[string]any{"values": `{"replicas":3}`}))
+		g.Expect(objects[1].GetKind()).Should(Equal("Secret"))
+	})
+
+	t.Run("only exposes allowlisted and explicit variables", func(t *testing.T) {
+		g := NewWithT(t)
+
+		t.Setenv("ALLOWED", "yes")
+		t.Setenv("DENIED", "yes")
+
+		source, err := execplugin.New(testShell,
+			execplugin.WithArgs("-c", testEnvScript),
+			execplugin.WithEnvAllowlist("ALLOWED"),
+			execplugin.WithEnv("EXPLICIT", "set"),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		objects, err := source.Render(t.Context(), nil)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(objects).Should(HaveLen(1))
+		g.Expect(objects[0].GetName()).Should(Equal("cm-yes-unset-set"))
+	})
+
+	t.Run("runs in the configured working directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		source, err := execplugin.New(testShell,
+			execplugin.WithArgs("-c", testPwdScript),
+			execplugin.WithWorkDir(dir),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		objects, err := source.Render(t.Context(), nil)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(objects[0].GetAnnotations()).Should(HaveKeyWithValue("dir", dir))
+	})
+
+	t.Run("reports structured diagnostics", func(t *testing.T) {
+		g := NewWithT(t)
+
+		source, err := execplugin.New(testShell, execplugin.WithArgs("-c", testFailingScript))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = source.Render(t.Context(), nil)
+		g.Expect(err).Should(MatchError(execplugin.ErrPluginFailed))
+		g.Expect(err).Should(MatchError(ContainSubstring("image.tag: must be set; plain failure")))
+
+		var pluginErr *execplugin.Error
+		g.Expect(errors.As(err, &pluginErr)).Should(BeTrue())
+		g.Expect(pluginErr.Diagnostics).Should(Equal([]execplugin.Diagnostic{
+			{Message: "must be set", Field: "image.tag"},
+			{Message: "plain failure"},
+		}))
+	})
+
+	t.Run("stops the plugin after the timeout", func(t *testing.T) {
+		g := NewWithT(t)
+
+		source, err := execplugin.New(testShell,
+			execplugin.WithArgs("-c", testSleepScript),
+			execplugin.WithTimeout(50*time.Millisecond),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = source.Render(t.Context(), nil)
+		g.Expect(err).Should(MatchError(execplugin.ErrPluginFailed))
+		g.Expect(err).Should(MatchError(context.DeadlineExceeded))
+	})
+}
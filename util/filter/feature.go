@@ -0,0 +1,44 @@
+package filter
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FeatureGatesAnnotation lists the feature gates an object depends on, as a
+// comma-separated list of gate names. A name prefixed with "!" requires the
+// gate to be disabled, e.g. "NewUI,!LegacyAuth".
+const FeatureGatesAnnotation = "k8s-manifest-kit.io/feature-gates"
+
+// ByFeatureGates keeps objects whose feature gate conditions hold for the
+// given gate states; objects without the FeatureGatesAnnotation are always
+// kept. Gates missing from gates are disabled, so features shipped in a chart
+// stay dormant until explicitly enabled.
+//
+// Example:
+//
+//	f := filter.ByFeatureGates(map[string]bool{"NewUI": true})
+func ByFeatureGates(gates map[string]bool) Filter {
+	return Func(func(_ context.Context, object unstructured.Unstructured) (bool, error) {
+		conditions, ok := object.GetAnnotations()[FeatureGatesAnnotation]
+		if !ok {
+			return true, nil
+		}
+
+		for condition := range strings.SplitSeq(conditions, ",") {
+			condition = strings.TrimSpace(condition)
+			if condition == "" {
+				continue
+			}
+
+			name, negated := strings.CutPrefix(condition, "!")
+			if gates[strings.TrimSpace(name)] == negated {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,78 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/filter"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const testFeatureGatesYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: new-ui
+  annotations:
+    k8s-manifest-kit.io/feature-gates: NewUI
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: legacy-auth
+  annotations:
+    k8s-manifest-kit.io/feature-gates: "!NewAuth"
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: canary
+  annotations:
+    k8s-manifest-kit.io/feature-gates: "NewUI, Canary"
+`
+
+func TestByFeatureGates(t *testing.T) {
+	t.Run("keeps ungated and negated objects when no gate is enabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(testFeatureGatesYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := filter.Apply(t.Context(), objects, filter.ByFeatureGates(nil))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(names(result)).Should(Equal([]string{"web", "legacy-auth"}))
+	})
+
+	t.Run("keeps objects whose gates are all enabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(testFeatureGatesYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := filter.Apply(t.Context(), objects,
+			filter.ByFeatureGates(map[string]bool{"NewUI": true, "Canary": true}))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(names(result)).Should(Equal([]string{"web", "new-ui", "legacy-auth", "canary"}))
+	})
+
+	t.Run("drops objects requiring a disabled gate", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(testFeatureGatesYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := filter.Apply(t.Context(), objects,
+			filter.ByFeatureGates(map[string]bool{"NewUI": true, "Canary": false, "NewAuth": true}))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(names(result)).Should(Equal([]string{"web", "new-ui"}))
+	})
+}
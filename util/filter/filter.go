@@ -0,0 +1,142 @@
+// Package filter provides declarative filters used to prune a set of rendered
+// objects.
+package filter
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+)
+
+// Filter decides whether an object is kept in the rendered set.
+type Filter interface {
+	// Filter returns true if the object must be kept.
+	Filter(ctx context.Context, object unstructured.Unstructured) (bool, error)
+}
+
+// Func adapts a plain function to the Filter interface.
+type Func func(ctx context.Context, object unstructured.Unstructured) (bool, error)
+
+// Filter calls f(ctx, object).
+func (f Func) Filter(ctx context.Context, object unstructured.Unstructured) (bool, error) {
+	return f(ctx, object)
+}
+
+// Not returns a Filter keeping the objects rejected by f, turning any
+// inclusion filter into an exclusion one.
+func Not(f Filter) Filter {
+	return Func(func(ctx context.Context, object unstructured.Unstructured) (bool, error) {
+		keep, err := f.Filter(ctx, object)
+		if err != nil {
+			return false, err
+		}
+
+		return !keep, nil
+	})
+}
+
+// And returns a Filter keeping the objects accepted by all the given filters.
+// An empty And keeps every object.
+func And(filters ...Filter) Filter {
+	return Func(func(ctx context.Context, object unstructured.Unstructured) (bool, error) {
+		return matchAll(ctx, object, filters)
+	})
+}
+
+// Or returns a Filter keeping the objects accepted by at least one of the
+// given filters. An empty Or keeps no object.
+func Or(filters ...Filter) Filter {
+	return Func(func(ctx context.Context, object unstructured.Unstructured) (bool, error) {
+		for _, f := range filters {
+			keep, err := f.Filter(ctx, object)
+			if err != nil {
+				return false, err
+			}
+
+			if keep {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+}
+
+// Apply returns the objects accepted by all the given filters, preserving
+// their order. Processing stops at the first error.
+func Apply(
+	ctx context.Context,
+	objects []unstructured.Unstructured,
+	filters ...Filter,
+) ([]unstructured.Unstructured, error) {
+	result := make([]unstructured.Unstructured, 0, len(objects))
+
+	for _, obj := range objects {
+		keep, err := matchAll(ctx, obj, filters)
+		if err != nil {
+			return nil, fmt.Errorf("unable to filter %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		if keep {
+			result = append(result, obj)
+		}
+	}
+
+	return result, nil
+}
+
+// Partition splits objects into those accepted by all the given filters and
+// the others, preserving their order. Processing stops at the first error.
+func Partition(
+	ctx context.Context,
+	objects []unstructured.Unstructured,
+	filters ...Filter,
+) ([]unstructured.Unstructured, []unstructured.Unstructured, error) {
+	matched := make([]unstructured.Unstructured, 0, len(objects))
+	rest := make([]unstructured.Unstructured, 0, len(objects))
+
+	for _, obj := range objects {
+		keep, err := matchAll(ctx, obj, filters)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to filter %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		if keep {
+			matched = append(matched, obj)
+		} else {
+			rest = append(rest, obj)
+		}
+	}
+
+	return matched, rest, nil
+}
+
+// Transformer returns a transform.Transformer applying the given filters, so
+// that filtering can take place at any position of a transformer pipeline.
+func Transformer(filters ...Filter) transform.Transformer {
+	return transform.Func(func(ctx context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		return Apply(ctx, objects, filters...)
+	})
+}
+
+func matchAll(ctx context.Context, object unstructured.Unstructured, filters []Filter) (bool, error) {
+	for _, f := range filters {
+		if f == nil {
+			continue
+		}
+
+		keep, err := f.Filter(ctx, object)
+		if err != nil {
+			return false, err
+		}
+
+		if !keep {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
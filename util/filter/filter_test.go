@@ -0,0 +1,180 @@
+package filter_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/filter"
+
+	. "github.com/onsi/gomega"
+)
+
+var errTestFilter = errors.New("filter failed")
+
+func testObjects() []unstructured.Unstructured {
+	return []unstructured.Unstructured{
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]any{"name": "apps"},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":      "web",
+				"namespace": "apps",
+				"labels":    map[string]any{"app.kubernetes.io/component": "frontend"},
+			},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "policy/v1",
+			"kind":       "PodDisruptionBudget",
+			"metadata": map[string]any{
+				"name":      "web",
+				"namespace": "apps",
+			},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":      "web-test",
+				"namespace": "tests",
+				"labels":    map[string]any{"app.kubernetes.io/component": "test"},
+			},
+		}},
+	}
+}
+
+func kinds(objects []unstructured.Unstructured) []string {
+	result := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		result = append(result, obj.GetKind())
+	}
+
+	return result
+}
+
+func names(objects []unstructured.Unstructured) []string {
+	result := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		result = append(result, obj.GetName())
+	}
+
+	return result
+}
+
+func byKind(kind string) filter.Filter {
+	return filter.Func(func(_ context.Context, obj unstructured.Unstructured) (bool, error) {
+		return obj.GetKind() == kind, nil
+	})
+}
+
+func failing() filter.Filter {
+	return filter.Func(func(_ context.Context, _ unstructured.Unstructured) (bool, error) {
+		return false, errTestFilter
+	})
+}
+
+func TestPartition(t *testing.T) {
+	t.Run("splits objects preserving order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		matched, rest, err := filter.Partition(t.Context(), testObjects(), filter.Or(byKind("Pod"), byKind("Namespace")))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(matched)).Should(Equal([]string{"Namespace", "Pod"}))
+		g.Expect(kinds(rest)).Should(Equal([]string{"Deployment", "PodDisruptionBudget"}))
+	})
+
+	t.Run("returns filter errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, _, err := filter.Partition(t.Context(), testObjects(), failing())
+
+		g.Expect(err).Should(MatchError(errTestFilter))
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("keeps objects accepted by all filters in order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.Not(byKind("Pod")), nil, filter.Not(byKind("Namespace")))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"Deployment", "PodDisruptionBudget"}))
+	})
+
+	t.Run("keeps everything without filters", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(4))
+	})
+
+	t.Run("returns filter errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := filter.Apply(t.Context(), testObjects(), failing())
+
+		g.Expect(err).Should(MatchError(errTestFilter))
+		g.Expect(err.Error()).Should(ContainSubstring("Namespace apps"))
+	})
+}
+
+func TestCombinators(t *testing.T) {
+	t.Run("And requires all filters", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.And(byKind("Pod"), filter.Not(byKind("Pod"))))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(BeEmpty())
+	})
+
+	t.Run("Or requires any filter", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.Or(byKind("Pod"), byKind("Namespace")))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"Namespace", "Pod"}))
+	})
+
+	t.Run("empty Or keeps nothing", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.Or())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(BeEmpty())
+	})
+
+	t.Run("propagates errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := filter.Apply(t.Context(), testObjects(), filter.Not(failing()))
+		g.Expect(err).Should(MatchError(errTestFilter))
+
+		_, err = filter.Apply(t.Context(), testObjects(), filter.Or(failing()))
+		g.Expect(err).Should(MatchError(errTestFilter))
+	})
+}
+
+func TestTransformer(t *testing.T) {
+	t.Run("filters as a pipeline stage", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Transformer(byKind("Deployment")).Transform(t.Context(), testObjects())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"Deployment"}))
+	})
+}
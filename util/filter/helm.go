@@ -0,0 +1,42 @@
+package filter
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// HelmHookAnnotation is the annotation marking an object as a Helm hook.
+	HelmHookAnnotation = "helm.sh/hook"
+
+	helmHookTest = "test"
+
+	// helmHookTestSuccess is the Helm 2 name of the test hook, still
+	// honored by Helm 3.
+	helmHookTestSuccess = "test-success"
+)
+
+// HelmTestHooks keeps objects that are Helm chart tests, i.e. whose
+// helm.sh/hook annotation lists the test hook. Combined with Partition it
+// separates chart tests from the objects to apply:
+//
+//	tests, objects, err := filter.Partition(ctx, rendered, filter.HelmTestHooks())
+func HelmTestHooks() Filter {
+	return Func(func(_ context.Context, object unstructured.Unstructured) (bool, error) {
+		hooks, ok := object.GetAnnotations()[HelmHookAnnotation]
+		if !ok {
+			return false, nil
+		}
+
+		for hook := range strings.SplitSeq(hooks, ",") {
+			switch strings.TrimSpace(hook) {
+			case helmHookTest, helmHookTestSuccess:
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+}
@@ -0,0 +1,53 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/filter"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const testHelmHooksYAML = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-test-connection
+  annotations:
+    helm.sh/hook: test
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: legacy-test
+  annotations:
+    helm.sh/hook: "pre-install, test-success"
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    helm.sh/hook: pre-upgrade
+`
+
+func TestHelmTestHooks(t *testing.T) {
+	t.Run("separates chart tests from the apply set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(testHelmHooksYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		tests, rest, err := filter.Partition(t.Context(), objects, filter.HelmTestHooks())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(names(tests)).Should(Equal([]string{"web-test-connection", "legacy-test"}))
+		g.Expect(names(rest)).Should(Equal([]string{"web", "migrate"}))
+	})
+}
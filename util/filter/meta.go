@@ -0,0 +1,49 @@
+package filter
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ByGVK keeps objects matching any of the given GroupVersionKinds.
+// An empty Version matches every version of the group and kind.
+func ByGVK(gvks ...schema.GroupVersionKind) Filter {
+	return Func(func(_ context.Context, object unstructured.Unstructured) (bool, error) {
+		objGVK := object.GroupVersionKind()
+
+		return slices.ContainsFunc(gvks, func(gvk schema.GroupVersionKind) bool {
+			if gvk.Group != objGVK.Group || gvk.Kind != objGVK.Kind {
+				return false
+			}
+
+			return gvk.Version == "" || gvk.Version == objGVK.Version
+		}), nil
+	})
+}
+
+// ByNamespace keeps objects in any of the given namespaces.
+// Cluster-scoped objects are matched by the empty namespace.
+func ByNamespace(namespaces ...string) Filter {
+	return Func(func(_ context.Context, object unstructured.Unstructured) (bool, error) {
+		return slices.Contains(namespaces, object.GetNamespace()), nil
+	})
+}
+
+// ByLabelSelector keeps objects whose labels match the given selector.
+//
+// Example:
+//
+//	selector, err := labels.Parse("app.kubernetes.io/component!=test")
+//	if err != nil {
+//	    return err
+//	}
+//	f := filter.ByLabelSelector(selector)
+func ByLabelSelector(selector labels.Selector) Filter {
+	return Func(func(_ context.Context, object unstructured.Unstructured) (bool, error) {
+		return selector.Matches(labels.Set(object.GetLabels())), nil
+	})
+}
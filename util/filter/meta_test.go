@@ -0,0 +1,106 @@
+package filter_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/filter"
+
+	. "github.com/onsi/gomega"
+)
+
+const testNotTestSelector = "app.kubernetes.io/component!=test"
+
+func TestByGVK(t *testing.T) {
+	t.Run("matches exact GroupVersionKinds", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.ByGVK(
+			schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"Deployment", "Pod"}))
+	})
+
+	t.Run("matches any version when version is empty", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.ByGVK(
+			schema.GroupVersionKind{Group: "policy", Kind: "PodDisruptionBudget"},
+		))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"PodDisruptionBudget"}))
+	})
+
+	t.Run("does not match a different version", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.ByGVK(
+			schema.GroupVersionKind{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget"},
+		))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(BeEmpty())
+	})
+
+	t.Run("excludes when negated", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.Not(filter.ByGVK(
+			schema.GroupVersionKind{Group: "policy", Kind: "PodDisruptionBudget"},
+		)))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"Namespace", "Deployment", "Pod"}))
+	})
+}
+
+func TestByNamespace(t *testing.T) {
+	t.Run("matches namespaced objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.ByNamespace("tests"))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"Pod"}))
+	})
+
+	t.Run("matches cluster-scoped objects with the empty namespace", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.ByNamespace("", "apps"))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"Namespace", "Deployment", "PodDisruptionBudget"}))
+	})
+}
+
+func TestByLabelSelector(t *testing.T) {
+	t.Run("matches label selectors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.ByLabelSelector(
+			labels.SelectorFromSet(labels.Set{"app.kubernetes.io/component": "frontend"}),
+		))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"Deployment"}))
+	})
+
+	t.Run("supports set-based requirements", func(t *testing.T) {
+		g := NewWithT(t)
+
+		selector, err := labels.Parse(testNotTestSelector)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := filter.Apply(t.Context(), testObjects(), filter.ByLabelSelector(selector))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(kinds(result)).Should(Equal([]string{"Namespace", "Deployment", "PodDisruptionBudget"}))
+	})
+}
@@ -0,0 +1,334 @@
+// Package argocd generates Argo CD Application and ApplicationSet objects
+// pointing at rendered output, so components do not hand-write the wrappers.
+package argocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+)
+
+const (
+	// APIVersion is the API version of the generated objects.
+	APIVersion = "argoproj.io/v1alpha1"
+
+	// KindApplication is the kind of an Argo CD Application.
+	KindApplication = "Application"
+
+	// KindApplicationSet is the kind of an Argo CD ApplicationSet.
+	KindApplicationSet = "ApplicationSet"
+
+	// DefaultNamespace is the namespace Argo CD is installed in by default.
+	DefaultNamespace = "argocd"
+
+	// DefaultProject is the Argo CD project applications belong to by default.
+	DefaultProject = "default"
+
+	// DefaultServer is the API server of the cluster Argo CD runs in.
+	DefaultServer = "https://kubernetes.default.svc"
+
+	// SyncWaveAnnotation orders the objects of an application during sync.
+	SyncWaveAnnotation = "argocd.argoproj.io/sync-wave"
+)
+
+// Sync waves set by SyncWaves.
+const (
+	// WaveFoundation holds namespaces and CRDs.
+	WaveFoundation = 0
+
+	// WaveObjects holds the built-in objects other than those of the other
+	// waves, such as RBAC, configuration, services and workloads.
+	WaveObjects = 1
+
+	// WaveCustomResources holds custom resources.
+	WaveCustomResources = 2
+
+	// WaveWebhooks holds admission webhook configurations.
+	WaveWebhooks = 3
+)
+
+var (
+	// ErrNameEmpty is returned when an application has no name.
+	ErrNameEmpty = errors.New("name cannot be empty")
+
+	// ErrRepoURLEmpty is returned when a source has no repository URL.
+	ErrRepoURLEmpty = errors.New("repository URL cannot be empty")
+)
+
+// Source is the location of the rendered output, typically a directory
+// written by output.DirWriter and committed to a Git repository.
+type Source struct {
+	// RepoURL is the URL of the Git repository holding the output.
+	RepoURL string
+
+	// Path is the directory of the output within the repository.
+	Path string
+
+	// TargetRevision is the branch, tag or commit to sync; empty means HEAD.
+	TargetRevision string
+}
+
+// Destination is the cluster and namespace the output is deployed to.
+type Destination struct {
+	// Server is the API server URL; empty means DefaultServer.
+	Server string
+
+	// Namespace is the namespace of namespaced objects lacking one.
+	Namespace string
+}
+
+// Template describes the applications generated by an ApplicationSet. Its
+// fields may reference the keys of the generator elements, e.g. "{{cluster}}".
+type Template struct {
+	// Name is the name of each generated application.
+	Name string
+
+	// Source is the location of the output of each generated application.
+	Source Source
+
+	// Destination is the deployment target of each generated application.
+	Destination Destination
+}
+
+// NewApplication returns an Application named name that syncs the output at
+// source to destination.
+//
+// Example:
+//
+//	app, err := argocd.NewApplication("web",
+//	    argocd.Source{RepoURL: "https://github.com/example/deploy", Path: "web"},
+//	    argocd.Destination{Namespace: "web"},
+//	    argocd.WithAutomatedSync(true, true),
+//	)
+func NewApplication(
+	name string,
+	source Source,
+	destination Destination,
+	opts ...Option,
+) (*unstructured.Unstructured, error) {
+	if name == "" {
+		return nil, ErrNameEmpty
+	}
+
+	options := newOptions(opts)
+
+	spec, err := applicationSpec(source, destination, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return newObject(KindApplication, name, spec, options), nil
+}
+
+// NewApplicationSet returns an ApplicationSet named name generating one
+// application from template per element of a list generator.
+//
+// Example:
+//
+//	appSet, err := argocd.NewApplicationSet("web",
+//	    argocd.Template{
+//	        Name:        "web-{{cluster}}",
+//	        Source:      argocd.Source{RepoURL: "https://github.com/example/deploy", Path: "web/{{cluster}}"},
+//	        Destination: argocd.Destination{Server: "{{url}}", Namespace: "web"},
+//	    },
+//	    []map[string]string{
+//	        {"cluster": "staging", "url": "https://staging.example.com"},
+//	        {"cluster": "production", "url": "https://production.example.com"},
+//	    },
+//	)
+func NewApplicationSet(
+	name string,
+	template Template,
+	elements []map[string]string,
+	opts ...Option,
+) (*unstructured.Unstructured, error) {
+	if name == "" {
+		return nil, ErrNameEmpty
+	}
+
+	if template.Name == "" {
+		return nil, fmt.Errorf("template: %w", ErrNameEmpty)
+	}
+
+	options := newOptions(opts)
+
+	spec, err := applicationSpec(template.Source, template.Destination, options)
+	if err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+
+	list := make([]any, 0, len(elements))
+	for _, element := range elements {
+		list = append(list, stringMap(element))
+	}
+
+	templateMeta := map[string]any{
+		"name": template.Name,
+	}
+
+	if len(options.Labels) > 0 {
+		templateMeta["labels"] = stringMap(options.Labels)
+	}
+
+	return newObject(KindApplicationSet, name, map[string]any{
+		"generators": []any{
+			map[string]any{
+				"list": map[string]any{
+					"elements": list,
+				},
+			},
+		},
+		"template": map[string]any{
+			"metadata": templateMeta,
+			"spec":     spec,
+		},
+	}, options), nil
+}
+
+// SyncWaves returns a Transformer annotating each object with one of a few
+// coarse sync waves following the apply order (see k8s.ApplyPriority):
+// namespaces and CRDs first, then the other built-in objects, then custom
+// resources, and admission webhooks last. Argo CD thus creates CRDs before
+// their custom resources and waits for workloads before registering
+// webhooks, without serializing the sync into a wave per kind. Objects that
+// already carry a sync wave keep it.
+func SyncWaves() transform.Transformer {
+	return transform.ObjectFunc(func(_ context.Context, obj *unstructured.Unstructured) error {
+		if _, ok := obj.GetAnnotations()[SyncWaveAnnotation]; ok {
+			return nil
+		}
+
+		k8s.SetAnnotation(obj, SyncWaveAnnotation, strconv.Itoa(syncWave(obj)))
+
+		return nil
+	})
+}
+
+// syncWave returns the sync wave of obj. Custom resources are told apart by
+// their group: built-in groups have no dot or end with .k8s.io, while CRD
+// groups must contain a dot and cannot use the reserved k8s.io suffix.
+func syncWave(obj *unstructured.Unstructured) int {
+	switch obj.GetKind() {
+	case "Namespace", "CustomResourceDefinition":
+		return WaveFoundation
+	case "MutatingWebhookConfiguration", "ValidatingWebhookConfiguration":
+		return WaveWebhooks
+	}
+
+	group := obj.GroupVersionKind().Group
+	if strings.Contains(group, ".") && !strings.HasSuffix(group, ".k8s.io") {
+		return WaveCustomResources
+	}
+
+	return WaveObjects
+}
+
+func newOptions(opts []Option) Options {
+	options := Options{
+		Namespace: DefaultNamespace,
+		Project:   DefaultProject,
+	}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	return options
+}
+
+func newObject(kind string, name string, spec map[string]any, options Options) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": APIVersion,
+			"kind":       kind,
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": options.Namespace,
+			},
+			"spec": spec,
+		},
+	}
+
+	if len(options.Labels) > 0 {
+		k8s.SetLabels(obj, options.Labels)
+	}
+
+	return obj
+}
+
+func applicationSpec(source Source, destination Destination, options Options) (map[string]any, error) {
+	if source.RepoURL == "" {
+		return nil, ErrRepoURLEmpty
+	}
+
+	src := map[string]any{
+		"repoURL": source.RepoURL,
+		"path":    source.Path,
+	}
+
+	if source.Path == "" {
+		src["path"] = "."
+	}
+
+	if source.TargetRevision != "" {
+		src["targetRevision"] = source.TargetRevision
+	}
+
+	dest := map[string]any{
+		"server": destination.Server,
+	}
+
+	if destination.Server == "" {
+		dest["server"] = DefaultServer
+	}
+
+	if destination.Namespace != "" {
+		dest["namespace"] = destination.Namespace
+	}
+
+	spec := map[string]any{
+		"project":     options.Project,
+		"source":      src,
+		"destination": dest,
+	}
+
+	syncPolicy := make(map[string]any)
+
+	if options.Automated != nil {
+		syncPolicy["automated"] = map[string]any{
+			"prune":    options.Automated.Prune,
+			"selfHeal": options.Automated.SelfHeal,
+		}
+	}
+
+	if len(options.SyncOptions) > 0 {
+		syncOptions := make([]any, 0, len(options.SyncOptions))
+		for _, o := range options.SyncOptions {
+			syncOptions = append(syncOptions, o)
+		}
+
+		syncPolicy["syncOptions"] = syncOptions
+	}
+
+	if len(syncPolicy) > 0 {
+		spec["syncPolicy"] = syncPolicy
+	}
+
+	return spec, nil
+}
+
+func stringMap(m map[string]string) map[string]any {
+	result := make(map[string]any, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+
+	return result
+}
@@ -0,0 +1,102 @@
+package argocd
+
+import (
+	"maps"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// Option is a generic option for NewApplication and NewApplicationSet.
+type Option = util.Option[Options]
+
+// AutomatedSync enables automated sync of an application.
+type AutomatedSync struct {
+	// Prune deletes objects that are no longer part of the output.
+	Prune bool
+
+	// SelfHeal reverts changes made to the objects in the cluster.
+	SelfHeal bool
+}
+
+// Options is a struct-based option that can set application options.
+type Options struct {
+	// Namespace is the namespace of the generated object (default "argocd").
+	Namespace string
+
+	// Project is the Argo CD project of the applications (default "default").
+	Project string
+
+	// Labels are added to the generated object and applications.
+	Labels map[string]string
+
+	// Automated enables automated sync; nil syncs manually.
+	Automated *AutomatedSync
+
+	// SyncOptions are Argo CD sync options such as "CreateNamespace=true".
+	SyncOptions []string
+}
+
+// ApplyTo applies the application options to the target configuration.
+func (opts Options) ApplyTo(target *Options) {
+	if opts.Namespace != "" {
+		target.Namespace = opts.Namespace
+	}
+
+	if opts.Project != "" {
+		target.Project = opts.Project
+	}
+
+	if len(opts.Labels) > 0 {
+		if target.Labels == nil {
+			target.Labels = make(map[string]string, len(opts.Labels))
+		}
+
+		maps.Copy(target.Labels, opts.Labels)
+	}
+
+	if opts.Automated != nil {
+		target.Automated = opts.Automated
+	}
+
+	target.SyncOptions = append(target.SyncOptions, opts.SyncOptions...)
+}
+
+// WithNamespace sets the namespace of the generated object.
+func WithNamespace(namespace string) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Namespace = namespace
+	})
+}
+
+// WithProject sets the Argo CD project of the applications.
+func WithProject(project string) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Project = project
+	})
+}
+
+// WithLabels adds labels to the generated object and applications.
+func WithLabels(labels map[string]string) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		if opts.Labels == nil {
+			opts.Labels = make(map[string]string, len(labels))
+		}
+
+		maps.Copy(opts.Labels, labels)
+	})
+}
+
+// WithAutomatedSync enables automated sync, optionally pruning removed objects
+// and reverting changes made in the cluster.
+func WithAutomatedSync(prune bool, selfHeal bool) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Automated = &AutomatedSync{Prune: prune, SelfHeal: selfHeal}
+	})
+}
+
+// WithSyncOptions adds Argo CD sync options such as "CreateNamespace=true".
+func WithSyncOptions(syncOptions ...string) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.SyncOptions = append(opts.SyncOptions, syncOptions...)
+	})
+}
@@ -0,0 +1,232 @@
+package argocd_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/interop/argocd"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const testRepoURL = "https://github.com/example/deploy"
+
+const testApplication = `
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: web
+  namespace: argocd
+spec:
+  project: default
+  source:
+    repoURL: https://github.com/example/deploy
+    path: web
+  destination:
+    server: https://kubernetes.default.svc
+    namespace: web
+`
+
+const testConfiguredApplication = `
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: web
+  namespace: gitops
+  labels:
+    team: platform
+spec:
+  project: apps
+  source:
+    repoURL: https://github.com/example/deploy
+    path: web
+    targetRevision: v1.2.0
+  destination:
+    server: https://kubernetes.default.svc
+    namespace: web
+  syncPolicy:
+    automated:
+      prune: true
+      selfHeal: false
+    syncOptions:
+      - CreateNamespace=true
+`
+
+const testApplicationSet = `
+apiVersion: argoproj.io/v1alpha1
+kind: ApplicationSet
+metadata:
+  name: web
+  namespace: argocd
+spec:
+  generators:
+    - list:
+        elements:
+          - cluster: staging
+            url: https://staging.example.com
+          - cluster: production
+            url: https://production.example.com
+  template:
+    metadata:
+      name: web-{{cluster}}
+    spec:
+      project: default
+      source:
+        repoURL: https://github.com/example/deploy
+        path: web/{{cluster}}
+      destination:
+        server: "{{url}}"
+        namespace: web
+`
+
+const testSyncWaveObjects = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  annotations:
+    argocd.argoproj.io/sync-wave: "-1"
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: policy
+`
+
+func TestNewApplication(t *testing.T) {
+	t.Run("should generate an application with defaults", func(t *testing.T) {
+		g := NewWithT(t)
+
+		app, err := argocd.NewApplication("web",
+			argocd.Source{RepoURL: testRepoURL, Path: "web"},
+			argocd.Destination{Namespace: "web"},
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(k8s.DecodeYAML([]byte(testApplication))).Should(ConsistOf(*app))
+	})
+
+	t.Run("should apply options", func(t *testing.T) {
+		g := NewWithT(t)
+
+		app, err := argocd.NewApplication("web",
+			argocd.Source{RepoURL: testRepoURL, Path: "web", TargetRevision: "v1.2.0"},
+			argocd.Destination{Namespace: "web"},
+			argocd.WithNamespace("gitops"),
+			argocd.WithProject("apps"),
+			argocd.WithLabels(map[string]string{"team": "platform"}),
+			argocd.WithAutomatedSync(true, false),
+			argocd.WithSyncOptions("CreateNamespace=true"),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(k8s.DecodeYAML([]byte(testConfiguredApplication))).Should(ConsistOf(*app))
+	})
+
+	t.Run("should sync the repository root when path is empty", func(t *testing.T) {
+		g := NewWithT(t)
+
+		app, err := argocd.NewApplication("web", argocd.Source{RepoURL: testRepoURL}, argocd.Destination{})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		path, _, err := unstructured.NestedString(app.Object, "spec", "source", "path")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(path).Should(Equal("."))
+	})
+
+	t.Run("should fail without a name", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := argocd.NewApplication("", argocd.Source{RepoURL: testRepoURL}, argocd.Destination{})
+		g.Expect(err).Should(MatchError(argocd.ErrNameEmpty))
+	})
+
+	t.Run("should fail without a repository URL", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := argocd.NewApplication("web", argocd.Source{Path: "web"}, argocd.Destination{})
+		g.Expect(err).Should(MatchError(argocd.ErrRepoURLEmpty))
+	})
+}
+
+func TestNewApplicationSet(t *testing.T) {
+	t.Run("should generate a list generator and template", func(t *testing.T) {
+		g := NewWithT(t)
+
+		appSet, err := argocd.NewApplicationSet("web",
+			argocd.Template{
+				Name:        "web-{{cluster}}",
+				Source:      argocd.Source{RepoURL: testRepoURL, Path: "web/{{cluster}}"},
+				Destination: argocd.Destination{Server: "{{url}}", Namespace: "web"},
+			},
+			[]map[string]string{
+				{"cluster": "staging", "url": "https://staging.example.com"},
+				{"cluster": "production", "url": "https://production.example.com"},
+			},
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(k8s.DecodeYAML([]byte(testApplicationSet))).Should(ConsistOf(*appSet))
+	})
+
+	t.Run("should fail without a template name", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := argocd.NewApplicationSet("web",
+			argocd.Template{Source: argocd.Source{RepoURL: testRepoURL}},
+			nil,
+		)
+		g.Expect(err).Should(MatchError(argocd.ErrNameEmpty))
+	})
+}
+
+func TestSyncWaves(t *testing.T) {
+	t.Run("should group objects into coarse sync waves", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(testSyncWaveObjects))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := argocd.SyncWaves().Transform(t.Context(), objects)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		waves := make(map[string]string, len(result))
+		for _, obj := range result {
+			waves[obj.GetKind()] = obj.GetAnnotations()[argocd.SyncWaveAnnotation]
+		}
+
+		g.Expect(waves).Should(Equal(map[string]string{
+			"Namespace":                      "0",
+			"CustomResourceDefinition":       "0",
+			"ConfigMap":                      "-1",
+			"ClusterRole":                    "1",
+			"Deployment":                     "1",
+			"Widget":                         "2",
+			"ValidatingWebhookConfiguration": "3",
+		}))
+	})
+}
@@ -0,0 +1,309 @@
+// Package flux generates Flux v2 source, HelmRelease and Kustomization
+// objects, so the same configuration can be rendered in process or handed
+// over to Flux.
+package flux
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const (
+	// SourceAPIVersion is the API version of the generated sources.
+	SourceAPIVersion = "source.toolkit.fluxcd.io/v1"
+
+	// HelmAPIVersion is the API version of the generated HelmReleases.
+	HelmAPIVersion = "helm.toolkit.fluxcd.io/v2"
+
+	// KustomizeAPIVersion is the API version of the generated Kustomizations.
+	KustomizeAPIVersion = "kustomize.toolkit.fluxcd.io/v1"
+
+	// KindGitRepository is the kind of a Git source.
+	KindGitRepository = "GitRepository"
+
+	// KindHelmRepository is the kind of a Helm repository source.
+	KindHelmRepository = "HelmRepository"
+
+	// KindOCIRepository is the kind of an OCI artifact source.
+	KindOCIRepository = "OCIRepository"
+
+	// KindHelmRelease is the kind of a Helm release.
+	KindHelmRelease = "HelmRelease"
+
+	// KindKustomization is the kind of a Flux Kustomization.
+	KindKustomization = "Kustomization"
+
+	// DefaultNamespace is the namespace Flux is installed in by default.
+	DefaultNamespace = "flux-system"
+
+	// ociScheme is the URL scheme of OCI registries.
+	ociScheme = "oci://"
+)
+
+var (
+	// ErrNameEmpty is returned when an object has no name.
+	ErrNameEmpty = errors.New("name cannot be empty")
+
+	// ErrURLEmpty is returned when a source has no URL.
+	ErrURLEmpty = errors.New("URL cannot be empty")
+
+	// ErrChartEmpty is returned when a HelmRelease has no chart.
+	ErrChartEmpty = errors.New("chart cannot be empty")
+
+	// ErrInvalidValues is returned when HelmRelease values cannot be
+	// represented as JSON.
+	ErrInvalidValues = errors.New("invalid values")
+)
+
+// SourceRef references the Flux source an object is built from.
+type SourceRef struct {
+	// Kind is the kind of the source, e.g. KindHelmRepository.
+	Kind string
+
+	// Name is the name of the source.
+	Name string
+
+	// Namespace is the namespace of the source; empty means the namespace of
+	// the referencing object.
+	Namespace string
+}
+
+// OCIReference selects the artifact of an OCIRepository. Digest takes
+// precedence over SemVer, which takes precedence over Tag.
+type OCIReference struct {
+	// Tag is the tag of the artifact, e.g. "latest".
+	Tag string
+
+	// SemVer is a semantic version range matched against the tags.
+	SemVer string
+
+	// Digest is the digest of the artifact, e.g. "sha256:...".
+	Digest string
+}
+
+// Chart is the Helm chart of a HelmRelease.
+type Chart struct {
+	// Name is the name of the chart in its repository.
+	Name string
+
+	// Version is the chart version or semantic version range; empty means
+	// the latest version.
+	Version string
+
+	// SourceRef references the HelmRepository serving the chart.
+	SourceRef SourceRef
+}
+
+// NewHelmRepository returns a HelmRepository serving charts from url. URLs
+// with the oci:// scheme produce an OCI Helm repository.
+func NewHelmRepository(name string, url string, opts ...Option) (*unstructured.Unstructured, error) {
+	if url == "" {
+		return nil, ErrURLEmpty
+	}
+
+	spec := map[string]any{
+		"url": url,
+	}
+
+	if strings.HasPrefix(url, ociScheme) {
+		spec["type"] = "oci"
+	}
+
+	return newObject(SourceAPIVersion, KindHelmRepository, name, spec, newOptions(opts))
+}
+
+// NewOCIRepository returns an OCIRepository pulling the artifact at url, e.g.
+// an archive pushed from output.Archive.
+func NewOCIRepository(
+	name string,
+	url string,
+	ref OCIReference,
+	opts ...Option,
+) (*unstructured.Unstructured, error) {
+	if url == "" {
+		return nil, ErrURLEmpty
+	}
+
+	spec := map[string]any{
+		"url": url,
+	}
+
+	switch {
+	case ref.Digest != "":
+		spec["ref"] = map[string]any{"digest": ref.Digest}
+	case ref.SemVer != "":
+		spec["ref"] = map[string]any{"semver": ref.SemVer}
+	case ref.Tag != "":
+		spec["ref"] = map[string]any{"tag": ref.Tag}
+	}
+
+	return newObject(SourceAPIVersion, KindOCIRepository, name, spec, newOptions(opts))
+}
+
+// NewHelmRelease returns a HelmRelease installing chart with the given values.
+// The values are converted to their JSON representation, e.g. an int becomes
+// an int64, and so are deep-copied into the generated object; values that
+// cannot be represented as JSON are rejected with ErrInvalidValues.
+//
+// Example:
+//
+//	release, err := flux.NewHelmRelease("podinfo",
+//	    flux.Chart{
+//	        Name:      "podinfo",
+//	        Version:   "6.x",
+//	        SourceRef: flux.SourceRef{Kind: flux.KindHelmRepository, Name: "podinfo"},
+//	    },
+//	    map[string]any{"replicaCount": 2},
+//	    flux.WithTargetNamespace("apps"),
+//	)
+func NewHelmRelease(
+	name string,
+	chart Chart,
+	values map[string]any,
+	opts ...Option,
+) (*unstructured.Unstructured, error) {
+	if chart.Name == "" {
+		return nil, ErrChartEmpty
+	}
+
+	options := newOptions(opts)
+
+	chartSpec := map[string]any{
+		"chart":     chart.Name,
+		"sourceRef": sourceRef(chart.SourceRef),
+	}
+
+	if chart.Version != "" {
+		chartSpec["version"] = chart.Version
+	}
+
+	spec := map[string]any{
+		"chart": map[string]any{
+			"spec": chartSpec,
+		},
+	}
+
+	if options.TargetNamespace != "" {
+		spec["targetNamespace"] = options.TargetNamespace
+	}
+
+	if len(values) > 0 {
+		converted, err := toJSONMap(values)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", KindHelmRelease, name, err)
+		}
+
+		spec["values"] = converted
+	}
+
+	return newObject(HelmAPIVersion, KindHelmRelease, name, spec, options)
+}
+
+// NewKustomization returns a Flux Kustomization applying the manifests at
+// path in the source referenced by ref, e.g. a directory written by
+// output.DirWriter.
+func NewKustomization(
+	name string,
+	ref SourceRef,
+	path string,
+	opts ...Option,
+) (*unstructured.Unstructured, error) {
+	options := newOptions(opts)
+
+	if path == "" {
+		path = "./"
+	}
+
+	spec := map[string]any{
+		"path":      path,
+		"prune":     options.Prune,
+		"sourceRef": sourceRef(ref),
+	}
+
+	if options.TargetNamespace != "" {
+		spec["targetNamespace"] = options.TargetNamespace
+	}
+
+	return newObject(KustomizeAPIVersion, KindKustomization, name, spec, options)
+}
+
+// toJSONMap converts values to the JSON-compatible types of unstructured
+// objects by round tripping them through JSON.
+func toJSONMap(values map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidValues, err)
+	}
+
+	converted := make(map[string]any)
+	if err := utiljson.Unmarshal(data, &converted); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidValues, err)
+	}
+
+	return converted, nil
+}
+
+func newOptions(opts []Option) Options {
+	options := Options{
+		Namespace: DefaultNamespace,
+		Interval:  DefaultInterval,
+	}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	return options
+}
+
+func newObject(
+	apiVersion string,
+	kind string,
+	name string,
+	spec map[string]any,
+	options Options,
+) (*unstructured.Unstructured, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%s: %w", kind, ErrNameEmpty)
+	}
+
+	spec["interval"] = options.Interval.String()
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": options.Namespace,
+			},
+			"spec": spec,
+		},
+	}
+
+	if len(options.Labels) > 0 {
+		k8s.SetLabels(obj, options.Labels)
+	}
+
+	return obj, nil
+}
+
+func sourceRef(ref SourceRef) map[string]any {
+	result := map[string]any{
+		"kind": ref.Kind,
+		"name": ref.Name,
+	}
+
+	if ref.Namespace != "" {
+		result["namespace"] = ref.Namespace
+	}
+
+	return result
+}
@@ -0,0 +1,100 @@
+package flux
+
+import (
+	"maps"
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// DefaultInterval is the default reconciliation interval.
+const DefaultInterval = 10 * time.Minute
+
+// Option is a generic option for the Flux object generators.
+type Option = util.Option[Options]
+
+// Options is a struct-based option that can set Flux object options.
+type Options struct {
+	// Namespace is the namespace of the generated object (default "flux-system").
+	Namespace string
+
+	// Interval is the reconciliation interval (default 10m).
+	Interval time.Duration
+
+	// Labels are added to the generated object.
+	Labels map[string]string
+
+	// TargetNamespace is the namespace HelmReleases and Kustomizations deploy
+	// their objects to.
+	TargetNamespace string
+
+	// Prune deletes objects removed from the source; Kustomization only.
+	Prune bool
+}
+
+// ApplyTo applies the Flux object options to the target configuration.
+func (opts Options) ApplyTo(target *Options) {
+	if opts.Namespace != "" {
+		target.Namespace = opts.Namespace
+	}
+
+	if opts.Interval > 0 {
+		target.Interval = opts.Interval
+	}
+
+	if len(opts.Labels) > 0 {
+		if target.Labels == nil {
+			target.Labels = make(map[string]string, len(opts.Labels))
+		}
+
+		maps.Copy(target.Labels, opts.Labels)
+	}
+
+	if opts.TargetNamespace != "" {
+		target.TargetNamespace = opts.TargetNamespace
+	}
+
+	if opts.Prune {
+		target.Prune = true
+	}
+}
+
+// WithNamespace sets the namespace of the generated object.
+func WithNamespace(namespace string) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Namespace = namespace
+	})
+}
+
+// WithInterval sets the reconciliation interval.
+func WithInterval(interval time.Duration) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Interval = interval
+	})
+}
+
+// WithLabels adds labels to the generated object.
+func WithLabels(labels map[string]string) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		if opts.Labels == nil {
+			opts.Labels = make(map[string]string, len(labels))
+		}
+
+		maps.Copy(opts.Labels, labels)
+	})
+}
+
+// WithTargetNamespace sets the namespace HelmReleases and Kustomizations
+// deploy their objects to.
+func WithTargetNamespace(namespace string) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.TargetNamespace = namespace
+	})
+}
+
+// WithPrune makes a Kustomization delete objects removed from its source.
+func WithPrune(prune bool) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Prune = prune
+	})
+}
@@ -0,0 +1,230 @@
+package flux_test
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/interop/flux"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const testHelmRepository = `
+apiVersion: source.toolkit.fluxcd.io/v1
+kind: HelmRepository
+metadata:
+  name: podinfo
+  namespace: flux-system
+spec:
+  interval: 10m0s
+  url: https://stefanprodan.github.io/podinfo
+`
+
+const testOCIRepository = `
+apiVersion: source.toolkit.fluxcd.io/v1
+kind: OCIRepository
+metadata:
+  name: web
+  namespace: apps
+  labels:
+    team: platform
+spec:
+  interval: 1m0s
+  url: oci://registry.example.com/manifests/web
+  ref:
+    digest: sha256:0123
+`
+
+const testHelmRelease = `
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: podinfo
+  namespace: flux-system
+spec:
+  interval: 10m0s
+  targetNamespace: apps
+  chart:
+    spec:
+      chart: podinfo
+      version: 6.x
+      sourceRef:
+        kind: HelmRepository
+        name: podinfo
+  values:
+    replicaCount: 2
+    ingress:
+      enabled: true
+`
+
+const testKustomization = `
+apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: web
+  namespace: flux-system
+spec:
+  interval: 10m0s
+  path: ./web
+  prune: true
+  sourceRef:
+    kind: GitRepository
+    name: deploy
+    namespace: gitops
+`
+
+func TestNewHelmRepository(t *testing.T) {
+	t.Run("should generate an HTTP Helm repository", func(t *testing.T) {
+		g := NewWithT(t)
+
+		repo, err := flux.NewHelmRepository("podinfo", "https://stefanprodan.github.io/podinfo")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(k8s.DecodeYAML([]byte(testHelmRepository))).Should(ConsistOf(*repo))
+	})
+
+	t.Run("should generate an OCI Helm repository for oci URLs", func(t *testing.T) {
+		g := NewWithT(t)
+
+		repo, err := flux.NewHelmRepository("podinfo", "oci://ghcr.io/stefanprodan/charts")
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		repoType, _, err := unstructured.NestedString(repo.Object, "spec", "type")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(repoType).Should(Equal("oci"))
+	})
+
+	t.Run("should fail without a URL", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := flux.NewHelmRepository("podinfo", "")
+		g.Expect(err).Should(MatchError(flux.ErrURLEmpty))
+	})
+
+	t.Run("should fail without a name", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := flux.NewHelmRepository("", "https://stefanprodan.github.io/podinfo")
+		g.Expect(err).Should(MatchError(flux.ErrNameEmpty))
+	})
+}
+
+func TestNewOCIRepository(t *testing.T) {
+	t.Run("should generate an OCI repository with options", func(t *testing.T) {
+		g := NewWithT(t)
+
+		repo, err := flux.NewOCIRepository("web",
+			"oci://registry.example.com/manifests/web",
+			flux.OCIReference{Tag: "latest", Digest: "sha256:0123"},
+			flux.WithNamespace("apps"),
+			flux.WithInterval(time.Minute),
+			flux.WithLabels(map[string]string{"team": "platform"}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(k8s.DecodeYAML([]byte(testOCIRepository))).Should(ConsistOf(*repo))
+	})
+
+	t.Run("should prefer a semver range over a tag", func(t *testing.T) {
+		g := NewWithT(t)
+
+		repo, err := flux.NewOCIRepository("web",
+			"oci://registry.example.com/manifests/web",
+			flux.OCIReference{Tag: "latest", SemVer: ">=1.0.0"},
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		ref, _, err := unstructured.NestedStringMap(repo.Object, "spec", "ref")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ref).Should(Equal(map[string]string{"semver": ">=1.0.0"}))
+	})
+}
+
+func TestNewHelmRelease(t *testing.T) {
+	t.Run("should generate a release with values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		values := map[string]any{
+			"replicaCount": 2,
+			"ingress":      map[string]any{"enabled": true},
+		}
+
+		release, err := flux.NewHelmRelease("podinfo",
+			flux.Chart{
+				Name:      "podinfo",
+				Version:   "6.x",
+				SourceRef: flux.SourceRef{Kind: flux.KindHelmRepository, Name: "podinfo"},
+			},
+			values,
+			flux.WithTargetNamespace("apps"),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(k8s.DecodeYAML([]byte(testHelmRelease))).Should(ConsistOf(*release))
+
+		values["ingress"].(map[string]any)["enabled"] = false
+
+		enabled, _, err := unstructured.NestedBool(release.Object, "spec", "values", "ingress", "enabled")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(enabled).Should(BeTrue())
+	})
+
+	t.Run("should store values that can be deep copied", func(t *testing.T) {
+		g := NewWithT(t)
+
+		release, err := flux.NewHelmRelease("podinfo",
+			flux.Chart{Name: "podinfo", SourceRef: flux.SourceRef{Kind: flux.KindHelmRepository, Name: "podinfo"}},
+			map[string]any{"replicaCount": 2, "ports": []int{80, 443}},
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		replicas, _, err := unstructured.NestedInt64(release.DeepCopy().Object, "spec", "values", "replicaCount")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(replicas).Should(Equal(int64(2)))
+	})
+
+	t.Run("should fail with values that cannot be represented as JSON", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := flux.NewHelmRelease("podinfo",
+			flux.Chart{Name: "podinfo", SourceRef: flux.SourceRef{Kind: flux.KindHelmRepository, Name: "podinfo"}},
+			map[string]any{"hook": func() {}},
+		)
+		g.Expect(err).Should(MatchError(flux.ErrInvalidValues))
+	})
+
+	t.Run("should fail without a chart", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := flux.NewHelmRelease("podinfo", flux.Chart{}, nil)
+		g.Expect(err).Should(MatchError(flux.ErrChartEmpty))
+	})
+}
+
+func TestNewKustomization(t *testing.T) {
+	t.Run("should generate a kustomization", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kustomization, err := flux.NewKustomization("web",
+			flux.SourceRef{Kind: flux.KindGitRepository, Name: "deploy", Namespace: "gitops"},
+			"./web",
+			flux.WithPrune(true),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(k8s.DecodeYAML([]byte(testKustomization))).Should(ConsistOf(*kustomization))
+	})
+
+	t.Run("should default to the source root", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kustomization, err := flux.NewKustomization("web",
+			flux.SourceRef{Kind: flux.KindOCIRepository, Name: "web"},
+			"",
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		path, _, err := unstructured.NestedString(kustomization.Object, "spec", "path")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(path).Should(Equal("./"))
+	})
+}
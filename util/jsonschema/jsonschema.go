@@ -0,0 +1,707 @@
+// Package jsonschema validates JSON-like values (as produced by YAML/JSON
+// decoding) against a JSON Schema, reporting path-addressed errors.
+//
+// It implements the subset of the specification used by Helm values.schema.json
+// files and Kubernetes structural schemas: type, enum, const, properties,
+// required, additionalProperties, patternProperties, items, numeric and length
+// bounds, pattern, allOf/anyOf/oneOf/not and local $ref references.
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+const rootPath = "(root)"
+
+var (
+	// ErrInvalidSchema is returned when a schema cannot be compiled.
+	ErrInvalidSchema = errors.New("invalid schema")
+
+	// ErrValidation is matched by every *ValidationError.
+	ErrValidation = errors.New("schema validation failed")
+)
+
+// FieldError is a validation failure at a specific location of the value.
+type FieldError struct {
+	// Path locates the invalid value, e.g. "image.tag" or "ports[0].port".
+	Path string
+
+	// Message describes the failure.
+	Message string
+}
+
+func (e FieldError) String() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError aggregates all the failures found while validating a value.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		messages[i] = fe.String()
+	}
+
+	return fmt.Sprintf("%s: %s", ErrValidation.Error(), strings.Join(messages, "; "))
+}
+
+// Unwrap allows errors.Is(err, ErrValidation).
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// Schema is a compiled JSON Schema.
+type Schema struct {
+	root     map[string]any
+	patterns map[string]*regexp.Regexp
+}
+
+// Compile parses a JSON (or YAML) encoded schema.
+func Compile(data []byte) (*Schema, error) {
+	var root map[string]any
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	return New(root)
+}
+
+// New compiles a schema given as a decoded JSON object.
+func New(root map[string]any) (*Schema, error) {
+	if root == nil {
+		root = map[string]any{}
+	}
+
+	s := &Schema{
+		root:     root,
+		patterns: make(map[string]*regexp.Regexp),
+	}
+
+	if err := s.compilePatterns(root); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// compilePatterns precompiles every regular expression in the schema so that
+// invalid patterns are reported at compile time rather than on validation.
+func (s *Schema) compilePatterns(node any) error {
+	switch n := node.(type) {
+	case map[string]any:
+		for k, v := range n {
+			if k == "pattern" {
+				if err := s.compilePattern(v); err != nil {
+					return err
+				}
+			}
+
+			if k == "patternProperties" {
+				if props, ok := v.(map[string]any); ok {
+					for p := range props {
+						if err := s.compilePattern(p); err != nil {
+							return err
+						}
+					}
+				}
+			}
+
+			if err := s.compilePatterns(v); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, v := range n {
+			if err := s.compilePatterns(v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) compilePattern(v any) error {
+	p, ok := v.(string)
+	if !ok {
+		return nil
+	}
+
+	if _, done := s.patterns[p]; done {
+		return nil
+	}
+
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return fmt.Errorf("%w: pattern %q: %w", ErrInvalidSchema, p, err)
+	}
+
+	s.patterns[p] = re
+
+	return nil
+}
+
+// Validate validates value against the schema. It returns nil if the value is
+// valid, or a *ValidationError listing every failure otherwise.
+func (s *Schema) Validate(value any) error {
+	v := validator{schema: s}
+	v.validate(s.root, value, rootPath)
+
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: v.errors}
+}
+
+type validator struct {
+	schema *Schema
+	errors []FieldError
+	depth  int
+}
+
+// maxRefDepth guards against infinitely recursive $ref chains.
+const maxRefDepth = 64
+
+func (v *validator) fail(path string, format string, args ...any) {
+	v.errors = append(v.errors, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+//nolint:cyclop // One branch per keyword keeps the validator easy to follow.
+func (v *validator) validate(schema any, value any, path string) {
+	value = normalize(value)
+
+	node, ok := schema.(map[string]any)
+	if !ok {
+		// Boolean schemas: true accepts everything, false nothing.
+		if b, isBool := schema.(bool); isBool && !b {
+			v.fail(path, "no value is allowed")
+		}
+
+		return
+	}
+
+	if ref, ok := node["$ref"].(string); ok {
+		v.validateRef(ref, value, path)
+
+		return
+	}
+
+	if value == nil && node["nullable"] == true {
+		return
+	}
+
+	if !v.validateType(node, value, path) {
+		return
+	}
+
+	v.validateEnum(node, value, path)
+	v.validateCombinators(node, value, path)
+
+	switch val := value.(type) {
+	case map[string]any:
+		v.validateObject(node, val, path)
+	case []any:
+		v.validateArray(node, val, path)
+	case string:
+		v.validateString(node, val, path)
+	default:
+		if n, isNumber := toFloat(value); isNumber {
+			v.validateNumber(node, n, path)
+		}
+	}
+}
+
+func (v *validator) validateRef(ref string, value any, path string) {
+	if v.depth >= maxRefDepth {
+		v.fail(path, "$ref %q is too deeply nested", ref)
+
+		return
+	}
+
+	target, ok := v.schema.resolve(ref)
+	if !ok {
+		v.fail(path, "unresolvable $ref %q", ref)
+
+		return
+	}
+
+	v.depth++
+	v.validate(target, value, path)
+	v.depth--
+}
+
+// resolve resolves a local JSON pointer reference such as "#/definitions/port".
+func (s *Schema) resolve(ref string) (any, bool) {
+	if ref == "#" {
+		return s.root, true
+	}
+
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, false
+	}
+
+	var current any = s.root
+	for token := range strings.SplitSeq(pointer, "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		current, ok = m[token]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func (v *validator) validateType(node map[string]any, value any, path string) bool {
+	var types []string
+
+	switch t := node["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+	default:
+		return true
+	}
+
+	actual := TypeOf(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	v.fail(path, "invalid type, expected %s, got %s", strings.Join(types, " or "), actual)
+
+	return false
+}
+
+func (v *validator) validateEnum(node map[string]any, value any, path string) {
+	if c, ok := node["const"]; ok && !equal(c, value) {
+		v.fail(path, "must be equal to %v", c)
+	}
+
+	enum, ok := node["enum"].([]any)
+	if !ok {
+		return
+	}
+
+	if slices.ContainsFunc(enum, func(e any) bool { return equal(e, value) }) {
+		return
+	}
+
+	v.fail(path, "must be one of %v", enum)
+}
+
+func (v *validator) validateCombinators(node map[string]any, value any, path string) {
+	if all, ok := node["allOf"].([]any); ok {
+		for _, sub := range all {
+			v.validate(sub, value, path)
+		}
+	}
+
+	if anyOf, ok := node["anyOf"].([]any); ok {
+		if v.countValid(anyOf, value, path) == 0 {
+			v.fail(path, "must match at least one schema in anyOf")
+		}
+	}
+
+	if oneOf, ok := node["oneOf"].([]any); ok {
+		if n := v.countValid(oneOf, value, path); n != 1 {
+			v.fail(path, "must match exactly one schema in oneOf, matched %d", n)
+		}
+	}
+
+	if not, ok := node["not"]; ok {
+		if v.countValid([]any{not}, value, path) == 1 {
+			v.fail(path, "must not match the schema in not")
+		}
+	}
+}
+
+// countValid returns the number of schemas accepting value, without recording
+// the failures of the individual alternatives.
+func (v *validator) countValid(schemas []any, value any, path string) int {
+	count := 0
+
+	for _, sub := range schemas {
+		nested := validator{schema: v.schema, depth: v.depth}
+		nested.validate(sub, value, path)
+
+		if len(nested.errors) == 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (v *validator) validateObject(node map[string]any, value map[string]any, path string) {
+	properties, _ := node["properties"].(map[string]any)
+	patternProperties, _ := node["patternProperties"].(map[string]any)
+
+	if required, ok := node["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, found := value[name]; !found {
+				v.fail(childPath(path, name), "is required")
+			}
+		}
+	}
+
+	if n, ok := toInt(node["minProperties"]); ok && len(value) < n {
+		v.fail(path, "must have at least %d properties", n)
+	}
+
+	if n, ok := toInt(node["maxProperties"]); ok && len(value) > n {
+		v.fail(path, "must have at most %d properties", n)
+	}
+
+	// Keys are visited in order so that errors are reported deterministically.
+	keys := make([]string, 0, len(value))
+	for k := range value {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		item := value[k]
+		p := childPath(path, k)
+		matched := false
+
+		if sub, ok := properties[k]; ok {
+			matched = true
+			v.validate(sub, item, p)
+		}
+
+		for pattern, sub := range patternProperties {
+			if re := v.schema.patterns[pattern]; re != nil && re.MatchString(k) {
+				matched = true
+				v.validate(sub, item, p)
+			}
+		}
+
+		if matched {
+			continue
+		}
+
+		switch additional := node["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(p, "additional property is not allowed")
+			}
+		case map[string]any:
+			v.validate(additional, item, p)
+		}
+	}
+}
+
+func (v *validator) validateArray(node map[string]any, value []any, path string) {
+	if n, ok := toInt(node["minItems"]); ok && len(value) < n {
+		v.fail(path, "must have at least %d items", n)
+	}
+
+	if n, ok := toInt(node["maxItems"]); ok && len(value) > n {
+		v.fail(path, "must have at most %d items", n)
+	}
+
+	if node["uniqueItems"] == true {
+		for i := range value {
+			for j := range i {
+				if equal(value[i], value[j]) {
+					v.fail(path, "items %d and %d are equal", j, i)
+				}
+			}
+		}
+	}
+
+	switch items := node["items"].(type) {
+	case map[string]any, bool:
+		for i, item := range value {
+			v.validate(items, item, indexPath(path, i))
+		}
+	case []any:
+		for i, item := range value {
+			if i < len(items) {
+				v.validate(items[i], item, indexPath(path, i))
+			}
+		}
+	}
+}
+
+func (v *validator) validateString(node map[string]any, value string, path string) {
+	length := utf8.RuneCountInString(value)
+
+	if n, ok := toInt(node["minLength"]); ok && length < n {
+		v.fail(path, "must be at least %d characters long", n)
+	}
+
+	if n, ok := toInt(node["maxLength"]); ok && length > n {
+		v.fail(path, "must be at most %d characters long", n)
+	}
+
+	if p, ok := node["pattern"].(string); ok {
+		if re := v.schema.patterns[p]; re != nil && !re.MatchString(value) {
+			v.fail(path, "must match pattern %q", p)
+		}
+	}
+}
+
+func (v *validator) validateNumber(node map[string]any, value float64, path string) {
+	if m, ok := toFloat(node["minimum"]); ok {
+		if node["exclusiveMinimum"] == true && value <= m {
+			v.fail(path, "must be greater than %v", m)
+		} else if value < m {
+			v.fail(path, "must be greater than or equal to %v", m)
+		}
+	}
+
+	if m, ok := toFloat(node["maximum"]); ok {
+		if node["exclusiveMaximum"] == true && value >= m {
+			v.fail(path, "must be less than %v", m)
+		} else if value > m {
+			v.fail(path, "must be less than or equal to %v", m)
+		}
+	}
+
+	// Draft 6+ numeric form of exclusive bounds.
+	if m, ok := toFloat(node["exclusiveMinimum"]); ok && value <= m {
+		v.fail(path, "must be greater than %v", m)
+	}
+
+	if m, ok := toFloat(node["exclusiveMaximum"]); ok && value >= m {
+		v.fail(path, "must be less than %v", m)
+	}
+
+	if m, ok := toFloat(node["multipleOf"]); ok && m > 0 && !isMultiple(value, m) {
+		v.fail(path, "must be a multiple of %v", m)
+	}
+}
+
+// isMultiple reports whether value is a multiple of m. The numbers are
+// compared exactly through their shortest decimal representation, as written
+// in documents, so that 0.3 is a multiple of 0.1 despite floating-point
+// rounding.
+func isMultiple(value float64, m float64) bool {
+	v, vOK := new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64))
+	d, dOK := new(big.Rat).SetString(strconv.FormatFloat(m, 'g', -1, 64))
+
+	// Infinities and NaN have no rational representation.
+	if !vOK || !dOK {
+		q := value / m
+
+		return q == math.Trunc(q)
+	}
+
+	return v.Quo(v, d).IsInt()
+}
+
+// TypeOf returns the JSON Schema type name of a decoded JSON/YAML value.
+func TypeOf(value any) string {
+	switch val := normalize(value).(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case float32:
+		return floatType(float64(val))
+	case float64:
+		return floatType(val)
+	}
+
+	// Floats are handled above, so any other number is an integer.
+	if _, ok := toFloat(value); ok {
+		return "integer"
+	}
+
+	return reflect.TypeOf(value).String()
+}
+
+func floatType(f float64) string {
+	if f == math.Trunc(f) && !math.IsInf(f, 0) {
+		return "integer"
+	}
+
+	return "number"
+}
+
+func toInt64(value any) (int64, bool) {
+	switch val := value.(type) {
+	case int:
+		return int64(val), true
+	case int8:
+		return int64(val), true
+	case int16:
+		return int64(val), true
+	case int32:
+		return int64(val), true
+	case int64:
+		return val, true
+	case uint8:
+		return int64(val), true
+	case uint16:
+		return int64(val), true
+	case uint32:
+		return int64(val), true
+	case uint:
+		if uint64(val) <= math.MaxInt64 {
+			return int64(val), true
+		}
+	case uint64:
+		if val <= math.MaxInt64 {
+			return int64(val), true
+		}
+	}
+
+	return 0, false
+}
+
+func toFloat(value any) (float64, bool) {
+	switch val := value.(type) {
+	case float64:
+		return val, true
+	case float32:
+		return float64(val), true
+	case uint:
+		return float64(val), true
+	case uint64:
+		return float64(val), true
+	}
+
+	if i, ok := toInt64(value); ok {
+		return float64(i), true
+	}
+
+	return 0, false
+}
+
+func toInt(value any) (int, bool) {
+	f, ok := toFloat(value)
+	if !ok {
+		return 0, false
+	}
+
+	return int(f), true
+}
+
+// equal compares decoded JSON values, treating numbers of different Go types
+// with the same value as equal.
+func equal(a any, b any) bool {
+	fa, aNum := toFloat(a)
+	fb, bNum := toFloat(b)
+
+	if aNum || bNum {
+		return aNum && bNum && fa == fb
+	}
+
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for k, v := range av {
+			if !equal(v, bv[k]) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// normalize converts typed slices and string-keyed maps (e.g. []string from
+// Go-built values) into their generic JSON representation.
+func normalize(value any) any {
+	switch value.(type) {
+	case nil, map[string]any, []any:
+		return value
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch {
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8:
+		result := make([]any, rv.Len())
+		for i := range result {
+			result[i] = rv.Index(i).Interface()
+		}
+
+		return result
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		result := make(map[string]any, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			result[iter.Key().String()] = iter.Value().Interface()
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+func childPath(parent string, key string) string {
+	if parent == rootPath {
+		return key
+	}
+
+	return parent + "." + key
+}
+
+func indexPath(parent string, index int) string {
+	if parent == rootPath {
+		return "[" + strconv.Itoa(index) + "]"
+	}
+
+	return parent + "[" + strconv.Itoa(index) + "]"
+}
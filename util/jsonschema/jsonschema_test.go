@@ -0,0 +1,221 @@
+package jsonschema_test
+
+import (
+	"math"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/k8s-manifest-kit/pkg/util/jsonschema"
+
+	. "github.com/onsi/gomega"
+)
+
+const testValuesSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["image"],
+  "additionalProperties": false,
+  "properties": {
+    "replicaCount": {"type": "integer", "minimum": 1, "maximum": 10},
+    "image": {
+      "type": "object",
+      "required": ["repository"],
+      "properties": {
+        "repository": {"type": "string", "minLength": 1},
+        "tag": {"type": "string", "pattern": "^[a-z0-9.-]+$"},
+        "pullPolicy": {"enum": ["Always", "IfNotPresent", "Never"]}
+      }
+    },
+    "ports": {
+      "type": "array",
+      "maxItems": 2,
+      "items": {"$ref": "#/definitions/port"}
+    },
+    "annotations": {
+      "type": "object",
+      "additionalProperties": {"type": "string"}
+    },
+    "resources": {"type": ["object", "null"]},
+    "mode": {"oneOf": [{"const": "simple"}, {"type": "integer"}]}
+  },
+  "definitions": {
+    "port": {
+      "type": "object",
+      "properties": {
+        "port": {"type": "integer", "exclusiveMaximum": 65536}
+      }
+    }
+  }
+}`
+
+const testValidValues = `
+replicaCount: 3
+image:
+  repository: nginx
+  tag: "1.25.0"
+  pullPolicy: IfNotPresent
+ports:
+- port: 80
+- port: 443
+annotations:
+  team: platform
+resources: null
+mode: simple
+`
+
+const testInvalidValues = `
+replicaCount: "3"
+image:
+  tag: "Latest!"
+  pullPolicy: Sometimes
+ports:
+- port: 80
+- port: 70000
+- port: 8080
+annotations:
+  weight: 10
+extra: true
+mode: 1.5
+`
+
+const testInvalidSchema = `{"properties": {"name": {"pattern": "("}}}`
+
+const testCombinatorSchema = `
+anyOf:
+- type: string
+- type: integer
+not:
+  const: forbidden
+`
+
+func decodeValues(g *WithT, content string) map[string]any {
+	values := map[string]any{}
+	g.Expect(yaml.Unmarshal([]byte(content), &values)).Should(Succeed())
+
+	return values
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("accepts valid values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		schema, err := jsonschema.Compile([]byte(testValuesSchema))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(schema.Validate(decodeValues(g, testValidValues))).Should(Succeed())
+	})
+
+	t.Run("reports every failure with its path", func(t *testing.T) {
+		g := NewWithT(t)
+
+		schema, err := jsonschema.Compile([]byte(testValuesSchema))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		err = schema.Validate(decodeValues(g, testInvalidValues))
+		g.Expect(err).Should(MatchError(jsonschema.ErrValidation))
+
+		var verr *jsonschema.ValidationError
+		g.Expect(err).Should(BeAssignableToTypeOf(verr))
+		verr = err.(*jsonschema.ValidationError)
+
+		paths := make([]string, 0, len(verr.Errors))
+		for _, fe := range verr.Errors {
+			paths = append(paths, fe.Path)
+		}
+
+		g.Expect(paths).Should(ConsistOf(
+			"annotations.weight",
+			"extra",
+			"image.repository",
+			"image.tag",
+			"image.pullPolicy",
+			"mode",
+			"ports",
+			"ports[1].port",
+			"replicaCount",
+		))
+	})
+
+	t.Run("accepts Go typed values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		schema, err := jsonschema.New(map[string]any{
+			"type":  "array",
+			"items": map[string]any{"type": "string"},
+		})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(schema.Validate([]string{"a", "b"})).Should(Succeed())
+		g.Expect(schema.Validate([]int{1})).Should(MatchError(jsonschema.ErrValidation))
+	})
+
+	t.Run("supports anyOf and not", func(t *testing.T) {
+		g := NewWithT(t)
+
+		schema, err := jsonschema.Compile([]byte(testCombinatorSchema))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(schema.Validate("allowed")).Should(Succeed())
+		g.Expect(schema.Validate(int64(3))).Should(Succeed())
+		g.Expect(schema.Validate(true)).Should(MatchError(jsonschema.ErrValidation))
+		g.Expect(schema.Validate("forbidden")).Should(MatchError(jsonschema.ErrValidation))
+	})
+
+	t.Run("checks multipleOf on decimal values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		schema, err := jsonschema.New(map[string]any{"type": "number", "multipleOf": 0.1})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(schema.Validate(0.3)).Should(Succeed())
+		g.Expect(schema.Validate(1.7)).Should(Succeed())
+		g.Expect(schema.Validate(uint64(3))).Should(Succeed())
+		g.Expect(schema.Validate(0.35)).Should(MatchError(jsonschema.ErrValidation))
+	})
+
+	t.Run("accepts anything with an empty schema", func(t *testing.T) {
+		g := NewWithT(t)
+
+		schema, err := jsonschema.New(nil)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(schema.Validate(map[string]any{"any": "thing"})).Should(Succeed())
+	})
+}
+
+func TestCompile(t *testing.T) {
+	t.Run("rejects invalid patterns", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := jsonschema.Compile([]byte(testInvalidSchema))
+
+		g.Expect(err).Should(MatchError(jsonschema.ErrInvalidSchema))
+	})
+
+	t.Run("rejects malformed documents", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := jsonschema.Compile([]byte("{"))
+
+		g.Expect(err).Should(MatchError(jsonschema.ErrInvalidSchema))
+	})
+}
+
+func TestTypeOf(t *testing.T) {
+	t.Run("maps decoded values to schema types", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(jsonschema.TypeOf(nil)).Should(Equal("null"))
+		g.Expect(jsonschema.TypeOf(true)).Should(Equal("boolean"))
+		g.Expect(jsonschema.TypeOf("s")).Should(Equal("string"))
+		g.Expect(jsonschema.TypeOf(1)).Should(Equal("integer"))
+		g.Expect(jsonschema.TypeOf(int64(1))).Should(Equal("integer"))
+		g.Expect(jsonschema.TypeOf(uint(1))).Should(Equal("integer"))
+		g.Expect(jsonschema.TypeOf(uint64(math.MaxUint64))).Should(Equal("integer"))
+		g.Expect(jsonschema.TypeOf(2.0)).Should(Equal("integer"))
+		g.Expect(jsonschema.TypeOf(2.5)).Should(Equal("number"))
+		g.Expect(jsonschema.TypeOf([]any{})).Should(Equal("array"))
+		g.Expect(jsonschema.TypeOf(map[string]any{})).Should(Equal("object"))
+	})
+}
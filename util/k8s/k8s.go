@@ -12,6 +12,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+const yamlIndent = 2
+
 // DecodeYAML decodes YAML content into a slice of unstructured objects.
 func DecodeYAML(content []byte) ([]unstructured.Unstructured, error) {
 	results := make([]unstructured.Unstructured, 0)
@@ -65,6 +67,32 @@ func DecodeYAML(content []byte) ([]unstructured.Unstructured, error) {
 	return results, nil
 }
 
+// EncodeYAML encodes objects as a multi-document YAML stream. Map keys are
+// written in sorted order and documents in the order given, so encoding the
+// same objects always produces the same bytes.
+func EncodeYAML(objects []unstructured.Unstructured) ([]byte, error) {
+	if len(objects) == 0 {
+		return []byte{}, nil
+	}
+
+	var buf bytes.Buffer
+
+	ye := yaml.NewEncoder(&buf)
+	ye.SetIndent(yamlIndent)
+
+	for i := range objects {
+		if err := ye.Encode(objects[i].Object); err != nil {
+			return nil, fmt.Errorf("unable to encode YAML document[%d]: %w", i, err)
+		}
+	}
+
+	if err := ye.Close(); err != nil {
+		return nil, fmt.Errorf("unable to encode YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
 // ToUnstructured converts any object to an unstructured.Unstructured representation.
 func ToUnstructured(obj any) (*unstructured.Unstructured, error) {
 	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
@@ -360,3 +360,60 @@ func TestToUnstructured(t *testing.T) {
 		g.Expect(spec).Should(HaveKey("selector"))
 	})
 }
+
+func TestEncodeYAML(t *testing.T) {
+	t.Run("round trips through DecodeYAML", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(multipleDocumentsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		data, err := k8s.EncodeYAML(objects)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		decoded, err := k8s.DecodeYAML(data)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(decoded).Should(Equal(objects))
+	})
+
+	t.Run("writes map keys in sorted order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := unstructured.Unstructured{
+			Object: map[string]any{
+				"kind":       "ConfigMap",
+				"apiVersion": "v1",
+				"metadata":   map[string]any{"name": "test"},
+				"data":       map[string]any{"b": "2", "a": "1"},
+			},
+		}
+
+		data, err := k8s.EncodeYAML([]unstructured.Unstructured{obj})
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(data)).Should(Equal("apiVersion: v1\ndata:\n  a: \"1\"\n  b: \"2\"\nkind: ConfigMap\nmetadata:\n  name: test\n"))
+	})
+
+	t.Run("produces identical bytes across calls", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(multipleDocumentsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		first, err := k8s.EncodeYAML(objects)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		for range 10 {
+			data, err := k8s.EncodeYAML(objects)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(data).Should(Equal(first))
+		}
+	})
+
+	t.Run("returns empty output for no objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data, err := k8s.EncodeYAML(nil)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(data).Should(BeEmpty())
+	})
+}
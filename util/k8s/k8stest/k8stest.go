@@ -0,0 +1,54 @@
+// Package k8stest provides test helpers for code producing Kubernetes objects.
+package k8stest
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+// DefaultRuns is the number of renders compared by ExpectDeterministic.
+const DefaultRuns = 5
+
+// RenderFunc produces a set of objects, typically by running a full render.
+type RenderFunc func() ([]unstructured.Unstructured, error)
+
+// ExpectDeterministic calls render DefaultRuns times and fails the test unless
+// every run encodes, with k8s.EncodeYAML, to byte-identical output. This
+// catches unstable object ordering and randomly generated values that would
+// otherwise show up as noise in GitOps diffs.
+func ExpectDeterministic(t testing.TB, render RenderFunc) {
+	t.Helper()
+
+	ExpectDeterministicN(t, DefaultRuns, render)
+}
+
+// ExpectDeterministicN is like ExpectDeterministic but compares the given
+// number of runs.
+func ExpectDeterministicN(t testing.TB, runs int, render RenderFunc) {
+	t.Helper()
+
+	g := NewWithT(t)
+
+	var first string
+
+	for i := range runs {
+		objects, err := render()
+		g.Expect(err).ShouldNot(HaveOccurred(), "render[%d] failed", i)
+
+		data, err := k8s.EncodeYAML(objects)
+		g.Expect(err).ShouldNot(HaveOccurred(), "render[%d] could not be encoded", i)
+
+		if i == 0 {
+			first = string(data)
+
+			continue
+		}
+
+		g.Expect(string(data)).Should(Equal(first), "render[%d] differs from render[0]", i)
+	}
+}
@@ -0,0 +1,66 @@
+package k8stest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s/k8stest"
+
+	. "github.com/onsi/gomega"
+)
+
+// recordingT captures failures instead of failing the enclosing test.
+type recordingT struct {
+	testing.TB
+
+	failed bool
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Fatalf(_ string, _ ...any) {
+	r.failed = true
+}
+
+func (r *recordingT) Errorf(_ string, _ ...any) {
+	r.failed = true
+}
+
+func configMap(name string) unstructured.Unstructured {
+	return unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name},
+		},
+	}
+}
+
+func TestExpectDeterministic(t *testing.T) {
+	t.Run("passes for stable renders", func(t *testing.T) {
+		g := NewWithT(t)
+
+		rt := &recordingT{TB: t}
+		k8stest.ExpectDeterministic(rt, func() ([]unstructured.Unstructured, error) {
+			return []unstructured.Unstructured{configMap("a"), configMap("b")}, nil
+		})
+
+		g.Expect(rt.failed).Should(BeFalse())
+	})
+
+	t.Run("fails when renders differ", func(t *testing.T) {
+		g := NewWithT(t)
+
+		calls := 0
+		rt := &recordingT{TB: t}
+		k8stest.ExpectDeterministicN(rt, 3, func() ([]unstructured.Unstructured, error) {
+			calls++
+
+			return []unstructured.Unstructured{configMap(fmt.Sprintf("cm-%d", calls))}, nil
+		})
+
+		g.Expect(rt.failed).Should(BeTrue())
+	})
+}
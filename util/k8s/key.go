@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ResourceKey identifies an object within a rendered set. The API version is
+// deliberately left out so that the same object served at another version of
+// its group keeps the same key.
+type ResourceKey struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// KeyOf returns the ResourceKey of obj.
+func KeyOf(obj *unstructured.Unstructured) ResourceKey {
+	return ResourceKey{
+		Group:     obj.GroupVersionKind().Group,
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+// String returns the key in the form "group/Kind/namespace/name", with "core"
+// standing in for the empty group and the namespace omitted for
+// cluster-scoped objects.
+func (k ResourceKey) String() string {
+	group := k.Group
+	if group == "" {
+		group = "core"
+	}
+
+	if k.Namespace == "" {
+		return group + "/" + k.Kind + "/" + k.Name
+	}
+
+	return group + "/" + k.Kind + "/" + k.Namespace + "/" + k.Name
+}
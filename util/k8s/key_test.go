@@ -0,0 +1,51 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestKeyOf(t *testing.T) {
+	t.Run("ignores the API version", func(t *testing.T) {
+		g := NewWithT(t)
+
+		v1 := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "autoscaling/v1",
+			"kind":       "HorizontalPodAutoscaler",
+			"metadata":   map[string]any{"name": "web", "namespace": "apps"},
+		}}
+		v2 := v1.DeepCopy()
+		v2.SetAPIVersion("autoscaling/v2")
+
+		g.Expect(k8s.KeyOf(v1)).Should(Equal(k8s.KeyOf(v2)))
+		g.Expect(k8s.KeyOf(v1)).Should(Equal(k8s.ResourceKey{
+			Group:     "autoscaling",
+			Kind:      "HorizontalPodAutoscaler",
+			Namespace: "apps",
+			Name:      "web",
+		}))
+	})
+}
+
+func TestResourceKeyString(t *testing.T) {
+	t.Run("formats namespaced keys", func(t *testing.T) {
+		g := NewWithT(t)
+
+		key := k8s.ResourceKey{Group: "apps", Kind: "Deployment", Namespace: "apps", Name: "web"}
+
+		g.Expect(key.String()).Should(Equal("apps/Deployment/apps/web"))
+	})
+
+	t.Run("formats core cluster-scoped keys", func(t *testing.T) {
+		g := NewWithT(t)
+
+		key := k8s.ResourceKey{Kind: "Namespace", Name: "apps"}
+
+		g.Expect(key.String()).Should(Equal("core/Namespace/apps"))
+	})
+}
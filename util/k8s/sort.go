@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"cmp"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// applyOrder lists kinds in the order they must be applied so that each object
+// finds its dependencies already in place: namespaces and CRDs first, then
+// policies, configuration, RBAC, services and workloads. Admission webhooks
+// come last so that they cannot reject objects of the same render before their
+// backing service is running.
+var applyOrder = []string{
+	"Namespace",
+	"CustomResourceDefinition",
+	"PriorityClass",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodSecurityPolicy",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"SecretList",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"ClusterRole",
+	"ClusterRoleList",
+	"ClusterRoleBinding",
+	"ClusterRoleBindingList",
+	"Role",
+	"RoleList",
+	"RoleBinding",
+	"RoleBindingList",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"IngressClass",
+	"Ingress",
+	"APIService",
+}
+
+// lateKinds are applied after every other kind, including custom resources.
+var lateKinds = []string{
+	"MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration",
+}
+
+var applyPriority = func() map[string]int {
+	priorities := make(map[string]int, len(applyOrder)+len(lateKinds))
+
+	for i, kind := range applyOrder {
+		priorities[kind] = i
+	}
+
+	// Unknown kinds (typically custom resources) sort between the known kinds
+	// and the late kinds.
+	for i, kind := range lateKinds {
+		priorities[kind] = len(applyOrder) + 1 + i
+	}
+
+	return priorities
+}()
+
+// ApplyPriority returns the position of kind in the apply order; lower values
+// are applied first. Unknown kinds, such as custom resources, are applied after
+// all built-in kinds but before admission webhook configurations.
+func ApplyPriority(kind string) int {
+	if p, ok := applyPriority[kind]; ok {
+		return p
+	}
+
+	return len(applyOrder)
+}
+
+// SortForApply sorts objects in place into an order suitable for applying them
+// one by one: by kind priority (see ApplyPriority), then by kind, namespace and
+// name so that the result is stable across renders.
+func SortForApply(objects []unstructured.Unstructured) {
+	slices.SortStableFunc(objects, CompareForApply)
+}
+
+// CompareForApply compares two objects according to the SortForApply order.
+func CompareForApply(a unstructured.Unstructured, b unstructured.Unstructured) int {
+	return cmp.Or(
+		cmp.Compare(ApplyPriority(a.GetKind()), ApplyPriority(b.GetKind())),
+		cmp.Compare(a.GetKind(), b.GetKind()),
+		cmp.Compare(a.GetNamespace(), b.GetNamespace()),
+		cmp.Compare(a.GetName(), b.GetName()),
+	)
+}
@@ -0,0 +1,132 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const unsortedYAML = `
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: webhook
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+  namespace: apps
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: apps
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: apps
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: apps
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: apps
+---
+apiVersion: example.com/v1
+kind: Gadget
+metadata:
+  name: gadget
+  namespace: apps
+`
+
+func names(objects []unstructured.Unstructured) []string {
+	result := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		result = append(result, obj.GetKind()+"/"+obj.GetName())
+	}
+
+	return result
+}
+
+func TestSortForApply(t *testing.T) {
+	t.Run("sorts by kind priority then name", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(unsortedYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		k8s.SortForApply(objects)
+
+		g.Expect(names(objects)).Should(Equal([]string{
+			"Namespace/apps",
+			"CustomResourceDefinition/widgets.example.com",
+			"ConfigMap/config",
+			"Service/web",
+			"Deployment/api",
+			"Deployment/web",
+			"Gadget/gadget",
+			"Widget/widget",
+			"ValidatingWebhookConfiguration/webhook",
+		}))
+	})
+
+	t.Run("is stable regardless of input order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(unsortedYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		reversed := make([]unstructured.Unstructured, len(objects))
+		for i := range objects {
+			reversed[len(objects)-1-i] = objects[i]
+		}
+
+		k8s.SortForApply(objects)
+		k8s.SortForApply(reversed)
+
+		g.Expect(names(reversed)).Should(Equal(names(objects)))
+	})
+
+	t.Run("handles empty input", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var objects []unstructured.Unstructured
+		k8s.SortForApply(objects)
+
+		g.Expect(objects).Should(BeEmpty())
+	})
+}
+
+func TestApplyPriority(t *testing.T) {
+	t.Run("places custom resources between built-in kinds and webhooks", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(k8s.ApplyPriority("Namespace")).Should(BeNumerically("<", k8s.ApplyPriority("Deployment")))
+		g.Expect(k8s.ApplyPriority("APIService")).Should(BeNumerically("<", k8s.ApplyPriority("Widget")))
+		g.Expect(k8s.ApplyPriority("Widget")).Should(BeNumerically("<", k8s.ApplyPriority("MutatingWebhookConfiguration")))
+	})
+}
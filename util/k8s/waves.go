@@ -0,0 +1,246 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// DependsOnAnnotation lists, comma-separated, the objects of the render an
+	// object depends on, as "Kind/name" for objects in the same namespace or
+	// cluster-scoped, or "Kind/namespace/name"; the kind is qualified with its
+	// group for non-core kinds, e.g. "Deployment.apps/operator".
+	DependsOnAnnotation = "k8s-manifest-kit.io/depends-on"
+
+	// SyncWaveAnnotation sets the sync wave of an object, an integer
+	// defaulting to 0: objects of lower sync waves are applied first.
+	SyncWaveAnnotation = "k8s-manifest-kit.io/sync-wave"
+)
+
+var (
+	// ErrInvalidDependency is returned when an object has an invalid
+	// DependsOnAnnotation or SyncWaveAnnotation.
+	ErrInvalidDependency = errors.New("invalid dependency")
+
+	// ErrDependencyCycle is returned when objects depend on each other.
+	ErrDependencyCycle = errors.New("dependency cycle")
+)
+
+// Waves splits objects into waves to apply in order, waiting for the objects
+// of a wave to become ready before applying the next one. An object comes
+// after the objects it depends on:
+//
+//   - the objects listed in its DependsOnAnnotation
+//   - the objects of lower sync waves (see SyncWaveAnnotation)
+//   - the CustomResourceDefinition of its kind, when rendered, so that custom
+//     resources are applied once their CRD is established
+//
+// Each object goes into the earliest wave possible, and each wave is sorted
+// with SortForApply. It fails with ErrInvalidDependency for malformed
+// annotations, dependencies on objects missing from the render or in a later
+// sync wave, and with ErrDependencyCycle for circular dependencies.
+func Waves(objects []unstructured.Unstructured) ([][]unstructured.Unstructured, error) {
+	graph, err := newDependencyGraph(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := graph.levels()
+	if err != nil {
+		return nil, err
+	}
+
+	var waves [][]unstructured.Unstructured
+
+	for i, level := range levels {
+		for len(waves) <= level {
+			waves = append(waves, nil)
+		}
+
+		waves[level] = append(waves[level], objects[i])
+	}
+
+	for _, wave := range waves {
+		SortForApply(wave)
+	}
+
+	return waves, nil
+}
+
+// dependencyGraph holds the dependencies between the objects of a render, by
+// index.
+type dependencyGraph struct {
+	keys      []ResourceKey
+	syncWaves []int
+	deps      [][]int
+}
+
+func newDependencyGraph(objects []unstructured.Unstructured) (*dependencyGraph, error) {
+	graph := &dependencyGraph{
+		keys:      make([]ResourceKey, len(objects)),
+		syncWaves: make([]int, len(objects)),
+		deps:      make([][]int, len(objects)),
+	}
+
+	index := make(map[ResourceKey]int, len(objects))
+	crds := make(map[schema.GroupKind]int)
+
+	for i := range objects {
+		graph.keys[i] = KeyOf(&objects[i])
+		index[graph.keys[i]] = i
+
+		if value, ok := objects[i].GetAnnotations()[SyncWaveAnnotation]; ok {
+			wave, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: sync wave %q is not an integer", ErrInvalidDependency, graph.keys[i], value)
+			}
+
+			graph.syncWaves[i] = wave
+		}
+
+		if objects[i].GroupVersionKind().GroupKind() == (schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}) {
+			group, _, _ := unstructured.NestedString(objects[i].Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(objects[i].Object, "spec", "names", "kind")
+			crds[schema.GroupKind{Group: group, Kind: kind}] = i
+		}
+	}
+
+	for i := range objects {
+		if crd, ok := crds[objects[i].GroupVersionKind().GroupKind()]; ok {
+			graph.deps[i] = append(graph.deps[i], crd)
+		}
+
+		for ref := range strings.SplitSeq(objects[i].GetAnnotations()[DependsOnAnnotation], ",") {
+			ref = strings.TrimSpace(ref)
+			if ref == "" {
+				continue
+			}
+
+			dep, err := resolveDependency(index, graph.keys[i], ref)
+			if err != nil {
+				return nil, err
+			}
+
+			graph.deps[i] = append(graph.deps[i], dep)
+		}
+
+		for _, dep := range graph.deps[i] {
+			if graph.syncWaves[dep] > graph.syncWaves[i] {
+				return nil, fmt.Errorf("%w: %s in sync wave %d depends on %s in later sync wave %d",
+					ErrInvalidDependency, graph.keys[i], graph.syncWaves[i], graph.keys[dep], graph.syncWaves[dep])
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// resolveDependency returns the index of the object referenced by ref in the
+// DependsOnAnnotation of the object identified by key.
+func resolveDependency(index map[ResourceKey]int, key ResourceKey, ref string) (int, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+		return 0, fmt.Errorf("%w: %s: reference %q is not Kind/name or Kind/namespace/name", ErrInvalidDependency, key, ref)
+	}
+
+	kind, group, _ := strings.Cut(parts[0], ".")
+	target := ResourceKey{Group: group, Kind: kind, Name: parts[len(parts)-1]}
+
+	if len(parts) == 3 {
+		target.Namespace = parts[1]
+	} else {
+		// Objects in the same namespace take precedence over cluster-scoped
+		// objects.
+		target.Namespace = key.Namespace
+		if _, ok := index[target]; !ok {
+			target.Namespace = ""
+		}
+	}
+
+	dep, ok := index[target]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s depends on %s, which is not rendered", ErrInvalidDependency, key, ref)
+	}
+
+	return dep, nil
+}
+
+// levels returns the wave of each object.
+func (g *dependencyGraph) levels() ([]int, error) {
+	levels := make([]int, len(g.keys))
+	done := make([]bool, len(g.keys))
+	visiting := make([]bool, len(g.keys))
+
+	var path []ResourceKey
+
+	var visit func(i int, base int) error
+	visit = func(i int, base int) error {
+		if done[i] {
+			return nil
+		}
+
+		path = append(path, g.keys[i])
+
+		if visiting[i] {
+			cycle := make([]string, 0, len(path))
+			for _, key := range path[slices.Index(path, g.keys[i]):] {
+				cycle = append(cycle, key.String())
+			}
+
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+		}
+
+		visiting[i] = true
+		levels[i] = base
+
+		for _, dep := range g.deps[i] {
+			// Dependencies of earlier sync waves are in earlier waves.
+			if g.syncWaves[dep] < g.syncWaves[i] {
+				continue
+			}
+
+			if err := visit(dep, base); err != nil {
+				return err
+			}
+
+			levels[i] = max(levels[i], levels[dep]+1)
+		}
+
+		visiting[i] = false
+		done[i] = true
+		path = path[:len(path)-1]
+
+		return nil
+	}
+
+	syncWaves := slices.Clone(g.syncWaves)
+	slices.Sort(syncWaves)
+
+	base := 0
+
+	for _, syncWave := range slices.Compact(syncWaves) {
+		next := base
+
+		for i := range g.keys {
+			if g.syncWaves[i] != syncWave {
+				continue
+			}
+
+			if err := visit(i, base); err != nil {
+				return nil, err
+			}
+
+			next = max(next, levels[i]+1)
+		}
+
+		base = next
+	}
+
+	return levels, nil
+}
@@ -0,0 +1,161 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+
+	. "github.com/onsi/gomega"
+)
+
+const operatorYAML = `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+  namespace: apps
+  annotations:
+    k8s-manifest-kit.io/depends-on: Deployment.apps/operator, ConfigMap/settings
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  namespace: apps
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: apps
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  namespace: apps
+  annotations:
+    k8s-manifest-kit.io/sync-wave: "-1"
+`
+
+func waveNames(waves [][]unstructured.Unstructured) [][]string {
+	result := make([][]string, 0, len(waves))
+	for _, wave := range waves {
+		result = append(result, names(wave))
+	}
+
+	return result
+}
+
+func decodeObjects(g *WithT, content string) []unstructured.Unstructured {
+	objects, err := k8s.DecodeYAML([]byte(content))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return objects
+}
+
+func TestWaves(t *testing.T) {
+	t.Run("should order objects by sync wave and dependencies", func(t *testing.T) {
+		g := NewWithT(t)
+
+		waves, err := k8s.Waves(decodeObjects(g, operatorYAML))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(waveNames(waves)).Should(Equal([][]string{
+			{"Job/migrate"},
+			{"CustomResourceDefinition/widgets.example.com", "ConfigMap/settings", "Deployment/operator"},
+			{"Widget/gadget"},
+		}))
+	})
+
+	t.Run("should resolve cluster-scoped dependencies", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, operatorYAML)
+		k8s.SetAnnotation(&objects[2], k8s.DependsOnAnnotation, "CustomResourceDefinition.apiextensions.k8s.io/widgets.example.com")
+
+		waves, err := k8s.Waves(objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(waveNames(waves)).Should(Equal([][]string{
+			{"Job/migrate"},
+			{"CustomResourceDefinition/widgets.example.com", "Deployment/operator"},
+			{"ConfigMap/settings"},
+			{"Widget/gadget"},
+		}))
+	})
+
+	t.Run("should put independent objects in a single wave", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, operatorYAML)[1:3]
+
+		waves, err := k8s.Waves(objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(waves).Should(HaveLen(1))
+	})
+
+	t.Run("should reject dependency cycles", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, operatorYAML)
+		k8s.SetAnnotation(&objects[1], k8s.DependsOnAnnotation, "Widget.example.com/gadget")
+
+		_, err := k8s.Waves(objects)
+
+		g.Expect(err).Should(MatchError(k8s.ErrDependencyCycle))
+		g.Expect(err).Should(MatchError(ContainSubstring(
+			"example.com/Widget/apps/gadget -> apps/Deployment/apps/operator -> example.com/Widget/apps/gadget")))
+	})
+
+	t.Run("should reject dependencies on objects missing from the render", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, operatorYAML)
+		k8s.SetAnnotation(&objects[1], k8s.DependsOnAnnotation, "Secret/credentials")
+
+		_, err := k8s.Waves(objects)
+
+		g.Expect(err).Should(MatchError(k8s.ErrInvalidDependency))
+		g.Expect(err).Should(MatchError(ContainSubstring("depends on Secret/credentials, which is not rendered")))
+	})
+
+	t.Run("should reject dependencies on later sync waves", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, operatorYAML)
+		k8s.SetAnnotation(&objects[4], k8s.DependsOnAnnotation, "ConfigMap/settings")
+
+		_, err := k8s.Waves(objects)
+
+		g.Expect(err).Should(MatchError(ContainSubstring("in sync wave -1 depends on core/ConfigMap/apps/settings in later sync wave 0")))
+	})
+
+	t.Run("should reject malformed annotations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, operatorYAML)
+		k8s.SetAnnotation(&objects[1], k8s.SyncWaveAnnotation, "first")
+
+		_, err := k8s.Waves(objects)
+		g.Expect(err).Should(MatchError(k8s.ErrInvalidDependency))
+
+		objects = decodeObjects(g, operatorYAML)
+		k8s.SetAnnotation(&objects[1], k8s.DependsOnAnnotation, "operator")
+
+		_, err = k8s.Waves(objects)
+		g.Expect(err).Should(MatchError(ContainSubstring(`reference "operator" is not Kind/name or Kind/namespace/name`)))
+	})
+}
@@ -0,0 +1,142 @@
+package krm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+	"github.com/k8s-manifest-kit/pkg/util/process"
+)
+
+const (
+	defaultContainerRuntime = "docker"
+)
+
+// ErrImageEmpty is returned when a container function is created without an image.
+var ErrImageEmpty = errors.New("krm: image cannot be empty or whitespace-only")
+
+// Function is a KRM function invoked as an external process, either directly
+// (exec) or through a container runtime. Each invocation encodes the objects
+// as a ResourceList on stdin and decodes the processed ResourceList from stdout.
+type Function struct {
+	name    string
+	command string
+	process process.Options
+	config  *unstructured.Unstructured
+}
+
+// NewExecFunction creates a KRM function that executes the binary at path.
+// The function does not inherit the environment of the caller: it only sees
+// the variables listed with WithEnvAllowlist and those set with WithEnv.
+func NewExecFunction(path string, opts ...Option) (*Function, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, utilerrors.ErrPathEmpty
+	}
+
+	options := Options{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	return &Function{
+		name:    path,
+		command: path,
+		process: options.Options,
+		config:  options.Config,
+	}, nil
+}
+
+// NewContainerFunction creates a KRM function that runs the given image with
+// a container runtime (docker by default). Containers run without network
+// access unless WithNetwork is set.
+func NewContainerFunction(image string, opts ...Option) (*Function, error) {
+	if strings.TrimSpace(image) == "" {
+		return nil, ErrImageEmpty
+	}
+
+	options := Options{
+		Runtime: defaultContainerRuntime,
+	}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	if options.Runtime == "" {
+		options.Runtime = defaultContainerRuntime
+	}
+
+	args := []string{"run", "--rm", "-i"}
+	if !options.Network {
+		args = append(args, "--network", "none")
+	}
+
+	// Environment variables must be forwarded explicitly as the container
+	// does not inherit the environment of the runtime process. They are all
+	// passed by name, the runtime reading their value from its own
+	// environment, so that values never appear on its command line.
+	for _, name := range options.EnvAllowlist {
+		args = append(args, "-e", name)
+	}
+
+	for _, env := range options.Env {
+		name, _, _ := strings.Cut(env, "=")
+		args = append(args, "-e", name)
+	}
+
+	args = append(args, image)
+	args = append(args, options.Args...)
+
+	return &Function{
+		name:    image,
+		command: options.Runtime,
+		process: process.Options{
+			Args:       args,
+			Env:        options.Env,
+			InheritEnv: true,
+			WorkDir:    options.WorkDir,
+			Timeout:    options.Timeout,
+		},
+		config: options.Config,
+	}, nil
+}
+
+// Name returns the binary path or image identifying the function.
+func (f *Function) Name() string {
+	return f.name
+}
+
+// Transform runs the function over the given objects and returns the objects
+// emitted by the function. Results with error severity, a non-zero exit code,
+// a timeout, or an invalid output all cause an error to be returned.
+func (f *Function) Transform(ctx context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	in, err := Encode(&ResourceList{
+		Items:          objects,
+		FunctionConfig: f.config,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("krm function %s: %w", f.name, err)
+	}
+
+	// The container runtime is trusted with the environment of the caller and
+	// forwards the configured variables through -e flags.
+	output, err := process.Run(ctx, f.command, in, f.process)
+	if err != nil {
+		return nil, fmt.Errorf("krm function %s failed: %w: %s", f.name, err, strings.TrimSpace(string(output.Stderr)))
+	}
+
+	out, err := Decode(output.Stdout)
+	if err != nil {
+		return nil, fmt.Errorf("krm function %s: %w", f.name, err)
+	}
+
+	if err := out.Err(); err != nil {
+		return nil, fmt.Errorf("krm function %s: %w", f.name, err)
+	}
+
+	return out.Items, nil
+}
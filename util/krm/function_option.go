@@ -0,0 +1,92 @@
+package krm
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+	"github.com/k8s-manifest-kit/pkg/util/process"
+)
+
+// Option is a generic option for Function.
+type Option = util.Option[Options]
+
+// Options is a struct-based option that can set multiple function options at once.
+type Options struct {
+	// Options are the arguments, environment, working directory and timeout
+	// of the function process. Container functions pass Args to the image
+	// and forward Env and EnvAllowlist to the container.
+	process.Options
+
+	// Config is the functionConfig passed to the function.
+	Config *unstructured.Unstructured
+
+	// Runtime is the container runtime binary used by container functions.
+	Runtime string
+
+	// Network enables network access for container functions.
+	Network bool
+}
+
+// ApplyTo applies the function options to the target configuration.
+func (opts Options) ApplyTo(target *Options) {
+	opts.Options.ApplyTo(&target.Options)
+
+	if opts.Config != nil {
+		target.Config = opts.Config
+	}
+	if opts.Runtime != "" {
+		target.Runtime = opts.Runtime
+	}
+	if opts.Network {
+		target.Network = opts.Network
+	}
+}
+
+// WithArgs sets additional arguments passed to the function.
+func WithArgs(args ...string) Option {
+	return process.WithArgs[Options](args...)
+}
+
+// WithEnv adds an environment variable made available to the function.
+func WithEnv(key string, value string) Option {
+	return process.WithEnv[Options](key, value)
+}
+
+// WithEnvAllowlist passes the named variables of the calling process through
+// to the function.
+func WithEnvAllowlist(names ...string) Option {
+	return process.WithEnvAllowlist[Options](names...)
+}
+
+// WithWorkDir sets the working directory of the function process.
+func WithWorkDir(dir string) Option {
+	return process.WithWorkDir[Options](dir)
+}
+
+// WithTimeout bounds the duration of a single function run.
+func WithTimeout(timeout time.Duration) Option {
+	return process.WithTimeout[Options](timeout)
+}
+
+// WithConfig sets the functionConfig passed to the function.
+func WithConfig(config *unstructured.Unstructured) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Config = config
+	})
+}
+
+// WithRuntime sets the container runtime binary (e.g. docker, podman).
+func WithRuntime(runtime string) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Runtime = runtime
+	})
+}
+
+// WithNetwork enables network access for container functions.
+func WithNetwork(enabled bool) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Network = enabled
+	})
+}
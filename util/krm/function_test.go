@@ -0,0 +1,236 @@
+package krm_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+	"github.com/k8s-manifest-kit/pkg/util/krm"
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+
+	. "github.com/onsi/gomega"
+)
+
+// Scripts emulating KRM functions; they only rely on a POSIX shell.
+const (
+	testShell = "/bin/sh"
+
+	testIdentityScript = `cat`
+
+	testFailingScript = `cat >/dev/null; echo "boom" >&2; exit 3`
+
+	testErrorResultsScript = `cat >/dev/null; cat <<'EOF'
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items: []
+results:
+- message: policy violated
+  severity: error
+EOF`
+
+	testEnvScript = `cat >/dev/null; cat <<EOF
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: $FN_NAME
+EOF`
+
+	testAllowlistScript = `cat >/dev/null; cat <<EOF
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: fn-${ALLOWED:-unset}-${DENIED:-unset}
+EOF`
+
+	testGarbageScript = `cat >/dev/null; echo "not yaml: ["`
+
+	testSleepScript = `while :; do :; done`
+
+	// testRuntimeScript emulates a container runtime, echoing the variable
+	// it forwards and its own arguments.
+	testRuntimeScript = `#!/bin/sh
+cat >/dev/null
+cat <<EOF
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: $FN_NAME
+  data:
+    args: "$*"
+EOF`
+)
+
+// Function must be usable as a pipeline stage.
+var _ transform.Transformer = (*krm.Function)(nil)
+
+func testObjects() []unstructured.Unstructured {
+	return []unstructured.Unstructured{
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "config"},
+		}},
+	}
+}
+
+func TestNewExecFunction(t *testing.T) {
+	t.Run("rejects an empty path", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := krm.NewExecFunction("  ")
+
+		g.Expect(err).Should(MatchError(utilerrors.ErrPathEmpty))
+	})
+
+	t.Run("passes objects through an identity function", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fn, err := krm.NewExecFunction(testShell, krm.WithArgs("-c", testIdentityScript))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(fn.Name()).Should(Equal(testShell))
+
+		result, err := fn.Transform(t.Context(), testObjects())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(Equal(testObjects()))
+	})
+
+	t.Run("exposes configured environment", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fn, err := krm.NewExecFunction(testShell,
+			krm.WithArgs("-c", testEnvScript),
+			krm.WithEnv("FN_NAME", "from-env"),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := fn.Transform(t.Context(), testObjects())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(1))
+		g.Expect(result[0].GetName()).Should(Equal("from-env"))
+	})
+
+	t.Run("only exposes allowlisted and configured variables", func(t *testing.T) {
+		g := NewWithT(t)
+
+		t.Setenv("ALLOWED", "yes")
+		t.Setenv("DENIED", "yes")
+
+		fn, err := krm.NewExecFunction(testShell,
+			krm.WithArgs("-c", testAllowlistScript),
+			krm.WithEnvAllowlist("ALLOWED"),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := fn.Transform(t.Context(), testObjects())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(1))
+		g.Expect(result[0].GetName()).Should(Equal("fn-yes-unset"))
+	})
+
+	t.Run("stops the function after the timeout", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fn, err := krm.NewExecFunction(testShell,
+			krm.WithArgs("-c", testSleepScript),
+			krm.WithTimeout(50*time.Millisecond),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = fn.Transform(t.Context(), testObjects())
+
+		g.Expect(err).Should(MatchError(context.DeadlineExceeded))
+	})
+
+	t.Run("reports non-zero exit codes with stderr", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fn, err := krm.NewExecFunction(testShell, krm.WithArgs("-c", testFailingScript))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = fn.Transform(t.Context(), testObjects())
+
+		g.Expect(err).Should(HaveOccurred())
+		g.Expect(err.Error()).Should(ContainSubstring("boom"))
+	})
+
+	t.Run("fails on error results", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fn, err := krm.NewExecFunction(testShell, krm.WithArgs("-c", testErrorResultsScript))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = fn.Transform(t.Context(), testObjects())
+
+		g.Expect(err).Should(MatchError(krm.ErrFunctionResults))
+		g.Expect(err.Error()).Should(ContainSubstring("policy violated"))
+	})
+
+	t.Run("fails on invalid output", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fn, err := krm.NewExecFunction(testShell, krm.WithArgs("-c", testGarbageScript))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = fn.Transform(t.Context(), testObjects())
+
+		g.Expect(err).Should(HaveOccurred())
+	})
+}
+
+func TestNewContainerFunction(t *testing.T) {
+	t.Run("rejects an empty image", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := krm.NewContainerFunction("")
+
+		g.Expect(err).Should(MatchError(krm.ErrImageEmpty))
+	})
+
+	t.Run("forwards variables by name only", func(t *testing.T) {
+		g := NewWithT(t)
+
+		runtime := filepath.Join(t.TempDir(), "runtime")
+		g.Expect(os.WriteFile(runtime, []byte(testRuntimeScript), 0o700)).Should(Succeed())
+
+		fn, err := krm.NewContainerFunction("example.com/fn:v1",
+			krm.WithRuntime(runtime),
+			krm.WithEnv("FN_NAME", "from-env"),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result, err := fn.Transform(t.Context(), nil)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(1))
+		g.Expect(result[0].GetName()).Should(Equal("from-env"))
+
+		args, _, err := unstructured.NestedString(result[0].Object, "data", "args")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(args).Should(Equal("run --rm -i --network none -e FN_NAME example.com/fn:v1"))
+	})
+
+	t.Run("is identified by its image", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fn, err := krm.NewContainerFunction("ghcr.io/kptdev/krm-functions-catalog/set-labels:v0.2", krm.WithRuntime("podman"))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(fn.Name()).Should(Equal("ghcr.io/kptdev/krm-functions-catalog/set-labels:v0.2"))
+	})
+}
@@ -0,0 +1,197 @@
+// Package krm implements the KRM functions specification, allowing rendered
+// objects to be processed by the ecosystem of kpt/kustomize functions.
+//
+// See https://github.com/kubernetes-sigs/kustomize/blob/master/cmd/config/docs/api-conventions/functions-spec.md.
+package krm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const (
+	// ResourceListAPIVersion is the apiVersion of the ResourceList wire format.
+	ResourceListAPIVersion = "config.kubernetes.io/v1"
+
+	// ResourceListKind is the kind of the ResourceList wire format.
+	ResourceListKind = "ResourceList"
+
+	// SeverityError marks a result that causes the function invocation to fail.
+	SeverityError = "error"
+
+	// SeverityWarning marks a result that is reported but does not fail the invocation.
+	SeverityWarning = "warning"
+
+	// SeverityInfo marks an informational result.
+	SeverityInfo = "info"
+)
+
+var (
+	// ErrInvalidResourceList is returned when a document is not a valid ResourceList.
+	ErrInvalidResourceList = errors.New("krm: invalid resource list")
+
+	// ErrFunctionResults is returned when a function reports results with error severity.
+	ErrFunctionResults = errors.New("krm: function reported errors")
+)
+
+// ResourceList is the input and output of a KRM function.
+type ResourceList struct {
+	// Items are the objects being processed by the function.
+	Items []unstructured.Unstructured
+
+	// FunctionConfig is the optional configuration of the function.
+	FunctionConfig *unstructured.Unstructured
+
+	// Results are the structured results reported by the function.
+	Results []Result
+}
+
+// Result is a single structured result reported by a KRM function.
+type Result struct {
+	Message  string `yaml:"message"`
+	Severity string `yaml:"severity,omitempty"`
+
+	// ResourceRef identifies the object the result refers to, if any.
+	ResourceRef map[string]any `yaml:"resourceRef,omitempty"`
+
+	// Field identifies the field the result refers to, if any.
+	Field map[string]any `yaml:"field,omitempty"`
+
+	// File identifies the file the result refers to, if any.
+	File map[string]any `yaml:"file,omitempty"`
+
+	Tags map[string]string `yaml:"tags,omitempty"`
+}
+
+// String returns a human-readable representation of the result.
+func (r Result) String() string {
+	var sb strings.Builder
+
+	if r.Severity != "" {
+		sb.WriteString("[")
+		sb.WriteString(r.Severity)
+		sb.WriteString("] ")
+	}
+
+	if r.ResourceRef != nil {
+		kind, _ := r.ResourceRef["kind"].(string)
+		name, _ := r.ResourceRef["name"].(string)
+
+		if kind != "" || name != "" {
+			sb.WriteString(kind)
+			sb.WriteString("/")
+			sb.WriteString(name)
+			sb.WriteString(": ")
+		}
+	}
+
+	sb.WriteString(r.Message)
+
+	return sb.String()
+}
+
+// Err returns an error wrapping ErrFunctionResults describing all the results
+// with error severity, or nil if there are none.
+func (rl *ResourceList) Err() error {
+	if rl == nil {
+		return nil
+	}
+
+	messages := make([]string, 0)
+	for _, r := range rl.Results {
+		if r.Severity == SeverityError {
+			messages = append(messages, r.String())
+		}
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrFunctionResults, strings.Join(messages, "; "))
+}
+
+// Encode serializes the ResourceList to its YAML wire format.
+func Encode(rl *ResourceList) ([]byte, error) {
+	items := make([]any, 0, len(rl.Items))
+	for _, item := range rl.Items {
+		items = append(items, item.Object)
+	}
+
+	out := map[string]any{
+		"apiVersion": ResourceListAPIVersion,
+		"kind":       ResourceListKind,
+		"items":      items,
+	}
+
+	if rl.FunctionConfig != nil {
+		out["functionConfig"] = rl.FunctionConfig.Object
+	}
+
+	if len(rl.Results) > 0 {
+		out["results"] = rl.Results
+	}
+
+	data, err := yaml.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode resource list: %w", err)
+	}
+
+	return data, nil
+}
+
+// Decode parses a ResourceList from its YAML (or JSON) wire format.
+func Decode(content []byte) (*ResourceList, error) {
+	var in struct {
+		APIVersion     string         `yaml:"apiVersion"`
+		Kind           string         `yaml:"kind"`
+		Items          []any          `yaml:"items"`
+		FunctionConfig map[string]any `yaml:"functionConfig"`
+		Results        []Result       `yaml:"results"`
+	}
+
+	if err := yaml.Unmarshal(content, &in); err != nil {
+		return nil, fmt.Errorf("unable to decode resource list: %w", err)
+	}
+
+	if in.Kind != ResourceListKind {
+		return nil, fmt.Errorf("%w: unexpected kind %q", ErrInvalidResourceList, in.Kind)
+	}
+
+	rl := ResourceList{
+		Items:   make([]unstructured.Unstructured, 0, len(in.Items)),
+		Results: in.Results,
+	}
+
+	for i, item := range in.Items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: items[%d] is %T, not an object", ErrInvalidResourceList, i, item)
+		}
+
+		obj, err := k8s.ToUnstructured(&m)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode resource list items[%d]: %w", i, err)
+		}
+
+		rl.Items = append(rl.Items, *obj)
+	}
+
+	if in.FunctionConfig != nil {
+		obj, err := k8s.ToUnstructured(&in.FunctionConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode resource list functionConfig: %w", err)
+		}
+
+		rl.FunctionConfig = obj
+	}
+
+	return &rl, nil
+}
@@ -0,0 +1,164 @@
+package krm_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/krm"
+
+	. "github.com/onsi/gomega"
+)
+
+const testResourceListYAML = `
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: config
+  data:
+    key: value
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: app
+  spec:
+    replicas: 3
+functionConfig:
+  apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: fn-config
+  data:
+    team: platform
+results:
+- message: replicas should be odd
+  severity: warning
+  resourceRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: app
+`
+
+const testResourceListWithErrorsYAML = `
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items: []
+results:
+- message: missing owner label
+  severity: error
+  resourceRef:
+    kind: Deployment
+    name: app
+- message: looks fine otherwise
+  severity: info
+`
+
+const testNotAResourceListYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`
+
+const testInvalidItemYAML = `
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- just-a-string
+`
+
+func TestDecode(t *testing.T) {
+	t.Run("decodes items, function config and results", func(t *testing.T) {
+		g := NewWithT(t)
+
+		rl, err := krm.Decode([]byte(testResourceListYAML))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(rl.Items).Should(HaveLen(2))
+		g.Expect(rl.Items[0].GetKind()).Should(Equal("ConfigMap"))
+		g.Expect(rl.Items[1].GetName()).Should(Equal("app"))
+
+		replicas, found, err := unstructured.NestedInt64(rl.Items[1].Object, "spec", "replicas")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(found).Should(BeTrue())
+		g.Expect(replicas).Should(Equal(int64(3)))
+
+		g.Expect(rl.FunctionConfig).ShouldNot(BeNil())
+		g.Expect(rl.FunctionConfig.GetName()).Should(Equal("fn-config"))
+
+		g.Expect(rl.Results).Should(HaveLen(1))
+		g.Expect(rl.Results[0].Severity).Should(Equal(krm.SeverityWarning))
+		g.Expect(rl.Err()).ShouldNot(HaveOccurred())
+	})
+
+	t.Run("rejects documents that are not a ResourceList", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := krm.Decode([]byte(testNotAResourceListYAML))
+
+		g.Expect(err).Should(MatchError(krm.ErrInvalidResourceList))
+	})
+
+	t.Run("rejects items that are not objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := krm.Decode([]byte(testInvalidItemYAML))
+
+		g.Expect(err).Should(MatchError(krm.ErrInvalidResourceList))
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("round trips through Decode", func(t *testing.T) {
+		g := NewWithT(t)
+
+		rl, err := krm.Decode([]byte(testResourceListYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		data, err := krm.Encode(rl)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		decoded, err := krm.Decode(data)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(decoded.Items).Should(Equal(rl.Items))
+		g.Expect(decoded.FunctionConfig).Should(Equal(rl.FunctionConfig))
+		g.Expect(decoded.Results).Should(Equal(rl.Results))
+	})
+
+	t.Run("encodes an empty list", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data, err := krm.Encode(&krm.ResourceList{})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		decoded, err := krm.Decode(data)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(decoded.Items).Should(BeEmpty())
+		g.Expect(decoded.FunctionConfig).Should(BeNil())
+	})
+}
+
+func TestResourceListErr(t *testing.T) {
+	t.Run("reports results with error severity", func(t *testing.T) {
+		g := NewWithT(t)
+
+		rl, err := krm.Decode([]byte(testResourceListWithErrorsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		err = rl.Err()
+		g.Expect(err).Should(MatchError(krm.ErrFunctionResults))
+		g.Expect(err.Error()).Should(ContainSubstring("Deployment/app: missing owner label"))
+		g.Expect(err.Error()).ShouldNot(ContainSubstring("looks fine"))
+	})
+
+	t.Run("handles nil receiver", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var rl *krm.ResourceList
+
+		g.Expect(rl.Err()).ShouldNot(HaveOccurred())
+	})
+}
@@ -0,0 +1,167 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+)
+
+const (
+	// ExemptAnnotation waives rules on the annotated object, as a
+	// comma-separated list of rule IDs, e.g. "host-network,run-as-root". It is
+	// only honored together with ExemptJustificationAnnotation.
+	ExemptAnnotation = "k8s-manifest-kit.io/exempt"
+
+	// ExemptJustificationAnnotation records why the rules listed in the
+	// ExemptAnnotation are waived.
+	ExemptJustificationAnnotation = "k8s-manifest-kit.io/exempt-justification"
+
+	// RuleExemptionInvalid reports ExemptAnnotations without justification or
+	// naming unknown rules.
+	RuleExemptionInvalid = "exemption-invalid"
+)
+
+// Exemption waives the findings of rules on selected objects.
+type Exemption struct {
+	// Rules are the IDs of the waived rules; empty waives all rules.
+	Rules []string
+
+	// Target selects the exempted objects.
+	Target transform.Selector
+
+	// Justification records why the findings are waived.
+	Justification string
+}
+
+// exemption is a compiled Exemption.
+type exemption struct {
+	Exemption
+
+	matches func(obj *unstructured.Unstructured) bool
+}
+
+// compileExemptions checks that options reference known rules and compiles
+// the exemptions.
+func compileExemptions(rules []Rule, options Options) ([]exemption, error) {
+	known := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		known[r.ID] = true
+	}
+
+	ids := slices.Concat(options.Disabled, slices.Sorted(maps.Keys(options.Severities)))
+	for _, e := range options.Exemptions {
+		ids = append(ids, e.Rules...)
+	}
+
+	for _, id := range ids {
+		if !known[id] {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownRule, id)
+		}
+	}
+
+	exemptions := make([]exemption, 0, len(options.Exemptions))
+
+	for i, e := range options.Exemptions {
+		matches, err := e.Target.Matcher()
+		if err != nil {
+			return nil, fmt.Errorf("exemption[%d]: %w", i, err)
+		}
+
+		exemptions = append(exemptions, exemption{Exemption: e, matches: matches})
+	}
+
+	return exemptions, nil
+}
+
+// exempted returns the justification of the exemption waiving rule on obj, if
+// any: an Exemption of the options, or else the ExemptAnnotation of obj.
+// RuleExemptionInvalid cannot be waived by annotations.
+func exempted(exemptions []exemption, rule string, obj *unstructured.Unstructured) (string, bool) {
+	if obj == nil {
+		return "", false
+	}
+
+	for _, e := range exemptions {
+		if (len(e.Rules) == 0 || slices.Contains(e.Rules, rule)) && e.matches(obj) {
+			return e.Justification, true
+		}
+	}
+
+	ids, justification := annotatedExemption(obj)
+	if rule == RuleExemptionInvalid || justification == "" || !slices.Contains(ids, rule) {
+		return "", false
+	}
+
+	return justification, true
+}
+
+// annotatedExemption returns the rule IDs listed in the ExemptAnnotation of obj
+// and the justification of the exemption.
+func annotatedExemption(obj *unstructured.Unstructured) ([]string, string) {
+	annotations := obj.GetAnnotations()
+
+	var ids []string
+
+	for id := range strings.SplitSeq(annotations[ExemptAnnotation], ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, strings.TrimSpace(annotations[ExemptJustificationAnnotation])
+}
+
+// exemptionRule returns the rule checking the ExemptAnnotations of objects
+// against rules.
+func exemptionRule(rules []Rule) Rule {
+	known := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		known[r.ID] = true
+	}
+
+	return Rule{
+		ID:          RuleExemptionInvalid,
+		Severity:    validate.SeverityError,
+		Description: "Exemption annotations name known rules and carry a justification.",
+		Check: func(objects []unstructured.Unstructured) validate.Findings {
+			var findings validate.Findings
+
+			for i := range objects {
+				if _, ok := objects[i].GetAnnotations()[ExemptAnnotation]; !ok {
+					continue
+				}
+
+				ids, justification := annotatedExemption(&objects[i])
+
+				var problems []string
+
+				if justification == "" {
+					problems = append(problems, "has no "+ExemptJustificationAnnotation+" annotation and is ignored")
+				}
+
+				for _, id := range ids {
+					if !known[id] {
+						problems = append(problems, fmt.Sprintf("names unknown rule %q", id))
+					}
+				}
+
+				for _, p := range problems {
+					findings = append(findings, validate.Finding{
+						Object:  k8s.KeyOf(&objects[i]),
+						Path:    "metadata.annotations",
+						Message: "exemption " + p,
+					})
+				}
+			}
+
+			return findings
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/lint"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+const testAnnotatedExemptions = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: agent
+  namespace: monitoring
+  annotations:
+    k8s-manifest-kit.io/exempt: host-network, host-pid-ipc
+    k8s-manifest-kit.io/exempt-justification: node exporter reads host metrics
+spec:
+  hostNetwork: true
+  hostPID: true
+  containers:
+    - name: agent
+      image: registry.example.com/agent:2.0
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+  namespace: monitoring
+  annotations:
+    k8s-manifest-kit.io/exempt: host-network,no-such-rule
+spec:
+  hostNetwork: true
+  containers:
+    - name: debug
+      image: registry.example.com/debug:1.0
+`
+
+func TestAnnotatedExemptions(t *testing.T) {
+	t.Run("should mark findings waived by annotations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings := lintOnly(t, lint.RuleHostNetwork, testAnnotatedExemptions)
+
+		g.Expect(findings).Should(HaveExactElements(
+			And(
+				HaveField("Object.Name", "agent"),
+				HaveField("Exempted", true),
+				HaveField("Justification", "node exporter reads host metrics"),
+			),
+			And(
+				HaveField("Object.Name", "debug"),
+				HaveField("Exempted", false),
+			),
+		))
+		g.Expect(lintOnly(t, lint.RuleHostNamespaces, testAnnotatedExemptions)).Should(HaveEach(
+			HaveField("Exempted", true),
+		))
+	})
+
+	t.Run("should report invalid exemption annotations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleExemptionInvalid, testAnnotatedExemptions)).Should(HaveExactElements(
+			And(
+				HaveField("Severity", validate.SeverityError),
+				HaveField("Object.Name", "debug"),
+				HaveField("Path", "metadata.annotations"),
+				HaveField("Message", "exemption has no k8s-manifest-kit.io/exempt-justification annotation and is ignored"),
+			),
+			HaveField("Message", `exemption names unknown rule "no-such-rule"`),
+		))
+	})
+
+	t.Run("should surface exemptions in reports", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := validate.NewReport(lintOnly(t, lint.RuleHostNamespaces, testAnnotatedExemptions))
+
+		g.Expect(report.Passed(validate.SeverityInfo)).Should(BeTrue())
+		g.Expect(report.Exempted).Should(Equal(1))
+	})
+}
@@ -0,0 +1,96 @@
+// Package lint checks rendered objects against best practices. Rules report
+// validate findings, so a linter runs standalone or as a validation stage:
+//
+//	findings, err := lint.New().Validate(ctx, objects)
+//
+//	transform.Apply(ctx, objects, validate.Stage(lint.New(), validate.WithFailOn(validate.SeverityWarning)))
+package lint
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+)
+
+// ErrUnknownRule is returned when options reference a rule that does not exist.
+var ErrUnknownRule = errors.New("unknown lint rule")
+
+// CheckFunc returns the problems a rule finds in objects. The Rule and
+// Severity of the returned findings are set by the linter.
+type CheckFunc func(objects []unstructured.Unstructured) validate.Findings
+
+// Rule is a lint check.
+type Rule struct {
+	// ID identifies the rule in findings and options, e.g. "host-network".
+	ID string
+
+	// Severity is the default severity of the findings of the rule.
+	Severity validate.Severity
+
+	// Description explains what the rule checks.
+	Description string
+
+	// Check finds the problems in the rendered objects.
+	Check CheckFunc
+}
+
+// New returns a Validator running the built-in rules, returned by
+// DefaultRules, followed by the rules added with WithRules and by
+// RuleExemptionInvalid. Rules are run in order, so findings are grouped by
+// rule and then listed in object order. Findings waived by WithExemptions or
+// by the ExemptAnnotation of their object are kept, marked as exempted.
+func New(opts ...Option) validate.Validator {
+	options := Options{}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	rules := slices.Concat(DefaultRules(), options.Rules)
+	rules = append(rules, exemptionRule(rules))
+
+	exemptions, err := compileExemptions(rules, options)
+	if err != nil {
+		return validate.Func(func(_ context.Context, _ []unstructured.Unstructured) (validate.Findings, error) {
+			return nil, err
+		})
+	}
+
+	return validate.Func(func(ctx context.Context, objects []unstructured.Unstructured) (validate.Findings, error) {
+		var findings validate.Findings
+
+		byKey := make(map[k8s.ResourceKey]*unstructured.Unstructured, len(objects))
+		for i := range objects {
+			byKey[k8s.KeyOf(&objects[i])] = &objects[i]
+		}
+
+		for _, r := range rules {
+			if slices.Contains(options.Disabled, r.ID) {
+				continue
+			}
+
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			severity := r.Severity
+			if s, ok := options.Severities[r.ID]; ok {
+				severity = s
+			}
+
+			for _, f := range r.Check(objects) {
+				f.Rule = r.ID
+				f.Severity = severity
+				f.Justification, f.Exempted = exempted(exemptions, r.ID, byKey[f.Object])
+				findings = append(findings, f)
+			}
+		}
+
+		return findings, nil
+	})
+}
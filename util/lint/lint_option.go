@@ -0,0 +1,73 @@
+package lint
+
+import (
+	"maps"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+)
+
+// Option is a generic option for New.
+type Option = util.Option[Options]
+
+// Options is a struct-based option that can set linter options.
+type Options struct {
+	// Rules are custom rules run after the built-in ones.
+	Rules []Rule
+
+	// Disabled lists the IDs of the rules not to run.
+	Disabled []string
+
+	// Severities overrides the severity of rules by ID.
+	Severities map[string]validate.Severity
+
+	// Exemptions waive the findings of rules on selected objects.
+	Exemptions []Exemption
+}
+
+// ApplyTo applies the linter options to the target configuration.
+func (opts Options) ApplyTo(target *Options) {
+	target.Rules = append(target.Rules, opts.Rules...)
+	target.Disabled = append(target.Disabled, opts.Disabled...)
+	target.Exemptions = append(target.Exemptions, opts.Exemptions...)
+
+	if len(opts.Severities) > 0 {
+		if target.Severities == nil {
+			target.Severities = make(map[string]validate.Severity, len(opts.Severities))
+		}
+
+		maps.Copy(target.Severities, opts.Severities)
+	}
+}
+
+// WithRules adds custom rules, run after the built-in ones.
+func WithRules(rules ...Rule) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Rules = append(opts.Rules, rules...)
+	})
+}
+
+// WithoutRules disables the rules with the given IDs.
+func WithoutRules(ids ...string) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Disabled = append(opts.Disabled, ids...)
+	})
+}
+
+// WithRuleSeverity overrides the severity of the rule with the given ID.
+func WithRuleSeverity(id string, severity validate.Severity) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		if opts.Severities == nil {
+			opts.Severities = make(map[string]validate.Severity)
+		}
+
+		opts.Severities[id] = severity
+	})
+}
+
+// WithExemptions waives the findings of rules on the selected objects.
+func WithExemptions(exemptions ...Exemption) Option {
+	return util.FunctionalOption[Options](func(opts *Options) {
+		opts.Exemptions = append(opts.Exemptions, exemptions...)
+	})
+}
@@ -0,0 +1,162 @@
+package lint_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/lint"
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+func defaultRuleIDs() []string {
+	var ids []string
+	for _, r := range lint.DefaultRules() {
+		ids = append(ids, r.ID)
+	}
+
+	return ids
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should group findings by rule", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := lint.New().Validate(t.Context(), decode(t, testNonCompliantDeployment))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(
+			HaveField("Rule", lint.RuleResourcesMissing),
+			HaveField("Rule", lint.RuleResourcesMissing),
+			HaveField("Rule", lint.RuleImageLatestTag),
+			HaveField("Rule", lint.RuleImageLatestTag),
+			HaveField("Rule", lint.RuleProbesMissing),
+			HaveField("Rule", lint.RuleProbesMissing),
+			HaveField("Rule", lint.RuleSingleReplicaWithoutPDB),
+			HaveField("Rule", lint.RuleHostNetwork),
+			HaveField("Rule", lint.RuleRunAsRoot),
+			HaveField("Rule", lint.RuleRunAsRoot),
+			HaveField("Rule", lint.RuleServiceAccountToken),
+			HaveField("Rule", lint.RuleImageDigest),
+			HaveField("Rule", lint.RuleImageDigest),
+		))
+	})
+
+	t.Run("should disable rules", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := lint.New(
+			lint.WithoutRules(lint.RuleResourcesMissing, lint.RuleImageLatestTag, lint.RuleProbesMissing),
+			lint.WithoutRules(lint.RuleSingleReplicaWithoutPDB, lint.RuleRunAsRoot),
+			lint.WithoutRules(lint.RuleServiceAccountToken, lint.RuleImageDigest),
+		).Validate(t.Context(), decode(t, testNonCompliantDeployment))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(HaveField("Rule", lint.RuleHostNetwork)))
+	})
+
+	t.Run("should override severities", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := lint.New(
+			lint.WithRuleSeverity(lint.RuleHostNetwork, validate.SeverityError),
+		).Validate(t.Context(), decode(t, testNonCompliantDeployment))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings.AtLeast(validate.SeverityError)).Should(HaveExactElements(
+			HaveField("Rule", lint.RuleHostNetwork),
+		))
+	})
+
+	t.Run("should run custom rules", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ownerLabel := lint.Rule{
+			ID:       "owner-label",
+			Severity: validate.SeverityInfo,
+			Check: func(objects []unstructured.Unstructured) validate.Findings {
+				var findings validate.Findings
+
+				for i := range objects {
+					if objects[i].GetLabels()["owner"] == "" {
+						findings = append(findings, validate.Finding{
+							Object:  k8s.KeyOf(&objects[i]),
+							Path:    "metadata.labels",
+							Message: "has no owner label",
+						})
+					}
+				}
+
+				return findings
+			},
+		}
+
+		findings, err := lint.New(
+			lint.WithoutRules(defaultRuleIDs()...),
+			lint.WithRules(ownerLabel),
+		).Validate(t.Context(), decode(t, testCompliantDeployment))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(And(
+			HaveField("Rule", "owner-label"),
+			HaveField("Severity", validate.SeverityInfo),
+			HaveField("Object.Name", "web"),
+		)))
+	})
+
+	t.Run("should mark exempted findings", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := lint.New(
+			lint.WithoutRules(lint.RuleResourcesMissing, lint.RuleImageLatestTag, lint.RuleProbesMissing),
+			lint.WithoutRules(lint.RuleSingleReplicaWithoutPDB, lint.RuleImageDigest),
+			lint.WithExemptions(
+				lint.Exemption{
+					Rules:         []string{lint.RuleHostNetwork, lint.RuleRunAsRoot},
+					Target:        transform.Selector{Kind: "Deployment", Name: "api"},
+					Justification: "node agent",
+				},
+				lint.Exemption{
+					Target: transform.Selector{LabelSelector: "team=platform"},
+				},
+			),
+		).Validate(t.Context(), decode(t, testNonCompliantDeployment))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(
+			And(HaveField("Rule", lint.RuleHostNetwork), HaveField("Exempted", true), HaveField("Justification", "node agent")),
+			And(HaveField("Rule", lint.RuleRunAsRoot), HaveField("Exempted", true)),
+			And(HaveField("Rule", lint.RuleRunAsRoot), HaveField("Exempted", true)),
+			And(HaveField("Rule", lint.RuleServiceAccountToken), HaveField("Exempted", false)),
+		))
+		g.Expect(findings.AtLeast(validate.SeverityInfo)).Should(HaveExactElements(
+			HaveField("Rule", lint.RuleServiceAccountToken),
+		))
+	})
+
+	t.Run("should reject unknown rules", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := lint.New(lint.WithoutRules("no-such-rule")).Validate(t.Context(), nil)
+		g.Expect(err).Should(MatchError(lint.ErrUnknownRule))
+
+		_, err = lint.New(lint.WithRuleSeverity("no-such-rule", validate.SeverityInfo)).Validate(t.Context(), nil)
+		g.Expect(err).Should(MatchError(lint.ErrUnknownRule))
+
+		_, err = lint.New(lint.WithExemptions(lint.Exemption{Rules: []string{"no-such-rule"}})).Validate(t.Context(), nil)
+		g.Expect(err).Should(MatchError(lint.ErrUnknownRule))
+	})
+
+	t.Run("should run as a validation stage", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := validate.Stage(lint.New(), validate.WithFailOn(validate.SeverityWarning)).
+			Transform(t.Context(), decode(t, testNonCompliantDeployment))
+
+		g.Expect(err).Should(MatchError(validate.ErrValidationFailed))
+	})
+}
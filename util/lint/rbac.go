@@ -0,0 +1,279 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+)
+
+const (
+	// RuleRBACWildcard reports RBAC rules granting all verbs or all resources.
+	RuleRBACWildcard = "rbac-wildcard"
+
+	// RuleRBACClusterAdmin reports bindings to the cluster-admin ClusterRole.
+	RuleRBACClusterAdmin = "rbac-cluster-admin"
+
+	// RuleRBACEscalation reports RBAC rules granting permissions that allow
+	// privilege escalation.
+	RuleRBACEscalation = "rbac-escalation"
+
+	// RuleRBACForeignSubject reports bindings to service accounts of
+	// namespaces outside the render.
+	RuleRBACForeignSubject = "rbac-foreign-subject"
+
+	// rbacGroup is the API group of the RBAC objects.
+	rbacGroup = "rbac.authorization.k8s.io"
+)
+
+// escalation is a permission allowing privilege escalation.
+type escalation struct {
+	verbs     []string
+	resources []string
+	reason    string
+}
+
+// escalations are the permissions reported by RuleRBACEscalation. An empty
+// resource list matches any resource.
+var escalations = []escalation{
+	{verbs: []string{"escalate", "bind"}, reason: "can grant permissions it does not hold"},
+	{verbs: []string{"impersonate"}, reason: "can impersonate other identities"},
+	{
+		verbs:     []string{"create"},
+		resources: []string{"pods/exec", "pods/attach", "serviceaccounts/token", "nodes/proxy"},
+		reason:    "can run commands or obtain credentials of other identities",
+	},
+	{verbs: []string{"get", "list", "watch"}, resources: []string{"secrets"}, reason: "can read secrets"},
+	{
+		verbs:     []string{"create", "update", "patch"},
+		resources: []string{"roles", "clusterroles", "rolebindings", "clusterrolebindings"},
+		reason:    "can modify RBAC permissions",
+	},
+}
+
+// RBACRules returns the built-in rules analyzing Roles, ClusterRoles and
+// their bindings, part of DefaultRules.
+func RBACRules() []Rule {
+	return []Rule{
+		{
+			ID:          RuleRBACWildcard,
+			Severity:    validate.SeverityWarning,
+			Description: "RBAC rules list verbs and resources explicitly.",
+			Check:       checkRBACWildcards,
+		},
+		{
+			ID:          RuleRBACClusterAdmin,
+			Severity:    validate.SeverityError,
+			Description: "No subject is bound to the cluster-admin ClusterRole.",
+			Check:       checkRBACClusterAdmin,
+		},
+		{
+			ID:          RuleRBACEscalation,
+			Severity:    validate.SeverityWarning,
+			Description: "RBAC rules do not grant permissions allowing privilege escalation.",
+			Check:       checkRBACEscalations,
+		},
+		{
+			ID:          RuleRBACForeignSubject,
+			Severity:    validate.SeverityWarning,
+			Description: "Bindings only bind service accounts of the namespaces of the render.",
+			Check:       checkRBACSubjects,
+		},
+	}
+}
+
+// policyRule is a rule of a Role or ClusterRole.
+type policyRule struct {
+	object    *unstructured.Unstructured
+	path      string
+	verbs     []string
+	resources []string
+}
+
+// policyRules returns the rules of the Roles and ClusterRoles among objects.
+func policyRules(objects []unstructured.Unstructured) []policyRule {
+	var result []policyRule
+
+	for i := range objects {
+		gk := objects[i].GroupVersionKind().GroupKind()
+		if gk != (schema.GroupKind{Group: rbacGroup, Kind: "Role"}) && gk != (schema.GroupKind{Group: rbacGroup, Kind: "ClusterRole"}) {
+			continue
+		}
+
+		rules, _, _ := unstructured.NestedSlice(objects[i].Object, "rules")
+		for j, r := range rules {
+			rule, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			verbs, _, _ := unstructured.NestedStringSlice(rule, "verbs")
+			resources, _, _ := unstructured.NestedStringSlice(rule, "resources")
+
+			result = append(result, policyRule{
+				object:    &objects[i],
+				path:      "rules[" + strconv.Itoa(j) + "]",
+				verbs:     verbs,
+				resources: resources,
+			})
+		}
+	}
+
+	return result
+}
+
+// grants reports whether the rule grants one of verbs on one of resources;
+// empty resources match any resource.
+func (r policyRule) grants(verbs []string, resources []string) bool {
+	verbMatch := slices.Contains(r.verbs, "*") || slices.ContainsFunc(verbs, func(v string) bool {
+		return slices.Contains(r.verbs, v)
+	})
+
+	resourceMatch := len(resources) == 0 || slices.Contains(r.resources, "*") ||
+		slices.ContainsFunc(resources, func(res string) bool {
+			return slices.Contains(r.resources, res)
+		})
+
+	return verbMatch && resourceMatch
+}
+
+func checkRBACWildcards(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, r := range policyRules(objects) {
+		if slices.Contains(r.verbs, "*") {
+			findings = append(findings, validate.Finding{
+				Object:  k8s.KeyOf(r.object),
+				Path:    r.path + ".verbs",
+				Message: "grants all verbs",
+			})
+		}
+
+		if slices.Contains(r.resources, "*") {
+			findings = append(findings, validate.Finding{
+				Object:  k8s.KeyOf(r.object),
+				Path:    r.path + ".resources",
+				Message: "grants all resources",
+			})
+		}
+	}
+
+	return findings
+}
+
+func checkRBACEscalations(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, r := range policyRules(objects) {
+		var reasons []string
+
+		for _, e := range escalations {
+			if r.grants(e.verbs, e.resources) && !slices.Contains(reasons, e.reason) {
+				reasons = append(reasons, e.reason)
+			}
+		}
+
+		if len(reasons) > 0 {
+			findings = append(findings, validate.Finding{
+				Object:  k8s.KeyOf(r.object),
+				Path:    r.path,
+				Message: strings.Join(reasons, "; "),
+			})
+		}
+	}
+
+	return findings
+}
+
+// binding is a RoleBinding or ClusterRoleBinding.
+type binding struct {
+	object   *unstructured.Unstructured
+	roleKind string
+	roleName string
+	subjects []any
+}
+
+// bindings returns the RoleBindings and ClusterRoleBindings among objects.
+func bindings(objects []unstructured.Unstructured) []binding {
+	var result []binding
+
+	for i := range objects {
+		gk := objects[i].GroupVersionKind().GroupKind()
+		if gk != (schema.GroupKind{Group: rbacGroup, Kind: "RoleBinding"}) &&
+			gk != (schema.GroupKind{Group: rbacGroup, Kind: "ClusterRoleBinding"}) {
+			continue
+		}
+
+		roleKind, _, _ := unstructured.NestedString(objects[i].Object, "roleRef", "kind")
+		roleName, _, _ := unstructured.NestedString(objects[i].Object, "roleRef", "name")
+		subjects, _, _ := unstructured.NestedSlice(objects[i].Object, "subjects")
+
+		result = append(result, binding{object: &objects[i], roleKind: roleKind, roleName: roleName, subjects: subjects})
+	}
+
+	return result
+}
+
+func checkRBACClusterAdmin(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, b := range bindings(objects) {
+		if b.roleKind == "ClusterRole" && b.roleName == "cluster-admin" {
+			findings = append(findings, validate.Finding{
+				Object:  k8s.KeyOf(b.object),
+				Path:    "roleRef",
+				Message: "binds the cluster-admin ClusterRole",
+			})
+		}
+	}
+
+	return findings
+}
+
+func checkRBACSubjects(objects []unstructured.Unstructured) validate.Findings {
+	namespaces := make(map[string]bool)
+
+	for i := range objects {
+		if ns := objects[i].GetNamespace(); ns != "" {
+			namespaces[ns] = true
+		}
+
+		if objects[i].GroupVersionKind().GroupKind() == (schema.GroupKind{Kind: "Namespace"}) {
+			namespaces[objects[i].GetName()] = true
+		}
+	}
+
+	var findings validate.Findings
+
+	for _, b := range bindings(objects) {
+		for j, s := range b.subjects {
+			subject, ok := s.(map[string]any)
+			if !ok || subject["kind"] != "ServiceAccount" {
+				continue
+			}
+
+			name, _ := subject["name"].(string)
+
+			namespace, _ := subject["namespace"].(string)
+			if namespace == "" {
+				namespace = b.object.GetNamespace()
+			}
+
+			if !namespaces[namespace] {
+				findings = append(findings, validate.Finding{
+					Object:  k8s.KeyOf(b.object),
+					Path:    "subjects[" + strconv.Itoa(j) + "]",
+					Message: fmt.Sprintf("binds service account %s/%s outside the namespaces of the render", namespace, name),
+				})
+			}
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,139 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/lint"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+const testRBAC = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: shop
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: operator
+rules:
+  - apiGroups: [""]
+    resources: [configmaps]
+    verbs: [get, list, watch]
+  - apiGroups: ["apps"]
+    resources: ["*"]
+    verbs: ["*"]
+  - apiGroups: [""]
+    resources: [secrets]
+    verbs: [get]
+  - apiGroups: [rbac.authorization.k8s.io]
+    resources: [clusterroles]
+    verbs: [bind, escalate]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: debugger
+  namespace: shop
+rules:
+  - apiGroups: [""]
+    resources: [pods/exec]
+    verbs: [create]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: operator-admin
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: cluster-admin
+subjects:
+  - kind: ServiceAccount
+    name: operator
+    namespace: shop
+  - kind: ServiceAccount
+    name: default
+    namespace: kube-system
+  - kind: Group
+    name: system:masters
+    apiGroup: rbac.authorization.k8s.io
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: debugger
+  namespace: shop
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: debugger
+subjects:
+  - kind: ServiceAccount
+    name: debugger
+`
+
+func TestRBACRules(t *testing.T) {
+	t.Run("should be part of the default rules", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for _, r := range lint.RBACRules() {
+			g.Expect(lint.DefaultRules()).Should(ContainElement(HaveField("ID", r.ID)))
+		}
+	})
+
+	t.Run("should report wildcard verbs and resources", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleRBACWildcard, testRBAC)).Should(HaveExactElements(
+			And(HaveField("Object.Name", "operator"), HaveField("Path", "rules[1].verbs")),
+			And(HaveField("Object.Name", "operator"), HaveField("Path", "rules[1].resources")),
+		))
+	})
+
+	t.Run("should report cluster-admin bindings", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleRBACClusterAdmin, testRBAC)).Should(HaveExactElements(And(
+			HaveField("Severity", validate.SeverityError),
+			HaveField("Object.Name", "operator-admin"),
+			HaveField("Path", "roleRef"),
+		)))
+	})
+
+	t.Run("should report escalation-capable permissions", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleRBACEscalation, testRBAC)).Should(HaveExactElements(
+			And(
+				HaveField("Path", "rules[1]"),
+				HaveField("Message", ContainSubstring("can read secrets")),
+			),
+			And(
+				HaveField("Path", "rules[2]"),
+				HaveField("Message", "can read secrets"),
+			),
+			And(
+				HaveField("Path", "rules[3]"),
+				HaveField("Message", "can grant permissions it does not hold"),
+			),
+			And(
+				HaveField("Object.Name", "debugger"),
+				HaveField("Message", "can run commands or obtain credentials of other identities"),
+			),
+		))
+	})
+
+	t.Run("should report subjects outside the render", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleRBACForeignSubject, testRBAC)).Should(HaveExactElements(And(
+			HaveField("Object.Name", "operator-admin"),
+			HaveField("Path", "subjects[1]"),
+			HaveField("Message", "binds service account kube-system/default outside the namespaces of the render"),
+		)))
+	})
+}
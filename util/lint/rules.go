@@ -0,0 +1,255 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+)
+
+const (
+	// RuleResourcesMissing reports containers without resource requests or limits.
+	RuleResourcesMissing = "resources-missing"
+
+	// RuleImageLatestTag reports images without a tag, or tagged "latest".
+	RuleImageLatestTag = "image-latest-tag"
+
+	// RuleProbesMissing reports serving containers without readiness or
+	// liveness probes.
+	RuleProbesMissing = "probes-missing"
+
+	// RuleSingleReplicaWithoutPDB reports single-replica Deployments and
+	// StatefulSets no PodDisruptionBudget selects.
+	RuleSingleReplicaWithoutPDB = "single-replica-without-pdb"
+
+	// RuleHostNetwork reports pods using the host network.
+	RuleHostNetwork = "host-network"
+)
+
+// DefaultRules returns the built-in rules run by New: the best-practice rules
+// followed by SecurityRules and RBACRules.
+func DefaultRules() []Rule {
+	rules := []Rule{
+		{
+			ID:          RuleResourcesMissing,
+			Severity:    validate.SeverityWarning,
+			Description: "Containers set resource requests and limits.",
+			Check:       checkResources,
+		},
+		{
+			ID:          RuleImageLatestTag,
+			Severity:    validate.SeverityWarning,
+			Description: "Images are pinned to a tag other than latest, or to a digest.",
+			Check:       checkImageTags,
+		},
+		{
+			ID:          RuleProbesMissing,
+			Severity:    validate.SeverityWarning,
+			Description: "Serving containers define readiness and liveness probes.",
+			Check:       checkProbes,
+		},
+		{
+			ID:          RuleSingleReplicaWithoutPDB,
+			Severity:    validate.SeverityWarning,
+			Description: "Single-replica Deployments and StatefulSets are covered by a PodDisruptionBudget.",
+			Check:       checkSingleReplicas,
+		},
+		{
+			ID:          RuleHostNetwork,
+			Severity:    validate.SeverityWarning,
+			Description: "Pods do not use the host network.",
+			Check:       checkHostNetwork,
+		},
+	}
+
+	return slices.Concat(rules, SecurityRules(), RBACRules())
+}
+
+func checkResources(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		for _, c := range p.containers() {
+			var missing []string
+
+			for _, field := range []string{"requests", "limits"} {
+				if values, _, _ := unstructured.NestedMap(c.spec, "resources", field); len(values) == 0 {
+					missing = append(missing, field)
+				}
+			}
+
+			if len(missing) > 0 {
+				findings = append(findings, p.finding(c.path+".resources",
+					fmt.Sprintf("container %q has no resource %s", c.name, strings.Join(missing, " or "))))
+			}
+		}
+	}
+
+	return findings
+}
+
+func checkImageTags(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		for _, c := range p.containers() {
+			image, _ := c.spec["image"].(string)
+			if image != "" && !pinned(image) {
+				findings = append(findings, p.finding(c.path+".image",
+					fmt.Sprintf("container %q uses image %q without a pinned tag", c.name, image)))
+			}
+		}
+	}
+
+	return findings
+}
+
+// pinned reports whether image has a digest or a tag other than latest.
+func pinned(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+
+	name := image[strings.LastIndex(image, "/")+1:]
+
+	i := strings.LastIndex(name, ":")
+
+	return i >= 0 && name[i+1:] != "latest"
+}
+
+func checkProbes(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		if p.batch() {
+			continue
+		}
+
+		for _, c := range p.containers() {
+			if c.init {
+				continue
+			}
+
+			var missing []string
+
+			for _, probe := range []string{"readiness", "liveness"} {
+				if _, ok := c.spec[probe+"Probe"]; !ok {
+					missing = append(missing, probe)
+				}
+			}
+
+			if len(missing) > 0 {
+				findings = append(findings, p.finding(c.path,
+					fmt.Sprintf("container %q has no %s probe", c.name, strings.Join(missing, " or "))))
+			}
+		}
+	}
+
+	return findings
+}
+
+func checkSingleReplicas(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for i := range objects {
+		obj := &objects[i]
+
+		gk := obj.GroupVersionKind().GroupKind()
+		if gk != (schema.GroupKind{Group: "apps", Kind: "Deployment"}) && gk != (schema.GroupKind{Group: "apps", Kind: "StatefulSet"}) {
+			continue
+		}
+
+		replicas, found, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if err != nil || (found && replicas != 1) || (!found && autoscaled(objects, obj)) {
+			continue
+		}
+
+		if disruptionBudgeted(objects, obj) {
+			continue
+		}
+
+		findings = append(findings, validate.Finding{
+			Object:  k8s.KeyOf(obj),
+			Path:    "spec.replicas",
+			Message: "runs a single replica and no PodDisruptionBudget selects its pods",
+		})
+	}
+
+	return findings
+}
+
+// autoscaled reports whether a HorizontalPodAutoscaler of objects scales obj,
+// in which case the replicas field is left unset on purpose.
+func autoscaled(objects []unstructured.Unstructured, obj *unstructured.Unstructured) bool {
+	for i := range objects {
+		hpa := &objects[i]
+		if hpa.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"}) ||
+			hpa.GetNamespace() != obj.GetNamespace() {
+			continue
+		}
+
+		kind, _, _ := unstructured.NestedString(hpa.Object, "spec", "scaleTargetRef", "kind")
+		name, _, _ := unstructured.NestedString(hpa.Object, "spec", "scaleTargetRef", "name")
+
+		if kind == obj.GetKind() && name == obj.GetName() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// disruptionBudgeted reports whether a PodDisruptionBudget of objects selects
+// the pods of obj.
+func disruptionBudgeted(objects []unstructured.Unstructured, obj *unstructured.Unstructured) bool {
+	podLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "labels")
+
+	for i := range objects {
+		pdb := &objects[i]
+		if pdb.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "policy", Kind: "PodDisruptionBudget"}) ||
+			pdb.GetNamespace() != obj.GetNamespace() {
+			continue
+		}
+
+		raw, found, _ := unstructured.NestedMap(pdb.Object, "spec", "selector")
+		if !found {
+			continue
+		}
+
+		var ls metav1.LabelSelector
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &ls); err != nil {
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&ls)
+		if err != nil {
+			continue
+		}
+
+		if selector.Matches(labels.Set(podLabels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkHostNetwork(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		if hostNetwork, _ := p.spec["hostNetwork"].(bool); hostNetwork {
+			findings = append(findings, p.finding(p.path+".hostNetwork", "pods use the host network"))
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,293 @@
+package lint_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/lint"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+const testCompliantDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      initContainers:
+        - name: migrate
+          image: registry.example.com/web-migrate:1.4.0
+          resources:
+            requests: {cpu: 100m, memory: 64Mi}
+            limits: {memory: 64Mi}
+      containers:
+        - name: web
+          image: registry.example.com/web@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+          resources:
+            requests: {cpu: 100m, memory: 128Mi}
+            limits: {memory: 128Mi}
+          readinessProbe:
+            httpGet: {path: /ready, port: 8080}
+          livenessProbe:
+            httpGet: {path: /live, port: 8080}
+`
+
+const testNonCompliantDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: shop
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: api
+  template:
+    metadata:
+      labels:
+        app: api
+    spec:
+      hostNetwork: true
+      containers:
+        - name: api
+          image: registry.example.com:5000/api
+          resources:
+            requests: {cpu: 100m}
+          readinessProbe:
+            httpGet: {path: /ready, port: 8080}
+        - name: proxy
+          image: envoyproxy/envoy:latest
+`
+
+const testCronJob = `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+  namespace: shop
+spec:
+  schedule: "0 * * * *"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: cleanup
+              image: busybox:1.36
+              resources:
+                requests: {cpu: 10m}
+                limits: {cpu: 10m}
+`
+
+const testSingleReplicaWorkloads = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  namespace: shop
+spec:
+  replicas: 1
+  template:
+    metadata:
+      labels:
+        app: db
+---
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: db
+  namespace: shop
+spec:
+  maxUnavailable: 1
+  selector:
+    matchExpressions:
+      - {key: app, operator: In, values: [db]}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+  namespace: shop
+spec:
+  template:
+    metadata:
+      labels:
+        app: worker
+---
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: worker
+  namespace: shop
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: worker
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cache
+  namespace: other
+spec:
+  template:
+    metadata:
+      labels:
+        app: db
+`
+
+func decode(t *testing.T, content string) []unstructured.Unstructured {
+	t.Helper()
+
+	objects, err := k8s.DecodeYAML([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return objects
+}
+
+func lintOnly(t *testing.T, rule string, content string) validate.Findings {
+	t.Helper()
+
+	var others []string
+
+	for _, id := range append(defaultRuleIDs(), lint.RuleExemptionInvalid) {
+		if id != rule {
+			others = append(others, id)
+		}
+	}
+
+	findings, err := lint.New(lint.WithoutRules(others...)).Validate(t.Context(), decode(t, content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return findings
+}
+
+func TestResourcesRule(t *testing.T) {
+	t.Run("should report missing requests and limits", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings := lintOnly(t, lint.RuleResourcesMissing, testNonCompliantDeployment)
+
+		g.Expect(findings).Should(HaveExactElements(
+			And(
+				HaveField("Rule", lint.RuleResourcesMissing),
+				HaveField("Severity", validate.SeverityWarning),
+				HaveField("Object.Name", "api"),
+				HaveField("Path", "spec.template.spec.containers[0].resources"),
+				HaveField("Message", `container "api" has no resource limits`),
+			),
+			HaveField("Message", `container "proxy" has no resource requests or limits`),
+		))
+	})
+
+	t.Run("should accept containers with resources", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleResourcesMissing, testCompliantDeployment)).Should(BeEmpty())
+		g.Expect(lintOnly(t, lint.RuleResourcesMissing, testCronJob)).Should(BeEmpty())
+	})
+}
+
+func TestImageTagRule(t *testing.T) {
+	t.Run("should report untagged and latest images", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings := lintOnly(t, lint.RuleImageLatestTag, testNonCompliantDeployment)
+
+		g.Expect(findings).Should(HaveExactElements(
+			And(
+				HaveField("Path", "spec.template.spec.containers[0].image"),
+				HaveField("Message", ContainSubstring("registry.example.com:5000/api")),
+			),
+			HaveField("Message", ContainSubstring("envoyproxy/envoy:latest")),
+		))
+	})
+
+	t.Run("should accept tags and digests", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleImageLatestTag, testCompliantDeployment)).Should(BeEmpty())
+	})
+}
+
+func TestProbesRule(t *testing.T) {
+	t.Run("should report missing probes of serving containers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings := lintOnly(t, lint.RuleProbesMissing, testNonCompliantDeployment)
+
+		g.Expect(findings).Should(HaveExactElements(
+			And(
+				HaveField("Path", "spec.template.spec.containers[0]"),
+				HaveField("Message", `container "api" has no liveness probe`),
+			),
+			HaveField("Message", `container "proxy" has no readiness or liveness probe`),
+		))
+	})
+
+	t.Run("should ignore init containers and batch workloads", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleProbesMissing, testCompliantDeployment)).Should(BeEmpty())
+		g.Expect(lintOnly(t, lint.RuleProbesMissing, testCronJob)).Should(BeEmpty())
+	})
+}
+
+func TestSingleReplicaRule(t *testing.T) {
+	t.Run("should report single replicas without a PodDisruptionBudget", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleSingleReplicaWithoutPDB, testNonCompliantDeployment)).Should(HaveExactElements(And(
+			HaveField("Object.Name", "api"),
+			HaveField("Path", "spec.replicas"),
+		)))
+	})
+
+	t.Run("should accept budgeted and autoscaled workloads", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings := lintOnly(t, lint.RuleSingleReplicaWithoutPDB, testSingleReplicaWorkloads)
+
+		g.Expect(findings).Should(HaveExactElements(And(
+			HaveField("Object.Name", "cache"),
+			HaveField("Object.Namespace", "other"),
+		)))
+	})
+
+	t.Run("should accept multiple replicas", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleSingleReplicaWithoutPDB, testCompliantDeployment)).Should(BeEmpty())
+	})
+}
+
+func TestHostNetworkRule(t *testing.T) {
+	t.Run("should report pods using the host network", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleHostNetwork, testNonCompliantDeployment)).Should(HaveExactElements(
+			HaveField("Path", "spec.template.spec.hostNetwork"),
+		))
+		g.Expect(lintOnly(t, lint.RuleHostNetwork, testCompliantDeployment)).Should(BeEmpty())
+	})
+}
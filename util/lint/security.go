@@ -0,0 +1,237 @@
+package lint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+)
+
+const (
+	// RulePrivilegedContainer reports privileged containers.
+	RulePrivilegedContainer = "privileged-container"
+
+	// RuleAddedCapabilities reports containers adding Linux capabilities other
+	// than NET_BIND_SERVICE.
+	RuleAddedCapabilities = "added-capabilities"
+
+	// RuleHostPath reports pods mounting hostPath volumes.
+	RuleHostPath = "host-path"
+
+	// RuleHostNamespaces reports pods sharing the host PID or IPC namespace.
+	RuleHostNamespaces = "host-pid-ipc"
+
+	// RuleRunAsRoot reports containers that run, or may run, as root.
+	RuleRunAsRoot = "run-as-root"
+
+	// RuleServiceAccountToken reports pods of the default service account
+	// automounting its token.
+	RuleServiceAccountToken = "automount-service-account-token"
+
+	// RuleImageDigest reports images not pinned to a digest.
+	RuleImageDigest = "image-digest-unpinned"
+)
+
+// SecurityRules returns the built-in security rules, part of DefaultRules.
+func SecurityRules() []Rule {
+	return []Rule{
+		{
+			ID:          RulePrivilegedContainer,
+			Severity:    validate.SeverityError,
+			Description: "Containers do not run privileged.",
+			Check:       checkPrivileged,
+		},
+		{
+			ID:          RuleAddedCapabilities,
+			Severity:    validate.SeverityWarning,
+			Description: "Containers add no Linux capabilities other than NET_BIND_SERVICE.",
+			Check:       checkCapabilities,
+		},
+		{
+			ID:          RuleHostPath,
+			Severity:    validate.SeverityWarning,
+			Description: "Pods do not mount hostPath volumes.",
+			Check:       checkHostPath,
+		},
+		{
+			ID:          RuleHostNamespaces,
+			Severity:    validate.SeverityError,
+			Description: "Pods do not share the host PID or IPC namespace.",
+			Check:       checkHostNamespaces,
+		},
+		{
+			ID:          RuleRunAsRoot,
+			Severity:    validate.SeverityWarning,
+			Description: "Containers set runAsNonRoot or a non-zero runAsUser.",
+			Check:       checkRunAsRoot,
+		},
+		{
+			ID:          RuleServiceAccountToken,
+			Severity:    validate.SeverityWarning,
+			Description: "Pods of the default service account do not automount its token.",
+			Check:       checkServiceAccountToken,
+		},
+		{
+			ID:          RuleImageDigest,
+			Severity:    validate.SeverityInfo,
+			Description: "Images are pinned to a digest.",
+			Check:       checkImageDigests,
+		},
+	}
+}
+
+func checkPrivileged(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		for _, c := range p.containers() {
+			if privileged, _, _ := unstructured.NestedBool(c.spec, "securityContext", "privileged"); privileged {
+				findings = append(findings, p.finding(c.path+".securityContext.privileged",
+					fmt.Sprintf("container %q runs privileged", c.name)))
+			}
+		}
+	}
+
+	return findings
+}
+
+func checkCapabilities(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		for _, c := range p.containers() {
+			added, _, _ := unstructured.NestedStringSlice(c.spec, "securityContext", "capabilities", "add")
+
+			var risky []string
+
+			for _, capability := range added {
+				if strings.TrimPrefix(strings.ToUpper(capability), "CAP_") != "NET_BIND_SERVICE" {
+					risky = append(risky, capability)
+				}
+			}
+
+			if len(risky) > 0 {
+				findings = append(findings, p.finding(c.path+".securityContext.capabilities.add",
+					fmt.Sprintf("container %q adds capabilities %s", c.name, strings.Join(risky, ", "))))
+			}
+		}
+	}
+
+	return findings
+}
+
+func checkHostPath(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		volumes, _ := p.spec["volumes"].([]any)
+		for i, v := range volumes {
+			volume, _ := v.(map[string]any)
+
+			path, found, _ := unstructured.NestedString(volume, "hostPath", "path")
+			if !found {
+				continue
+			}
+
+			name, _ := volume["name"].(string)
+			findings = append(findings, p.finding(p.path+".volumes["+strconv.Itoa(i)+"].hostPath",
+				fmt.Sprintf("volume %q mounts host path %q", name, path)))
+		}
+	}
+
+	return findings
+}
+
+func checkHostNamespaces(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		for _, field := range []string{"hostPID", "hostIPC"} {
+			if shared, _ := p.spec[field].(bool); shared {
+				findings = append(findings, p.finding(p.path+"."+field,
+					fmt.Sprintf("pods share the host %s namespace", strings.TrimPrefix(field, "host"))))
+			}
+		}
+	}
+
+	return findings
+}
+
+func checkRunAsRoot(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		podContext, _ := p.spec["securityContext"].(map[string]any)
+
+		for _, c := range p.containers() {
+			containerContext, _ := c.spec["securityContext"].(map[string]any)
+
+			// Container settings take precedence over pod settings.
+			user, userSet := effectiveField[int64](containerContext, podContext, "runAsUser")
+			nonRoot, _ := effectiveField[bool](containerContext, podContext, "runAsNonRoot")
+
+			switch {
+			case userSet && user == 0:
+				findings = append(findings, p.finding(c.path, fmt.Sprintf("container %q runs as root", c.name)))
+			case !userSet && !nonRoot:
+				findings = append(findings, p.finding(c.path,
+					fmt.Sprintf("container %q may run as root: set runAsNonRoot or runAsUser", c.name)))
+			}
+		}
+	}
+
+	return findings
+}
+
+// effectiveField returns the value of a security context field set on the
+// container, or else on the pod.
+func effectiveField[T any](containerContext map[string]any, podContext map[string]any, field string) (T, bool) {
+	for _, sc := range []map[string]any{containerContext, podContext} {
+		if value, ok := sc[field].(T); ok {
+			return value, true
+		}
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+func checkServiceAccountToken(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		account, _ := p.spec["serviceAccountName"].(string)
+		if account != "" && account != "default" {
+			continue
+		}
+
+		if automount, set := p.spec["automountServiceAccountToken"].(bool); set && !automount {
+			continue
+		}
+
+		findings = append(findings, p.finding(p.path+".automountServiceAccountToken",
+			"pods automount the token of the default service account"))
+	}
+
+	return findings
+}
+
+func checkImageDigests(objects []unstructured.Unstructured) validate.Findings {
+	var findings validate.Findings
+
+	for _, p := range podSpecs(objects) {
+		for _, c := range p.containers() {
+			image, _ := c.spec["image"].(string)
+			if image != "" && !strings.Contains(image, "@") {
+				findings = append(findings, p.finding(c.path+".image",
+					fmt.Sprintf("container %q uses image %q without a digest", c.name, image)))
+			}
+		}
+	}
+
+	return findings
+}
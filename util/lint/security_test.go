@@ -0,0 +1,157 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/lint"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+const testInsecureDaemonSet = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+  namespace: monitoring
+spec:
+  template:
+    spec:
+      hostPID: true
+      hostIPC: true
+      securityContext:
+        runAsNonRoot: true
+      volumes:
+        - name: config
+          configMap:
+            name: agent
+        - name: proc
+          hostPath:
+            path: /proc
+      containers:
+        - name: agent
+          image: registry.example.com/agent:2.0
+          securityContext:
+            privileged: true
+            runAsUser: 0
+            capabilities:
+              add: [NET_BIND_SERVICE, SYS_ADMIN, CAP_NET_RAW]
+        - name: sidecar
+          image: registry.example.com/sidecar:1.0@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+          securityContext:
+            capabilities:
+              add: [NET_BIND_SERVICE]
+`
+
+const testHardenedPod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+  namespace: default
+spec:
+  serviceAccountName: app
+  securityContext:
+    runAsUser: 1000
+  containers:
+    - name: app
+      image: registry.example.com/app@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: once
+  namespace: default
+spec:
+  template:
+    spec:
+      automountServiceAccountToken: false
+      containers:
+        - name: once
+          image: registry.example.com/once@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+          securityContext:
+            runAsNonRoot: true
+`
+
+func TestSecurityRules(t *testing.T) {
+	t.Run("should be part of the default rules", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for _, r := range lint.SecurityRules() {
+			g.Expect(lint.DefaultRules()).Should(ContainElement(HaveField("ID", r.ID)))
+		}
+	})
+
+	t.Run("should report privileged containers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RulePrivilegedContainer, testInsecureDaemonSet)).Should(HaveExactElements(And(
+			HaveField("Severity", validate.SeverityError),
+			HaveField("Path", "spec.template.spec.containers[0].securityContext.privileged"),
+		)))
+	})
+
+	t.Run("should report added capabilities except NET_BIND_SERVICE", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleAddedCapabilities, testInsecureDaemonSet)).Should(HaveExactElements(
+			HaveField("Message", `container "agent" adds capabilities SYS_ADMIN, CAP_NET_RAW`),
+		))
+	})
+
+	t.Run("should report hostPath volumes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleHostPath, testInsecureDaemonSet)).Should(HaveExactElements(And(
+			HaveField("Path", "spec.template.spec.volumes[1].hostPath"),
+			HaveField("Message", `volume "proc" mounts host path "/proc"`),
+		)))
+	})
+
+	t.Run("should report host PID and IPC namespaces", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleHostNamespaces, testInsecureDaemonSet)).Should(HaveExactElements(
+			HaveField("Message", "pods share the host PID namespace"),
+			HaveField("Message", "pods share the host IPC namespace"),
+		))
+	})
+
+	t.Run("should report containers running as root", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleRunAsRoot, testInsecureDaemonSet)).Should(HaveExactElements(
+			HaveField("Message", `container "agent" runs as root`),
+		))
+		g.Expect(lintOnly(t, lint.RuleRunAsRoot, testNonCompliantDeployment)).Should(HaveExactElements(
+			HaveField("Message", ContainSubstring(`container "api" may run as root`)),
+			HaveField("Message", ContainSubstring(`container "proxy" may run as root`)),
+		))
+	})
+
+	t.Run("should report automounted default service account tokens", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleServiceAccountToken, testInsecureDaemonSet)).Should(HaveExactElements(
+			HaveField("Path", "spec.template.spec.automountServiceAccountToken"),
+		))
+	})
+
+	t.Run("should report images without digests", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(lintOnly(t, lint.RuleImageDigest, testInsecureDaemonSet)).Should(HaveExactElements(And(
+			HaveField("Severity", validate.SeverityInfo),
+			HaveField("Message", ContainSubstring("registry.example.com/agent:2.0")),
+		)))
+	})
+
+	t.Run("should accept hardened workloads", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for _, r := range lint.SecurityRules() {
+			g.Expect(lintOnly(t, r.ID, testHardenedPod)).Should(BeEmpty(), r.ID)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+)
+
+// podSpecPaths locates the pod spec of the built-in workload kinds.
+var podSpecPaths = map[schema.GroupKind][]string{
+	{Kind: "Pod"}:                        {"spec"},
+	{Kind: "ReplicationController"}:      {"spec", "template", "spec"},
+	{Group: "apps", Kind: "Deployment"}:  {"spec", "template", "spec"},
+	{Group: "apps", Kind: "StatefulSet"}: {"spec", "template", "spec"},
+	{Group: "apps", Kind: "DaemonSet"}:   {"spec", "template", "spec"},
+	{Group: "apps", Kind: "ReplicaSet"}:  {"spec", "template", "spec"},
+	{Group: "batch", Kind: "Job"}:        {"spec", "template", "spec"},
+	{Group: "batch", Kind: "CronJob"}:    {"spec", "jobTemplate", "spec", "template", "spec"},
+	{Kind: "PodTemplate"}:                {"template", "spec"},
+}
+
+// podSpec is the pod spec of a workload.
+type podSpec struct {
+	object *unstructured.Unstructured
+	spec   map[string]any
+	path   string
+}
+
+// container is a container of a pod spec.
+type container struct {
+	spec map[string]any
+	name string
+	path string
+	init bool
+}
+
+// podSpecs returns the pod specs of the workloads among objects, in order.
+func podSpecs(objects []unstructured.Unstructured) []podSpec {
+	var result []podSpec
+
+	for i := range objects {
+		fields := podSpecPaths[objects[i].GroupVersionKind().GroupKind()]
+		if len(fields) == 0 {
+			continue
+		}
+
+		spec, ok, _ := unstructured.NestedMap(objects[i].Object, fields...)
+		if !ok {
+			continue
+		}
+
+		result = append(result, podSpec{object: &objects[i], spec: spec, path: strings.Join(fields, ".")})
+	}
+
+	return result
+}
+
+// batch reports whether the pods run to completion rather than serve.
+func (p podSpec) batch() bool {
+	return p.object.GroupVersionKind().Group == "batch"
+}
+
+// finding returns a finding about the pod spec field at path.
+func (p podSpec) finding(path string, message string) validate.Finding {
+	return validate.Finding{Object: k8s.KeyOf(p.object), Path: path, Message: message}
+}
+
+// containers returns the init containers and containers of the pod spec.
+func (p podSpec) containers() []container {
+	var result []container
+
+	for _, field := range []string{"initContainers", "containers"} {
+		items, _ := p.spec[field].([]any)
+		for i, item := range items {
+			spec, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			name, _ := spec["name"].(string)
+			result = append(result, container{
+				spec: spec,
+				name: name,
+				path: p.path + "." + field + "[" + strconv.Itoa(i) + "]",
+				init: field == "initContainers",
+			})
+		}
+	}
+
+	return result
+}
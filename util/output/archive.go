@@ -0,0 +1,116 @@
+package output
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// archiveTime is the modification time of every archive entry; a fixed value
+// keeps archives of identical objects byte-identical.
+var archiveTime = time.Unix(0, 0).UTC()
+
+// Archive packages objects as a gzip-compressed tarball laid out like the tree
+// written by DirWriter with the same options. The archive is reproducible:
+// entries are sorted, timestamps and ownership are fixed, so identical
+// objects produce identical bytes and thus a stable digest.
+//
+// With WithHelmChart, the entries are nested in a directory named after the
+// chart, as in the archives produced by helm package.
+func Archive(objects []unstructured.Unstructured, opts ...DirOption) ([]byte, error) {
+	options, err := newDirOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := encodeFiles(objects, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.HelmChart != nil {
+		for i := range files {
+			p, err := cleanFilePath(options.HelmChart.Name + "/" + files[i].path)
+			if err != nil {
+				return nil, err
+			}
+
+			files[i].path = p
+		}
+	}
+
+	slices.SortFunc(files, func(a encodedFile, b encodedFile) int {
+		return strings.Compare(a.path, b.path)
+	})
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	dirs := make(map[string]bool)
+
+	for _, f := range files {
+		for _, dir := range parentDirs(f.path) {
+			if dirs[dir] {
+				continue
+			}
+
+			dirs[dir] = true
+
+			if err := tw.WriteHeader(archiveHeader(dir+"/", tar.TypeDir, 0o755, 0)); err != nil {
+				return nil, fmt.Errorf("unable to archive %s: %w", dir, err)
+			}
+		}
+
+		if err := tw.WriteHeader(archiveHeader(f.path, tar.TypeReg, 0o644, int64(len(f.data)))); err != nil {
+			return nil, fmt.Errorf("unable to archive %s: %w", f.path, err)
+		}
+
+		if _, err := tw.Write(f.data); err != nil {
+			return nil, fmt.Errorf("unable to archive %s: %w", f.path, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("unable to close archive: %w", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("unable to compress archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func archiveHeader(name string, typeflag byte, mode int64, size int64) *tar.Header {
+	return &tar.Header{
+		Typeflag: typeflag,
+		Name:     name,
+		Mode:     mode,
+		Size:     size,
+		ModTime:  archiveTime,
+		Format:   tar.FormatPAX,
+	}
+}
+
+// parentDirs returns the directories containing the slash-separated path p,
+// outermost first.
+func parentDirs(p string) []string {
+	var dirs []string
+
+	for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+
+	slices.Reverse(dirs)
+
+	return dirs
+}
@@ -0,0 +1,114 @@
+package output_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+// readArchive returns the entries of a tar.gz archive, in order, mapped to
+// their content; directories map to an empty string.
+func readArchive(g *WithT, data []byte) ([]string, map[string]string) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	tr := tar.NewReader(gz)
+
+	entries := make([]string, 0)
+	contents := make(map[string]string)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(hdr.ModTime.Unix()).Should(BeZero())
+
+		content, err := io.ReadAll(tr)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		entries = append(entries, hdr.Name)
+		contents[hdr.Name] = string(content)
+	}
+
+	return entries, contents
+}
+
+func TestArchive(t *testing.T) {
+	t.Run("should archive one file per resource", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data, err := output.Archive(decodeObjects(g, testOutputYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		entries, contents := readArchive(g, data)
+		g.Expect(entries).Should(Equal([]string{
+			"deployment-web.yaml",
+			"namespace-apps.yaml",
+			"service-web.yaml",
+		}))
+
+		g.Expect(names(decodeObjects(g, contents["deployment-web.yaml"]))).Should(Equal([]string{"Deployment/web"}))
+	})
+
+	t.Run("should add directory entries for nested layouts", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data, err := output.Archive(
+			decodeObjects(g, testOutputYAML),
+			output.WithLayout(output.LayoutPerNamespace),
+			output.WithKustomization(output.Kustomization{
+				Namespace:    "apps",
+				CommonLabels: map[string]string{"team": "platform"},
+			}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		entries, contents := readArchive(g, data)
+		g.Expect(entries).Should(Equal([]string{
+			"_cluster/",
+			"_cluster/namespace-apps.yaml",
+			"apps/",
+			"apps/deployment-web.yaml",
+			"apps/service-web.yaml",
+			output.KustomizationFileName,
+		}))
+
+		g.Expect(contents[output.KustomizationFileName]).Should(Equal(testKustomization))
+	})
+
+	t.Run("should produce identical bytes for identical objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		first, err := output.Archive(decodeObjects(g, testOutputYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		second, err := output.Archive(decodeObjects(g, testOutputYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(first).Should(Equal(second))
+	})
+
+	t.Run("should fail on colliding file names", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.Archive(decodeObjects(g, testCollidingYAML))
+		g.Expect(err).Should(MatchError(output.ErrFileCollision))
+	})
+
+	t.Run("should fail on an unknown layout", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.Archive(nil, output.WithLayout("unknown"))
+		g.Expect(err).Should(MatchError(output.ErrUnknownLayout))
+	})
+}
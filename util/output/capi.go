@@ -0,0 +1,179 @@
+package output
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const (
+	// CRSDataKey is the ConfigMap or Secret key holding the manifests of a
+	// ClusterResourceSet shard.
+	CRSDataKey = "resources.yaml"
+
+	// CRSSecretType is the type of the Secrets holding ClusterResourceSet
+	// shards.
+	CRSSecretType = "addons.cluster.x-k8s.io/resource-set"
+)
+
+// ErrObjectTooLarge is returned when an object alone exceeds the ConfigMap
+// size limit.
+var ErrObjectTooLarge = errors.New("object too large")
+
+// ClusterResourceSet wraps objects for delivery to Cluster API workload
+// clusters: it returns the ConfigMaps and Secrets holding the manifests,
+// followed by the ClusterResourceSet named name referencing them, all in
+// namespace.
+//
+// Objects are sorted into apply order, since Cluster API applies the
+// resources in the order they are listed, and packed into as few shards
+// ("<name>-0", "<name>-1", ...) as the size limit allows. Secrets are never
+// stored in plain text in a ConfigMap: runs of consecutive Secrets go into
+// Secrets of type CRSSecretType instead, so they get the access control of
+// Secrets on the management cluster. Changing the limit or the objects may
+// move objects between shards; with the Reconcile strategy Cluster API
+// reapplies the changed shards.
+func ClusterResourceSet(
+	name string,
+	namespace string,
+	objects []unstructured.Unstructured,
+	opts ...CRSOption,
+) ([]unstructured.Unstructured, error) {
+	options := CRSOptions{
+		MaxSize: DefaultMaxConfigMapSize,
+	}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	sorted := slices.Clone(objects)
+	k8s.SortForApply(sorted)
+
+	shards, err := shardManifests(sorted, options.MaxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]unstructured.Unstructured, 0, len(shards)+1)
+	resources := make([]any, 0, len(shards))
+
+	for i, shard := range shards {
+		shardName := name + "-" + strconv.Itoa(i)
+
+		obj := unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      shardName,
+				"namespace": namespace,
+			},
+			"data": map[string]any{
+				CRSDataKey: shard.manifests,
+			},
+		}}
+
+		if shard.secret {
+			obj.SetKind("Secret")
+			obj.Object["type"] = CRSSecretType
+			obj.Object["data"] = map[string]any{
+				CRSDataKey: base64.StdEncoding.EncodeToString([]byte(shard.manifests)),
+			}
+		}
+
+		result = append(result, obj)
+
+		resources = append(resources, map[string]any{
+			"kind": obj.GetKind(),
+			"name": shardName,
+		})
+	}
+
+	matchLabels := make(map[string]any, len(options.ClusterSelector))
+	for k, v := range options.ClusterSelector {
+		matchLabels[k] = v
+	}
+
+	spec := map[string]any{
+		"clusterSelector": map[string]any{
+			"matchLabels": matchLabels,
+		},
+		"resources": resources,
+	}
+
+	if options.Strategy != "" {
+		spec["strategy"] = string(options.Strategy)
+	}
+
+	result = append(result, unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "addons.cluster.x-k8s.io/v1beta1",
+		"kind":       "ClusterResourceSet",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": spec,
+	}})
+
+	return result, nil
+}
+
+// crsShard is a multi-document YAML stream of a ClusterResourceSet.
+type crsShard struct {
+	manifests string
+
+	// secret reports that the shard holds Secrets and must be stored in a
+	// Secret.
+	secret bool
+}
+
+// shardManifests encodes objects into multi-document YAML streams of at most
+// maxSize bytes each, keeping the order of the objects. Secrets and other
+// objects never share a shard.
+func shardManifests(objects []unstructured.Unstructured, maxSize int) ([]crsShard, error) {
+	separator := DefaultSeparator + "\n"
+
+	var shards []crsShard
+	var current bytes.Buffer
+	var secret bool
+
+	for i := range objects {
+		var doc bytes.Buffer
+		if err := WriteYAML(&doc, objects[i:i+1]); err != nil {
+			return nil, err
+		}
+
+		if doc.Len() > maxSize {
+			return nil, fmt.Errorf("%w: %s is %d bytes, limit is %d", ErrObjectTooLarge, k8s.KeyOf(&objects[i]), doc.Len(), maxSize)
+		}
+
+		isSecret := objects[i].GroupVersionKind().GroupKind() == (schema.GroupKind{Kind: "Secret"})
+
+		if current.Len() > 0 && (isSecret != secret || current.Len()+len(separator)+doc.Len() > maxSize) {
+			shards = append(shards, crsShard{manifests: current.String(), secret: secret})
+			current.Reset()
+		}
+
+		secret = isSecret
+
+		if current.Len() > 0 {
+			current.WriteString(separator)
+		}
+
+		current.Write(doc.Bytes())
+	}
+
+	if current.Len() > 0 {
+		shards = append(shards, crsShard{manifests: current.String(), secret: secret})
+	}
+
+	return shards, nil
+}
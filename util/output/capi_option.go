@@ -0,0 +1,87 @@
+package output
+
+import (
+	"maps"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// DefaultMaxConfigMapSize is the default size limit of the manifests held by
+// a ClusterResourceSet ConfigMap. It stays well below the 1MiB object size
+// limit so the ConfigMaps can also be applied client-side, which stores a copy
+// of the object in an annotation.
+const DefaultMaxConfigMapSize = 256 * 1024
+
+// CRSStrategy is the strategy of a ClusterResourceSet.
+type CRSStrategy string
+
+const (
+	// CRSStrategyApplyOnce applies the resources once per cluster.
+	CRSStrategyApplyOnce CRSStrategy = "ApplyOnce"
+
+	// CRSStrategyReconcile reapplies the resources when they change.
+	CRSStrategyReconcile CRSStrategy = "Reconcile"
+)
+
+// CRSOption is a generic option for ClusterResourceSet.
+type CRSOption = util.Option[CRSOptions]
+
+// CRSOptions is a struct-based option that can set ClusterResourceSet options.
+type CRSOptions struct {
+	// ClusterSelector selects the workload clusters by label; empty selects
+	// no cluster, as in Cluster API.
+	ClusterSelector map[string]string
+
+	// Strategy is the ClusterResourceSet strategy; empty leaves the Cluster
+	// API default (ApplyOnce).
+	Strategy CRSStrategy
+
+	// MaxSize is the size limit, in bytes, of the manifests of a ConfigMap;
+	// it also applies to the manifests of a Secret before base64 encoding.
+	MaxSize int
+}
+
+// ApplyTo applies the ClusterResourceSet options to the target configuration.
+func (opts CRSOptions) ApplyTo(target *CRSOptions) {
+	if len(opts.ClusterSelector) > 0 {
+		if target.ClusterSelector == nil {
+			target.ClusterSelector = make(map[string]string, len(opts.ClusterSelector))
+		}
+
+		maps.Copy(target.ClusterSelector, opts.ClusterSelector)
+	}
+
+	if opts.Strategy != "" {
+		target.Strategy = opts.Strategy
+	}
+
+	if opts.MaxSize > 0 {
+		target.MaxSize = opts.MaxSize
+	}
+}
+
+// WithClusterSelector selects the workload clusters receiving the resources.
+func WithClusterSelector(labels map[string]string) CRSOption {
+	return util.FunctionalOption[CRSOptions](func(opts *CRSOptions) {
+		if opts.ClusterSelector == nil {
+			opts.ClusterSelector = make(map[string]string, len(labels))
+		}
+
+		maps.Copy(opts.ClusterSelector, labels)
+	})
+}
+
+// WithCRSStrategy sets the ClusterResourceSet strategy.
+func WithCRSStrategy(strategy CRSStrategy) CRSOption {
+	return util.FunctionalOption[CRSOptions](func(opts *CRSOptions) {
+		opts.Strategy = strategy
+	})
+}
+
+// WithMaxConfigMapSize sets the size limit of the manifests of a ConfigMap or
+// Secret shard; defaults to DefaultMaxConfigMapSize.
+func WithMaxConfigMapSize(size int) CRSOption {
+	return util.FunctionalOption[CRSOptions](func(opts *CRSOptions) {
+		opts.MaxSize = size
+	})
+}
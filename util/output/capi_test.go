@@ -0,0 +1,155 @@
+package output_test
+
+import (
+	"encoding/base64"
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testClusterResourceSet = `
+apiVersion: addons.cluster.x-k8s.io/v1beta1
+kind: ClusterResourceSet
+metadata:
+  name: addons
+  namespace: fleet
+spec:
+  clusterSelector:
+    matchLabels:
+      cni: calico
+  strategy: Reconcile
+  resources:
+    - kind: ConfigMap
+      name: addons-0
+`
+
+const testCRSSecretYAML = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  namespace: apps
+stringData:
+  password: hunter2
+`
+
+// shardObjects decodes the manifests held by the ConfigMaps of a
+// ClusterResourceSet bundle, in order.
+func shardObjects(g *WithT, bundle []unstructured.Unstructured) []unstructured.Unstructured {
+	var result []unstructured.Unstructured
+
+	for _, cm := range bundle[:len(bundle)-1] {
+		g.Expect(cm.GetKind()).Should(Equal("ConfigMap"))
+
+		data, _, err := unstructured.NestedString(cm.Object, "data", output.CRSDataKey)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result = append(result, decodeObjects(g, data)...)
+	}
+
+	return result
+}
+
+func TestClusterResourceSet(t *testing.T) {
+	t.Run("should wrap objects into a ConfigMap and a ClusterResourceSet", func(t *testing.T) {
+		g := NewWithT(t)
+
+		bundle, err := output.ClusterResourceSet("addons", "fleet",
+			decodeObjects(g, testOutputYAML),
+			output.WithClusterSelector(map[string]string{"cni": "calico"}),
+			output.WithCRSStrategy(output.CRSStrategyReconcile),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(bundle).Should(HaveLen(2))
+
+		g.Expect(bundle[0].GetName()).Should(Equal("addons-0"))
+		g.Expect(bundle[0].GetNamespace()).Should(Equal("fleet"))
+		g.Expect(bundle[1]).Should(Equal(decodeObjects(g, testClusterResourceSet)[0]))
+	})
+
+	t.Run("should list objects in apply order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+		slices.Reverse(objects)
+
+		bundle, err := output.ClusterResourceSet("addons", "fleet", objects)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(names(shardObjects(g, bundle))).Should(Equal([]string{"Namespace/apps", "Service/web", "Deployment/web"}))
+		g.Expect(objects[0].GetKind()).Should(Equal("Service"))
+	})
+
+	t.Run("should shard objects by size", func(t *testing.T) {
+		g := NewWithT(t)
+
+		bundle, err := output.ClusterResourceSet("addons", "fleet",
+			decodeObjects(g, testOutputYAML),
+			output.WithMaxConfigMapSize(150),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(len(bundle)).Should(BeNumerically(">", 2))
+
+		for _, cm := range bundle[:len(bundle)-1] {
+			data, _, err := unstructured.NestedString(cm.Object, "data", output.CRSDataKey)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(len(data)).Should(BeNumerically("<=", 150))
+		}
+
+		resources, _, err := unstructured.NestedSlice(bundle[len(bundle)-1].Object, "spec", "resources")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(resources).Should(HaveLen(len(bundle) - 1))
+
+		g.Expect(names(shardObjects(g, bundle))).Should(Equal([]string{"Namespace/apps", "Service/web", "Deployment/web"}))
+	})
+
+	t.Run("should store Secrets in Secret shards", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := append(decodeObjects(g, testOutputYAML), decodeObjects(g, testCRSSecretYAML)...)
+
+		bundle, err := output.ClusterResourceSet("addons", "fleet", objects)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(names(bundle)).Should(Equal([]string{
+			"ConfigMap/addons-0", "Secret/addons-1", "ConfigMap/addons-2", "ClusterResourceSet/addons",
+		}))
+
+		for _, cm := range []unstructured.Unstructured{bundle[0], bundle[2]} {
+			data, _, err := unstructured.NestedString(cm.Object, "data", output.CRSDataKey)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(data).ShouldNot(ContainSubstring("hunter2"))
+		}
+
+		g.Expect(bundle[1].Object).Should(HaveKeyWithValue("type", output.CRSSecretType))
+
+		encoded, _, err := unstructured.NestedString(bundle[1].Object, "data", output.CRSDataKey)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(names(decodeObjects(g, string(data)))).Should(Equal([]string{"Secret/credentials"}))
+
+		resources, _, err := unstructured.NestedSlice(bundle[3].Object, "spec", "resources")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(resources).Should(Equal([]any{
+			map[string]any{"kind": "ConfigMap", "name": "addons-0"},
+			map[string]any{"kind": "Secret", "name": "addons-1"},
+			map[string]any{"kind": "ConfigMap", "name": "addons-2"},
+		}))
+	})
+
+	t.Run("should fail when an object exceeds the size limit", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.ClusterResourceSet("addons", "fleet",
+			decodeObjects(g, testOutputYAML),
+			output.WithMaxConfigMapSize(10),
+		)
+		g.Expect(err).Should(MatchError(output.ErrObjectTooLarge))
+	})
+}
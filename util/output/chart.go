@@ -0,0 +1,137 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// ChartFileName is the chart metadata file written with WithHelmChart.
+	ChartFileName = "Chart.yaml"
+
+	// ValuesFileName is the values file written with WithHelmChart.
+	ValuesFileName = "values.yaml"
+
+	// ChartTemplatesDir is the chart directory holding the rendered objects.
+	ChartTemplatesDir = "templates"
+
+	// DefaultChartVersion is the chart version used when none is configured.
+	DefaultChartVersion = "0.1.0"
+
+	// chartAPIVersion is the Helm 3 chart API version.
+	chartAPIVersion = "v2"
+)
+
+var (
+	// ErrChartNameEmpty is returned when a Helm chart has no name.
+	ErrChartNameEmpty = errors.New("chart name cannot be empty")
+
+	// ErrInvalidChartName is returned when a Helm chart name is not a
+	// DNS-1123 label, e.g. when it contains a path separator.
+	ErrInvalidChartName = errors.New("invalid chart name")
+
+	// ErrIncompatibleOptions is returned when writer options cannot be combined.
+	ErrIncompatibleOptions = errors.New("incompatible options")
+
+	// chartKeys are written first, in this order, in Chart.yaml.
+	chartKeys = []string{"apiVersion", "name", "description", "type", "version", "appVersion"}
+
+	// templateEscaper escapes Go template delimiters so that Helm emits the
+	// rendered content verbatim. The replacer works in a single pass, so the
+	// delimiters it inserts are not escaped again.
+	templateEscaper = strings.NewReplacer(
+		"{{", `{{ "{{" }}`,
+		"}}", `{{ "}}" }}`,
+	)
+)
+
+// HelmChart configures the Helm chart wrapping the written files, so that
+// consumers which can only deploy charts can consume any render.
+type HelmChart struct {
+	// Name is the chart name; required. It must be a DNS-1123 label, i.e.
+	// lowercase alphanumerics and dashes, as it names the chart directory.
+	Name string
+
+	// Version is the chart version; defaults to DefaultChartVersion.
+	Version string
+
+	// AppVersion is the version of the packaged application, if any.
+	AppVersion string
+
+	// Description is a one-line description of the chart.
+	Description string
+
+	// Values are written to values.yaml. The templates are static, so the
+	// values only document the configuration the objects were rendered with.
+	Values map[string]any
+}
+
+func (c *HelmChart) validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return ErrChartNameEmpty
+	}
+
+	if errs := validation.IsDNS1123Label(c.Name); len(errs) > 0 {
+		return fmt.Errorf("%w: %q: %s", ErrInvalidChartName, c.Name, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// wrap moves files into the templates directory, escaping their content, and
+// adds the chart metadata and values files.
+func (c *HelmChart) wrap(files []encodedFile) ([]encodedFile, error) {
+	result := make([]encodedFile, 0, len(files)+2)
+
+	metadata := map[string]any{
+		"apiVersion": chartAPIVersion,
+		"name":       c.Name,
+		"type":       "application",
+		"version":    c.Version,
+	}
+
+	if c.Version == "" {
+		metadata["version"] = DefaultChartVersion
+	}
+
+	if c.AppVersion != "" {
+		metadata["appVersion"] = c.AppVersion
+	}
+
+	if c.Description != "" {
+		metadata["description"] = c.Description
+	}
+
+	var chart bytes.Buffer
+	if err := encodeMap(&chart, metadata, chartKeys); err != nil {
+		return nil, fmt.Errorf("unable to encode %s: %w", ChartFileName, err)
+	}
+
+	values := c.Values
+	if values == nil {
+		values = make(map[string]any)
+	}
+
+	var valuesData bytes.Buffer
+	if err := encodeMap(&valuesData, values, nil); err != nil {
+		return nil, fmt.Errorf("unable to encode %s: %w", ValuesFileName, err)
+	}
+
+	result = append(result,
+		encodedFile{path: ChartFileName, data: chart.Bytes()},
+		encodedFile{path: ValuesFileName, data: valuesData.Bytes()},
+	)
+
+	for _, f := range files {
+		result = append(result, encodedFile{
+			path: ChartTemplatesDir + "/" + f.path,
+			data: []byte(templateEscaper.Replace(string(f.data))),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package output_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testTemplatedYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: alerts
+  namespace: apps
+data:
+  rule: "{{ $labels.instance }} is down"
+`
+
+const testChartYAML = `apiVersion: v2
+name: web
+description: Web application
+type: application
+version: 1.2.3
+appVersion: 2.0.0
+`
+
+const testChartValuesYAML = `image:
+  tag: 2.0.0
+replicas: 2
+`
+
+const testEscapedConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: alerts
+  namespace: apps
+data:
+  rule: '{{ "{{" }} $labels.instance {{ "}}" }} is down'
+`
+
+func TestWithHelmChart(t *testing.T) {
+	t.Run("should write a chart skeleton", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		w, err := output.NewDirWriter(dir, output.WithHelmChart(output.HelmChart{
+			Name:        "web",
+			Version:     "1.2.3",
+			AppVersion:  "2.0.0",
+			Description: "Web application",
+			Values: map[string]any{
+				"replicas": int64(2),
+				"image":    map[string]any{"tag": "2.0.0"},
+			},
+		}))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		g.Expect(listFiles(g, dir)).Should(ConsistOf(
+			output.ChartFileName,
+			output.ValuesFileName,
+			filepath.Join(output.ChartTemplatesDir, "namespace-apps.yaml"),
+			filepath.Join(output.ChartTemplatesDir, "deployment-web.yaml"),
+			filepath.Join(output.ChartTemplatesDir, "service-web.yaml"),
+		))
+
+		chart, err := os.ReadFile(filepath.Join(dir, output.ChartFileName))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(chart)).Should(Equal(testChartYAML))
+
+		values, err := os.ReadFile(filepath.Join(dir, output.ValuesFileName))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(values)).Should(Equal(testChartValuesYAML))
+	})
+
+	t.Run("should default the version and write empty values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		w, err := output.NewDirWriter(dir, output.WithHelmChart(output.HelmChart{Name: "web"}))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		chart, err := os.ReadFile(filepath.Join(dir, output.ChartFileName))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(chart)).Should(ContainSubstring("version: " + output.DefaultChartVersion + "\n"))
+
+		values, err := os.ReadFile(filepath.Join(dir, output.ValuesFileName))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(values)).Should(Equal("{}\n"))
+	})
+
+	t.Run("should escape template delimiters", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		w, err := output.NewDirWriter(dir, output.WithHelmChart(output.HelmChart{Name: "alerts"}))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testTemplatedYAML))).Should(Succeed())
+
+		data, err := os.ReadFile(filepath.Join(dir, output.ChartTemplatesDir, "configmap-alerts.yaml"))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(data)).Should(Equal(testEscapedConfigMap))
+	})
+
+	t.Run("should nest archive entries in the chart directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data, err := output.Archive(
+			decodeObjects(g, testOutputYAML),
+			output.WithHelmChart(output.HelmChart{Name: "web"}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		entries, _ := readArchive(g, data)
+		g.Expect(entries).Should(Equal([]string{
+			"web/",
+			"web/Chart.yaml",
+			"web/templates/",
+			"web/templates/deployment-web.yaml",
+			"web/templates/namespace-apps.yaml",
+			"web/templates/service-web.yaml",
+			"web/values.yaml",
+		}))
+	})
+
+	t.Run("should reject a chart without a name", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.NewDirWriter(t.TempDir(), output.WithHelmChart(output.HelmChart{}))
+		g.Expect(err).Should(MatchError(output.ErrChartNameEmpty))
+	})
+
+	t.Run("should reject chart names that are not DNS-1123 labels", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for _, name := range []string{"../../evil", "a/b", `a\b`, "..", "Web"} {
+			_, err := output.NewDirWriter(t.TempDir(), output.WithHelmChart(output.HelmChart{Name: name}))
+			g.Expect(err).Should(MatchError(output.ErrInvalidChartName), name)
+
+			_, err = output.Archive(decodeObjects(g, testOutputYAML), output.WithHelmChart(output.HelmChart{Name: name}))
+			g.Expect(err).Should(MatchError(output.ErrInvalidChartName), name)
+		}
+	})
+
+	t.Run("should reject a chart with a kustomization", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.NewDirWriter(t.TempDir(),
+			output.WithHelmChart(output.HelmChart{Name: "web"}),
+			output.WithKustomization(output.Kustomization{}),
+		)
+		g.Expect(err).Should(MatchError(output.ErrIncompatibleOptions))
+	})
+}
@@ -0,0 +1,384 @@
+// Package output writes rendered objects to files, streams and archives.
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const (
+	// SingleFileName is the file written by LayoutSingleFile.
+	SingleFileName = "all.yaml"
+
+	// KustomizationFileName is the kustomization written with WithKustomization.
+	KustomizationFileName = "kustomization.yaml"
+
+	// ClusterScopedDir is the directory holding cluster-scoped objects with
+	// LayoutPerNamespace.
+	ClusterScopedDir = "_cluster"
+)
+
+var (
+	// ErrFileCollision is returned when two objects map to the same file.
+	ErrFileCollision = errors.New("file collision")
+
+	// ErrUnknownLayout is returned for an unsupported directory layout.
+	ErrUnknownLayout = errors.New("unknown layout")
+)
+
+// PostProcessor transforms the content of an emitted file; path is
+// slash-separated and relative to the output root.
+type PostProcessor func(path string, content []byte) ([]byte, error)
+
+// Layout selects how objects are distributed over files.
+type Layout string
+
+const (
+	// LayoutPerResource writes each object to <kind>-<name>.yaml.
+	LayoutPerResource Layout = "per-resource"
+
+	// LayoutPerNamespace writes each object to <namespace>/<kind>-<name>.yaml,
+	// cluster-scoped objects going to the _cluster directory.
+	LayoutPerNamespace Layout = "per-namespace"
+
+	// LayoutSingleFile writes all objects to all.yaml.
+	LayoutSingleFile Layout = "single-file"
+)
+
+// DirWriter writes rendered objects to a directory. Each Write replaces the
+// whole directory: the content is staged in a sibling directory which is
+// swapped in only once every file was written, so the directory never holds
+// a mix of old and new files and stale files do not survive. The swap takes
+// two renames, so the directory is briefly missing in between; after a crash
+// at that point the previous content is left in the sibling
+// ".<name>.tmp-*.old" directory. The directory must be dedicated to the
+// writer.
+//
+// Files are created readable by the owner only, as renders routinely contain
+// Secrets.
+type DirWriter struct {
+	dir  string
+	opts DirOptions
+}
+
+// NewDirWriter creates a writer for the directory at dir.
+func NewDirWriter(dir string, opts ...DirOption) (*DirWriter, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, utilerrors.ErrPathEmpty
+	}
+
+	options, err := newDirOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DirWriter{
+		dir:  filepath.Clean(dir),
+		opts: options,
+	}, nil
+}
+
+// Write replaces the content of the directory with the given objects.
+func (w *DirWriter) Write(objects []unstructured.Unstructured) error {
+	files, err := encodeFiles(objects, w.opts)
+	if err != nil {
+		return err
+	}
+
+	parent := filepath.Dir(w.dir)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return fmt.Errorf("unable to create directory %s: %w", parent, err)
+	}
+
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(w.dir)+".tmp-")
+	if err != nil {
+		return fmt.Errorf("unable to create staging directory: %w", err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(staging)
+	}()
+
+	for _, f := range files {
+		if err := writeFile(filepath.Join(staging, filepath.FromSlash(f.path)), f.data); err != nil {
+			return err
+		}
+	}
+
+	return swapDir(staging, w.dir)
+}
+
+// Kustomization configures the kustomization.yaml generated next to the
+// written files, so the directory can be consumed directly by kustomize,
+// Flux or Argo CD.
+type Kustomization struct {
+	// Namespace is set as the kustomization namespace when not empty.
+	Namespace string
+
+	// CommonLabels are set as the kustomization commonLabels when not empty.
+	CommonLabels map[string]string
+}
+
+// object builds the kustomization listing the given files as resources.
+func (k *Kustomization) object(files []*file) unstructured.Unstructured {
+	resources := make([]any, 0, len(files))
+	for _, f := range files {
+		resources = append(resources, f.path)
+	}
+
+	obj := map[string]any{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  resources,
+	}
+
+	if k.Namespace != "" {
+		obj["namespace"] = k.Namespace
+	}
+
+	if len(k.CommonLabels) > 0 {
+		labels := make(map[string]any, len(k.CommonLabels))
+		for key, value := range k.CommonLabels {
+			labels[key] = value
+		}
+
+		obj["commonLabels"] = labels
+	}
+
+	return unstructured.Unstructured{Object: obj}
+}
+
+func newDirOptions(opts []DirOption) (DirOptions, error) {
+	options := DirOptions{
+		Layout: LayoutPerResource,
+	}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	switch options.Layout {
+	case LayoutPerResource, LayoutPerNamespace, LayoutSingleFile:
+	default:
+		return DirOptions{}, fmt.Errorf("%w: %q", ErrUnknownLayout, string(options.Layout))
+	}
+
+	if options.FileTemplate != "" {
+		if _, err := parseFileTemplate(options.FileTemplate); err != nil {
+			return DirOptions{}, err
+		}
+	}
+
+	if options.HelmChart != nil {
+		if err := options.HelmChart.validate(); err != nil {
+			return DirOptions{}, err
+		}
+
+		// Helm would render the kustomization as a template of the chart.
+		if options.Kustomization != nil {
+			return DirOptions{}, fmt.Errorf("%w: a Helm chart cannot include a kustomization", ErrIncompatibleOptions)
+		}
+	}
+
+	return options, nil
+}
+
+// file is a file of the output tree; path is slash-separated and relative to
+// the root of the tree.
+type file struct {
+	path    string
+	objects []unstructured.Unstructured
+}
+
+type encodedFile struct {
+	path string
+	data []byte
+}
+
+// encodeFiles lays out and encodes the files of the output tree, including
+// the kustomization or the Helm chart wrapping when configured, and runs the
+// post-processors.
+func encodeFiles(objects []unstructured.Unstructured, opts DirOptions) ([]encodedFile, error) {
+	files, err := layoutFiles(objects, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Kustomization != nil {
+		for _, f := range files {
+			if f.path == KustomizationFileName {
+				return nil, fmt.Errorf("%w: objects map to %s", ErrFileCollision, KustomizationFileName)
+			}
+		}
+
+		files = append(files, &file{
+			path:    KustomizationFileName,
+			objects: []unstructured.Unstructured{opts.Kustomization.object(files)},
+		})
+	}
+
+	result := make([]encodedFile, 0, len(files))
+
+	for _, f := range files {
+		var buf bytes.Buffer
+		if err := WriteYAML(&buf, f.objects); err != nil {
+			return nil, fmt.Errorf("unable to encode %s: %w", f.path, err)
+		}
+
+		result = append(result, encodedFile{path: f.path, data: buf.Bytes()})
+	}
+
+	if opts.HelmChart != nil {
+		result, err = opts.HelmChart.wrap(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range result {
+		// Paths are checked once final, as they are also written by the Helm
+		// chart wrapping.
+		if _, err := cleanFilePath(result[i].path); err != nil {
+			return nil, err
+		}
+
+		for _, fn := range opts.PostProcessors {
+			data, err := fn(result[i].path, result[i].data)
+			if err != nil {
+				return nil, fmt.Errorf("unable to post-process %s: %w", result[i].path, err)
+			}
+
+			result[i].data = data
+		}
+	}
+
+	return result, nil
+}
+
+// layoutFiles groups the objects by file, in order of first appearance.
+func layoutFiles(objects []unstructured.Unstructured, opts DirOptions) ([]*file, error) {
+	pathOf := func(obj *unstructured.Unstructured) (string, error) {
+		return filePath(obj, opts.Layout)
+	}
+
+	switch {
+	case opts.FileTemplate != "":
+		t, err := parseFileTemplate(opts.FileTemplate)
+		if err != nil {
+			return nil, err
+		}
+
+		pathOf = func(obj *unstructured.Unstructured) (string, error) {
+			return templatePath(t, obj)
+		}
+	case opts.Layout == LayoutSingleFile:
+		return []*file{{path: SingleFileName, objects: objects}}, nil
+	}
+
+	result := make([]*file, 0, len(objects))
+	owners := make(map[string]k8s.ResourceKey, len(objects))
+
+	for i := range objects {
+		key := k8s.KeyOf(&objects[i])
+
+		path, err := pathOf(&objects[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+
+		if owner, ok := owners[path]; ok {
+			return nil, fmt.Errorf("%w: %s and %s both map to %s", ErrFileCollision, owner, key, path)
+		}
+
+		owners[path] = key
+		result = append(result, &file{path: path, objects: objects[i : i+1]})
+	}
+
+	// A file cannot also be the directory of another file.
+	for _, f := range result {
+		for _, dir := range parentDirs(f.path) {
+			if owner, ok := owners[dir]; ok {
+				return nil, fmt.Errorf("%w: %s is both the file of %s and a directory of %s", ErrFileCollision, dir, owner, f.path)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// filePath returns the path of obj in the layout. Names and namespaces that
+// could leave the output directory are rejected.
+func filePath(obj *unstructured.Unstructured, layout Layout) (string, error) {
+	for _, segment := range []string{obj.GetName(), obj.GetNamespace()} {
+		if strings.ContainsAny(segment, `/\`) || strings.Contains(segment, "..") {
+			return "", fmt.Errorf("%w: %q", ErrInvalidFilePath, segment)
+		}
+	}
+
+	name := strings.ToLower(obj.GetKind()) + "-" + obj.GetName() + ".yaml"
+
+	if layout != LayoutPerNamespace {
+		return name, nil
+	}
+
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = ClusterScopedDir
+	}
+
+	return namespace + "/" + name, nil
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("unable to create directory for %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// swapDir replaces target with staging. The previous content is moved aside
+// first and only removed once staging is in place, so a failed rename leaves
+// either the old or the new tree. The two renames are not atomic together:
+// target does not exist between them.
+func swapDir(staging string, target string) error {
+	backup := staging + ".old"
+
+	hadTarget := true
+	if err := os.Rename(target, backup); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("unable to replace %s: %w", target, err)
+		}
+
+		hadTarget = false
+	}
+
+	if err := os.Rename(staging, target); err != nil {
+		if hadTarget {
+			_ = os.Rename(backup, target)
+		}
+
+		return fmt.Errorf("unable to replace %s: %w", target, err)
+	}
+
+	if hadTarget {
+		if err := os.RemoveAll(backup); err != nil {
+			return fmt.Errorf("unable to remove previous content of %s: %w", target, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// DirOption is a generic option for DirWriter.
+type DirOption = util.Option[DirOptions]
+
+// DirOptions is a struct-based option that can set multiple directory writer options at once.
+type DirOptions struct {
+	// Layout selects how objects are distributed over files.
+	Layout Layout
+
+	// FileTemplate, when set, names the file of each object and takes
+	// precedence over Layout.
+	FileTemplate string
+
+	// Kustomization, when set, generates a kustomization.yaml listing the
+	// written files.
+	Kustomization *Kustomization
+
+	// HelmChart, when set, wraps the written files into a Helm chart.
+	HelmChart *HelmChart
+
+	// PostProcessors transform the content of each file, in order.
+	PostProcessors []PostProcessor
+}
+
+// ApplyTo applies the directory writer options to the target configuration.
+func (opts DirOptions) ApplyTo(target *DirOptions) {
+	if opts.Layout != "" {
+		target.Layout = opts.Layout
+	}
+	if opts.FileTemplate != "" {
+		target.FileTemplate = opts.FileTemplate
+	}
+	if opts.Kustomization != nil {
+		target.Kustomization = opts.Kustomization
+	}
+	if opts.HelmChart != nil {
+		target.HelmChart = opts.HelmChart
+	}
+	target.PostProcessors = append(target.PostProcessors, opts.PostProcessors...)
+}
+
+// WithLayout sets the file layout; defaults to LayoutPerResource.
+func WithLayout(layout Layout) DirOption {
+	return util.FunctionalOption[DirOptions](func(opts *DirOptions) {
+		opts.Layout = layout
+	})
+}
+
+// WithFileTemplate names the file of each object with a text/template, taking
+// precedence over the layout. The template receives the Group, Version, Kind,
+// Namespace and Name of the object and may use the lower, upper and default
+// functions; the resulting slash-separated path must stay within the
+// directory. Two objects mapping to the same file fail the write.
+//
+// Example:
+//
+//	output.WithFileTemplate(`{{.Namespace | default "_cluster"}}/{{.Kind | lower}}/{{.Name}}.yaml`)
+func WithFileTemplate(tmpl string) DirOption {
+	return util.FunctionalOption[DirOptions](func(opts *DirOptions) {
+		opts.FileTemplate = tmpl
+	})
+}
+
+// WithKustomization generates a kustomization.yaml listing every written file
+// as a resource, with the given namespace and common labels.
+func WithKustomization(kustomization Kustomization) DirOption {
+	return util.FunctionalOption[DirOptions](func(opts *DirOptions) {
+		opts.Kustomization = &kustomization
+	})
+}
+
+// WithHelmChart wraps the written files into a Helm chart: the objects go to
+// the templates directory with Go template delimiters escaped, next to a
+// generated Chart.yaml and values.yaml. It cannot be combined with
+// WithKustomization.
+func WithHelmChart(chart HelmChart) DirOption {
+	return util.FunctionalOption[DirOptions](func(opts *DirOptions) {
+		opts.HelmChart = &chart
+	})
+}
+
+// WithPostProcessor transforms the content of every emitted file, including
+// generated ones such as kustomization.yaml, e.g. to add a license header or
+// a "DO NOT EDIT" banner or to run a formatter. The function receives the
+// slash-separated path of the file relative to the output root. Several
+// post-processors run in the order they are given.
+//
+// Example:
+//
+//	output.WithPostProcessor(func(_ string, content []byte) ([]byte, error) {
+//	    return append([]byte("# Code generated by platform-render. DO NOT EDIT.\n"), content...), nil
+//	})
+func WithPostProcessor(fn PostProcessor) DirOption {
+	return util.FunctionalOption[DirOptions](func(opts *DirOptions) {
+		opts.PostProcessors = append(opts.PostProcessors, fn)
+	})
+}
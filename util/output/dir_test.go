@@ -0,0 +1,314 @@
+package output_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	utilerrors "github.com/k8s-manifest-kit/pkg/util/errors"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testOutputYAML = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: apps
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+spec:
+  replicas: 2
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: apps
+`
+
+const testCollidingYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: b
+`
+
+const testEscapingYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: ../..
+`
+
+const testKustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+commonLabels:
+  team: platform
+namespace: apps
+resources:
+  - _cluster/namespace-apps.yaml
+  - apps/deployment-web.yaml
+  - apps/service-web.yaml
+`
+
+func decodeObjects(g *WithT, content string) []unstructured.Unstructured {
+	objects, err := k8s.DecodeYAML([]byte(content))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return objects
+}
+
+func names(objects []unstructured.Unstructured) []string {
+	result := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		result = append(result, obj.GetKind()+"/"+obj.GetName())
+	}
+
+	return result
+}
+
+// listFiles returns the paths of the regular files below dir, relative to dir.
+func listFiles(g *WithT, dir string) []string {
+	var result []string
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, rel)
+		}
+
+		return nil
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return result
+}
+
+func TestNewDirWriter(t *testing.T) {
+	t.Run("rejects an empty directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.NewDirWriter("")
+
+		g.Expect(err).Should(MatchError(utilerrors.ErrPathEmpty))
+	})
+
+	t.Run("rejects unknown layouts", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.NewDirWriter(t.TempDir(), output.WithLayout("random"))
+
+		g.Expect(err).Should(MatchError(output.ErrUnknownLayout))
+	})
+}
+
+func TestDirWriter(t *testing.T) {
+	t.Run("writes one file per resource by default", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := filepath.Join(t.TempDir(), "out")
+
+		w, err := output.NewDirWriter(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		g.Expect(listFiles(g, dir)).Should(ConsistOf("namespace-apps.yaml", "deployment-web.yaml", "service-web.yaml"))
+
+		data, err := os.ReadFile(filepath.Join(dir, "deployment-web.yaml"))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		objects, err := k8s.DecodeYAML(data)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(objects).Should(HaveLen(1))
+		g.Expect(objects[0].GetName()).Should(Equal("web"))
+	})
+
+	t.Run("writes per-namespace subdirectories", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		w, err := output.NewDirWriter(dir, output.WithLayout(output.LayoutPerNamespace))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		g.Expect(listFiles(g, dir)).Should(ConsistOf(
+			filepath.Join("_cluster", "namespace-apps.yaml"),
+			filepath.Join("apps", "deployment-web.yaml"),
+			filepath.Join("apps", "service-web.yaml"),
+		))
+	})
+
+	t.Run("writes a single file", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		w, err := output.NewDirWriter(dir, output.WithLayout(output.LayoutSingleFile))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		g.Expect(listFiles(g, dir)).Should(Equal([]string{output.SingleFileName}))
+
+		data, err := os.ReadFile(filepath.Join(dir, output.SingleFileName))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		objects, err := k8s.DecodeYAML(data)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(objects).Should(HaveLen(3))
+	})
+
+	t.Run("replaces previous content", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(os.WriteFile(filepath.Join(dir, "stale.yaml"), []byte("stale"), 0o600)).Should(Succeed())
+
+		w, err := output.NewDirWriter(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		g.Expect(filepath.Join(dir, "stale.yaml")).ShouldNot(BeAnExistingFile())
+
+		entries, err := os.ReadDir(filepath.Dir(dir))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(entries).Should(HaveLen(1))
+	})
+
+	t.Run("rejects colliding file names and keeps previous content", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(os.WriteFile(filepath.Join(dir, "previous.yaml"), []byte("previous"), 0o600)).Should(Succeed())
+
+		w, err := output.NewDirWriter(dir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		err = w.Write(decodeObjects(g, testCollidingYAML))
+		g.Expect(err).Should(MatchError(output.ErrFileCollision))
+		g.Expect(filepath.Join(dir, "previous.yaml")).Should(BeAnExistingFile())
+	})
+
+	t.Run("rejects names and namespaces leaving the directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		root := t.TempDir()
+
+		w, err := output.NewDirWriter(filepath.Join(root, "out"), output.WithLayout(output.LayoutPerNamespace))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		err = w.Write(decodeObjects(g, testEscapingYAML))
+		g.Expect(err).Should(MatchError(output.ErrInvalidFilePath))
+
+		objects := decodeObjects(g, testOutputYAML)
+		objects[1].SetName("../../web")
+
+		err = w.Write(objects)
+		g.Expect(err).Should(MatchError(output.ErrInvalidFilePath))
+
+		entries, err := os.ReadDir(root)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(entries).Should(BeEmpty())
+	})
+
+	t.Run("generates a kustomization listing the written files", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		w, err := output.NewDirWriter(dir,
+			output.WithLayout(output.LayoutPerNamespace),
+			output.WithKustomization(output.Kustomization{
+				Namespace:    "apps",
+				CommonLabels: map[string]string{"team": "platform"},
+			}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		data, err := os.ReadFile(filepath.Join(dir, output.KustomizationFileName))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(data)).Should(Equal(testKustomization))
+	})
+
+	t.Run("post-processes every file in order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		var paths []string
+
+		w, err := output.NewDirWriter(dir,
+			output.WithKustomization(output.Kustomization{}),
+			output.WithPostProcessor(func(path string, content []byte) ([]byte, error) {
+				paths = append(paths, path)
+
+				return append([]byte("# DO NOT EDIT\n"), content...), nil
+			}),
+			output.WithPostProcessor(func(_ string, content []byte) ([]byte, error) {
+				return append([]byte("# License: Apache-2.0\n"), content...), nil
+			}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		g.Expect(paths).Should(ConsistOf(
+			"namespace-apps.yaml",
+			"deployment-web.yaml",
+			"service-web.yaml",
+			output.KustomizationFileName,
+		))
+
+		for _, name := range paths {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(string(data)).Should(HavePrefix("# License: Apache-2.0\n# DO NOT EDIT\n"))
+		}
+	})
+
+	t.Run("fails when a post-processor fails and keeps previous content", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(os.WriteFile(filepath.Join(dir, "previous.yaml"), []byte("previous"), 0o600)).Should(Succeed())
+
+		errFormat := errors.New("format failed")
+
+		w, err := output.NewDirWriter(dir,
+			output.WithPostProcessor(func(_ string, _ []byte) ([]byte, error) {
+				return nil, errFormat
+			}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		err = w.Write(decodeObjects(g, testOutputYAML))
+		g.Expect(err).Should(MatchError(errFormat))
+		g.Expect(filepath.Join(dir, "previous.yaml")).Should(BeAnExistingFile())
+	})
+}
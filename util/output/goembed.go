@@ -0,0 +1,98 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ErrInvalidIdentifier is returned when a Go package or variable name is not
+// a valid identifier.
+var ErrInvalidIdentifier = errors.New("invalid Go identifier")
+
+var goEmbedTemplate = template.Must(template.New("goembed").Parse(`// Code generated by k8s-manifest-kit. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// {{.Var}} holds the rendered objects as a multi-document YAML stream.
+const {{.Var}} = {{.Literal}}
+
+// {{.Var}}Objects decodes {{.Var}}; each call returns new objects that the
+// caller may modify.
+func {{.Var}}Objects() ([]unstructured.Unstructured, error) {
+	return k8s.DecodeYAML([]byte({{.Var}}))
+}
+`))
+
+// WriteGoEmbed writes to w a Go source file of package pkg embedding objects
+// as the YAML constant varName, plus a varName+"Objects" accessor decoding
+// them, so operators can compile a baseline render into their binary. The
+// objects are encoded with WriteYAML and the source is gofmt-formatted, so
+// regenerating from the same objects leaves the file unchanged.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	err := output.WriteGoEmbed(&buf, "manifests", "Baseline", objects)
+//	// manifests.BaselineObjects() returns the objects at runtime.
+func WriteGoEmbed(w io.Writer, pkg string, varName string, objects []unstructured.Unstructured) error {
+	if !token.IsIdentifier(pkg) {
+		return fmt.Errorf("%w: package %q", ErrInvalidIdentifier, pkg)
+	}
+
+	if !token.IsIdentifier(varName) {
+		return fmt.Errorf("%w: variable %q", ErrInvalidIdentifier, varName)
+	}
+
+	var manifests bytes.Buffer
+	if err := WriteYAML(&manifests, objects); err != nil {
+		return err
+	}
+
+	var src bytes.Buffer
+
+	err := goEmbedTemplate.Execute(&src, map[string]any{
+		"Package": pkg,
+		"Var":     varName,
+		"Literal": goStringLiteral(manifests.String()),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to generate Go source: %w", err)
+	}
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to format Go source: %w", err)
+	}
+
+	if _, err := w.Write(formatted); err != nil {
+		return fmt.Errorf("unable to write Go source: %w", err)
+	}
+
+	return nil
+}
+
+// goStringLiteral returns s as a raw string literal, which keeps the embedded
+// YAML readable and diffable, falling back to an interpreted literal when s
+// cannot be represented raw.
+func goStringLiteral(s string) string {
+	if strings.ContainsAny(s, "`\r") {
+		return strconv.Quote(s)
+	}
+
+	return "`" + s + "`"
+}
@@ -0,0 +1,101 @@
+package output_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testBacktickYAML = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: script\ndata:\n  run.sh: echo `date`\n"
+
+// parseGoEmbed parses generated source, returning its package name and the
+// value of the string constant name.
+func parseGoEmbed(g *WithT, src []byte, name string) (string, string) {
+	file, err := parser.ParseFile(token.NewFileSet(), "embed.go", src, parser.ParseComments)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	var value string
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || spec.Names[0].Name != name {
+			return true
+		}
+
+		lit, ok := spec.Values[0].(*ast.BasicLit)
+		g.Expect(ok).Should(BeTrue())
+
+		value, err = strconv.Unquote(lit.Value)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		return false
+	})
+
+	return file.Name.Name, value
+}
+
+func TestWriteGoEmbed(t *testing.T) {
+	t.Run("should embed the objects with an accessor", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteGoEmbed(&buf, "manifests", "Baseline", objects)).Should(Succeed())
+
+		src := buf.String()
+		g.Expect(src).Should(HavePrefix("// Code generated by k8s-manifest-kit. DO NOT EDIT.\n"))
+		g.Expect(src).Should(ContainSubstring("const Baseline = `apiVersion: v1\n"))
+		g.Expect(src).Should(ContainSubstring("func BaselineObjects() ([]unstructured.Unstructured, error) {"))
+
+		pkg, value := parseGoEmbed(g, buf.Bytes(), "Baseline")
+		g.Expect(pkg).Should(Equal("manifests"))
+
+		decoded, err := k8s.DecodeYAML([]byte(value))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(decoded).Should(Equal(objects))
+	})
+
+	t.Run("should fall back to an interpreted literal", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testBacktickYAML)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteGoEmbed(&buf, "manifests", "scripts", objects)).Should(Succeed())
+		g.Expect(buf.String()).Should(ContainSubstring(`const scripts = "apiVersion: v1\n`))
+
+		_, value := parseGoEmbed(g, buf.Bytes(), "scripts")
+
+		decoded, err := k8s.DecodeYAML([]byte(value))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(decoded).Should(Equal(objects))
+	})
+
+	t.Run("should produce identical source for identical objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var first, second bytes.Buffer
+		g.Expect(output.WriteGoEmbed(&first, "manifests", "Baseline", decodeObjects(g, testOutputYAML))).Should(Succeed())
+		g.Expect(output.WriteGoEmbed(&second, "manifests", "Baseline", decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		g.Expect(first.String()).Should(Equal(second.String()))
+	})
+
+	t.Run("should reject invalid identifiers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteGoEmbed(&buf, "my-pkg", "Baseline", nil)).Should(MatchError(output.ErrInvalidIdentifier))
+		g.Expect(output.WriteGoEmbed(&buf, "manifests", "func", nil)).Should(MatchError(output.ErrInvalidIdentifier))
+		g.Expect(buf.Len()).Should(BeZero())
+	})
+}
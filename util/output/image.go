@@ -0,0 +1,167 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// containerFields are the pod spec fields listing containers.
+var containerFields = []string{"initContainers", "containers", "ephemeralContainers"}
+
+// imageReportHeader is the header row of the CSV image report.
+var imageReportHeader = []string{"image", "group", "kind", "namespace", "name", "container", "path"}
+
+// ImageReportEntry records a container image and where it is referenced.
+type ImageReportEntry struct {
+	Image     string `json:"image"`
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Container string `json:"container"`
+
+	// Path is the field path of the image, e.g.
+	// "spec.template.spec.containers[0].image".
+	Path string `json:"path"`
+}
+
+// ImageReportDocument lists the container images a rendered set runs.
+type ImageReportDocument struct {
+	// Images are the distinct images, sorted.
+	Images []string `json:"images"`
+
+	// Entries are the image references, in object order.
+	Entries []ImageReportEntry `json:"entries"`
+}
+
+// ImageReport returns the container images referenced by objects. Pod specs
+// are found wherever they are nested, so workloads, CronJobs and custom
+// resources embedding pod templates are all covered.
+func ImageReport(objects []unstructured.Unstructured) ImageReportDocument {
+	report := ImageReportDocument{
+		Images:  make([]string, 0),
+		Entries: make([]ImageReportEntry, 0),
+	}
+
+	for i := range objects {
+		obj := &objects[i]
+
+		collectImages(obj.Object, "", func(container string, path string, image string) {
+			report.Entries = append(report.Entries, ImageReportEntry{
+				Image:     image,
+				Group:     obj.GroupVersionKind().Group,
+				Kind:      obj.GetKind(),
+				Namespace: obj.GetNamespace(),
+				Name:      obj.GetName(),
+				Container: container,
+				Path:      path,
+			})
+		})
+	}
+
+	for _, e := range report.Entries {
+		report.Images = append(report.Images, e.Image)
+	}
+
+	slices.Sort(report.Images)
+	report.Images = slices.Compact(report.Images)
+
+	return report
+}
+
+// WriteJSON writes the report to w as a JSON document.
+func (r ImageReportDocument) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("unable to encode image report: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCSV writes the entries of the report to w as CSV, with a header row.
+func (r ImageReportDocument) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(imageReportHeader); err != nil {
+		return fmt.Errorf("unable to write image report: %w", err)
+	}
+
+	for _, e := range r.Entries {
+		record := []string{e.Image, e.Group, e.Kind, e.Namespace, e.Name, e.Container, e.Path}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("unable to write image report: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("unable to write image report: %w", err)
+	}
+
+	return nil
+}
+
+// collectImages walks value and calls fn for each container image found in
+// a container list field.
+func collectImages(value any, path string, fn func(container string, path string, image string)) {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			fieldPath := joinPath(path, k)
+
+			if containers, ok := v[k].([]any); ok && slices.Contains(containerFields, k) && collectContainerImages(containers, fieldPath, fn) {
+				continue
+			}
+
+			collectImages(v[k], fieldPath, fn)
+		}
+	case []any:
+		for i, item := range v {
+			collectImages(item, path+"["+strconv.Itoa(i)+"]", fn)
+		}
+	}
+}
+
+// collectContainerImages reports the images of a container list, returning
+// false when the list does not look like one.
+func collectContainerImages(containers []any, path string, fn func(container string, path string, image string)) bool {
+	found := false
+
+	for i, item := range containers {
+		container, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		image, ok := container["image"].(string)
+		if !ok || image == "" {
+			continue
+		}
+
+		name, _ := container["name"].(string)
+		fn(name, path+"["+strconv.Itoa(i)+"].image", image)
+
+		found = true
+	}
+
+	return found
+}
+
+func joinPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
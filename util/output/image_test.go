@@ -0,0 +1,115 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testImagesYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: migrate
+          image: registry.example.com/web:1.0.0
+      containers:
+        - name: web
+          image: registry.example.com/web:1.0.0
+        - name: proxy
+          image: envoyproxy/envoy:v1.30.0
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+  namespace: apps
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: backup
+              image: busybox:1.36
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: apps
+data:
+  containers: none
+`
+
+const testImagesCSV = `image,group,kind,namespace,name,container,path
+registry.example.com/web:1.0.0,apps,Deployment,apps,web,web,spec.template.spec.containers[0].image
+envoyproxy/envoy:v1.30.0,apps,Deployment,apps,web,proxy,spec.template.spec.containers[1].image
+registry.example.com/web:1.0.0,apps,Deployment,apps,web,migrate,spec.template.spec.initContainers[0].image
+busybox:1.36,batch,CronJob,apps,backup,backup,spec.jobTemplate.spec.template.spec.containers[0].image
+`
+
+func TestImageReport(t *testing.T) {
+	t.Run("should list images with their source objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := output.ImageReport(decodeObjects(g, testImagesYAML))
+
+		g.Expect(report.Images).Should(Equal([]string{
+			"busybox:1.36",
+			"envoyproxy/envoy:v1.30.0",
+			"registry.example.com/web:1.0.0",
+		}))
+		g.Expect(report.Entries).Should(HaveLen(4))
+		g.Expect(report.Entries[3]).Should(Equal(output.ImageReportEntry{
+			Image:     "busybox:1.36",
+			Group:     "batch",
+			Kind:      "CronJob",
+			Namespace: "apps",
+			Name:      "backup",
+			Container: "backup",
+			Path:      "spec.jobTemplate.spec.template.spec.containers[0].image",
+		}))
+	})
+
+	t.Run("should return empty lists without images", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := output.ImageReport(decodeObjects(g, testOutputYAML))
+
+		g.Expect(report.Images).Should(BeEmpty())
+		g.Expect(report.Entries).Should(BeEmpty())
+	})
+
+	t.Run("should write JSON", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := output.ImageReport(decodeObjects(g, testImagesYAML))
+
+		var buf bytes.Buffer
+		g.Expect(report.WriteJSON(&buf)).Should(Succeed())
+
+		var decoded output.ImageReportDocument
+		g.Expect(json.Unmarshal(buf.Bytes(), &decoded)).Should(Succeed())
+		g.Expect(decoded).Should(Equal(report))
+	})
+
+	t.Run("should write CSV", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := output.ImageReport(decodeObjects(g, testImagesYAML))
+
+		var buf bytes.Buffer
+		g.Expect(report.WriteCSV(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(Equal(testImagesCSV))
+	})
+}
@@ -0,0 +1,144 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// InventoryDataKey is the ConfigMap key holding an inventory.
+const InventoryDataKey = "inventory.json"
+
+// ErrInvalidInventory is returned when a ConfigMap does not hold an inventory.
+var ErrInvalidInventory = errors.New("invalid inventory")
+
+// InventoryEntry records an object of a rendered set.
+type InventoryEntry struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Hash is the content hash of the object (see k8s.ContentHash).
+	Hash string `json:"hash"`
+}
+
+// Key returns the ResourceKey of the entry.
+func (e InventoryEntry) Key() k8s.ResourceKey {
+	return k8s.ResourceKey{
+		Group:     e.Group,
+		Kind:      e.Kind,
+		Namespace: e.Namespace,
+		Name:      e.Name,
+	}
+}
+
+// InventoryDocument lists the objects of a rendered set, the foundation for
+// pruning objects that are no longer rendered and for detecting drift.
+type InventoryDocument struct {
+	// Hash identifies the whole set: it changes when an object is added,
+	// removed or modified, and does not depend on the order of the objects.
+	Hash string `json:"hash"`
+
+	// Entries are sorted by resource key.
+	Entries []InventoryEntry `json:"entries"`
+}
+
+// Inventory returns the inventory of objects.
+func Inventory(objects []unstructured.Unstructured) InventoryDocument {
+	entries := make([]InventoryEntry, 0, len(objects))
+
+	for i := range objects {
+		gvk := objects[i].GroupVersionKind()
+
+		entries = append(entries, InventoryEntry{
+			Group:     gvk.Group,
+			Version:   gvk.Version,
+			Kind:      gvk.Kind,
+			Namespace: objects[i].GetNamespace(),
+			Name:      objects[i].GetName(),
+			Hash:      k8s.ContentHash(&objects[i]),
+		})
+	}
+
+	slices.SortStableFunc(entries, func(a InventoryEntry, b InventoryEntry) int {
+		if c := strings.Compare(a.Key().String(), b.Key().String()); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Hash, b.Hash)
+	})
+
+	hasher := sha256.New()
+	for _, e := range entries {
+		_, _ = fmt.Fprintf(hasher, "%s %s\n", e.Key(), e.Hash)
+	}
+
+	return InventoryDocument{
+		Hash:    "sha256:" + hex.EncodeToString(hasher.Sum(nil)),
+		Entries: entries,
+	}
+}
+
+// Keys returns the resource keys of the inventory, in order.
+func (d InventoryDocument) Keys() []k8s.ResourceKey {
+	keys := make([]k8s.ResourceKey, 0, len(d.Entries))
+	for _, e := range d.Entries {
+		keys = append(keys, e.Key())
+	}
+
+	return keys
+}
+
+// ConfigMap returns a ConfigMap storing the inventory under InventoryDataKey,
+// for keeping it in the cluster next to the objects it lists.
+func (d InventoryDocument) ConfigMap(name string, namespace string) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode inventory: %w", err)
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"data": map[string]any{
+				InventoryDataKey: string(data),
+			},
+		},
+	}, nil
+}
+
+// InventoryFromConfigMap returns the inventory stored in a ConfigMap by
+// InventoryDocument.ConfigMap, e.g. as read back from the cluster before
+// pruning.
+func InventoryFromConfigMap(cm *unstructured.Unstructured) (InventoryDocument, error) {
+	data, found, err := unstructured.NestedString(cm.Object, "data", InventoryDataKey)
+	if err != nil {
+		return InventoryDocument{}, fmt.Errorf("%w: %w", ErrInvalidInventory, err)
+	}
+
+	if !found {
+		return InventoryDocument{}, fmt.Errorf("%w: %s has no %s key", ErrInvalidInventory, k8s.KeyOf(cm), InventoryDataKey)
+	}
+
+	var d InventoryDocument
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		return InventoryDocument{}, fmt.Errorf("%w: %w", ErrInvalidInventory, err)
+	}
+
+	return d, nil
+}
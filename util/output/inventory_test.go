@@ -0,0 +1,115 @@
+package output_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestInventory(t *testing.T) {
+	t.Run("should list objects sorted by key", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+		inventory := output.Inventory(objects)
+
+		g.Expect(inventory.Hash).Should(MatchRegexp("^sha256:[0-9a-f]{64}$"))
+		g.Expect(inventory.Entries).Should(Equal([]output.InventoryEntry{
+			{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "apps", Name: "web", Hash: k8s.ContentHash(&objects[1])},
+			{Version: "v1", Kind: "Namespace", Name: "apps", Hash: k8s.ContentHash(&objects[0])},
+			{Version: "v1", Kind: "Service", Namespace: "apps", Name: "web", Hash: k8s.ContentHash(&objects[2])},
+		}))
+		g.Expect(inventory.Keys()).Should(Equal([]k8s.ResourceKey{
+			{Group: "apps", Kind: "Deployment", Namespace: "apps", Name: "web"},
+			{Kind: "Namespace", Name: "apps"},
+			{Kind: "Service", Namespace: "apps", Name: "web"},
+		}))
+	})
+
+	t.Run("should not depend on the order of the objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+		first := output.Inventory(objects)
+
+		slices.Reverse(objects)
+		second := output.Inventory(objects)
+
+		g.Expect(second).Should(Equal(first))
+	})
+
+	t.Run("should change the set hash when an object changes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+		before := output.Inventory(objects)
+
+		err := unstructured.SetNestedField(objects[1].Object, int64(3), "spec", "replicas")
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		after := output.Inventory(objects)
+		g.Expect(after.Hash).ShouldNot(Equal(before.Hash))
+		g.Expect(after.Entries[0].Hash).ShouldNot(Equal(before.Entries[0].Hash))
+		g.Expect(after.Entries[1:]).Should(Equal(before.Entries[1:]))
+	})
+
+	t.Run("should change the set hash when an object is removed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+
+		g.Expect(output.Inventory(objects[:2]).Hash).ShouldNot(Equal(output.Inventory(objects).Hash))
+	})
+
+	t.Run("should store the inventory in a ConfigMap", func(t *testing.T) {
+		g := NewWithT(t)
+
+		inventory := output.Inventory(decodeObjects(g, testOutputYAML))
+
+		cm, err := inventory.ConfigMap("web-inventory", "apps")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(cm.GetKind()).Should(Equal("ConfigMap"))
+		g.Expect(cm.GetNamespace()).Should(Equal("apps"))
+
+		data, _, err := unstructured.NestedString(cm.Object, "data", output.InventoryDataKey)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		var decoded output.InventoryDocument
+		g.Expect(json.Unmarshal([]byte(data), &decoded)).Should(Succeed())
+		g.Expect(decoded).Should(Equal(inventory))
+	})
+
+	t.Run("should read the inventory back from a ConfigMap", func(t *testing.T) {
+		g := NewWithT(t)
+
+		inventory := output.Inventory(decodeObjects(g, testOutputYAML))
+
+		cm, err := inventory.ConfigMap("web-inventory", "apps")
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		decoded, err := output.InventoryFromConfigMap(cm)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(decoded).Should(Equal(inventory))
+	})
+
+	t.Run("should reject ConfigMaps without an inventory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cm := decodeObjects(g, testCollidingYAML)[0]
+
+		_, err := output.InventoryFromConfigMap(&cm)
+		g.Expect(err).Should(MatchError(output.ErrInvalidInventory))
+
+		cm.Object["data"] = map[string]any{output.InventoryDataKey: "{"}
+
+		_, err = output.InventoryFromConfigMap(&cm)
+		g.Expect(err).Should(MatchError(output.ErrInvalidInventory))
+	})
+}
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// WriteJSON writes objects to w as a single JSON array followed by a newline.
+// Map keys are sorted, so identical objects always produce identical bytes.
+func WriteJSON(w io.Writer, objects []unstructured.Unstructured, opts ...JSONOption) error {
+	options := JSONOptions{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	items := make([]map[string]any, len(objects))
+	for i := range objects {
+		items[i] = objects[i].Object
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	if options.Indent != "" {
+		enc.SetIndent("", options.Indent)
+	}
+
+	if err := enc.Encode(items); err != nil {
+		return fmt.Errorf("unable to write JSON: %w", err)
+	}
+
+	return nil
+}
+
+// WriteNDJSON writes objects to w as newline-delimited JSON, one object per
+// line, as expected by line-oriented tools such as jq -c or BigQuery loads.
+func WriteNDJSON(w io.Writer, objects []unstructured.Unstructured) error {
+	bw := bufio.NewWriter(w)
+
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+
+	for i := range objects {
+		if err := enc.Encode(objects[i].Object); err != nil {
+			return fmt.Errorf("unable to encode %s %s: %w", objects[i].GetKind(), objects[i].GetName(), err)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("unable to write NDJSON: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package output
+
+import (
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// JSONOption is a generic option for WriteJSON.
+type JSONOption = util.Option[JSONOptions]
+
+// JSONOptions is a struct-based option that can set JSON writer options.
+type JSONOptions struct {
+	// Indent is the indentation of nested values; empty writes compact JSON.
+	Indent string
+}
+
+// ApplyTo applies the JSON writer options to the target configuration.
+func (opts JSONOptions) ApplyTo(target *JSONOptions) {
+	if opts.Indent != "" {
+		target.Indent = opts.Indent
+	}
+}
+
+// WithIndent pretty-prints the JSON output with the given indentation.
+func WithIndent(indent string) JSONOption {
+	return util.FunctionalOption[JSONOptions](func(opts *JSONOptions) {
+		opts.Indent = indent
+	})
+}
@@ -0,0 +1,72 @@
+package output_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWriteJSON(t *testing.T) {
+	t.Run("writes a JSON array", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteJSON(&buf, objects)).Should(Succeed())
+
+		var decoded []map[string]any
+		g.Expect(json.Unmarshal(buf.Bytes(), &decoded)).Should(Succeed())
+		g.Expect(decoded).Should(HaveLen(3))
+		g.Expect(decoded[1]).Should(HaveKeyWithValue("kind", "Deployment"))
+		g.Expect(buf.String()).Should(HavePrefix(`[{"apiVersion":"v1","kind":"Namespace"`))
+	})
+
+	t.Run("indents on request", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)[:1]
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteJSON(&buf, objects, output.WithIndent("  "))).Should(Succeed())
+
+		g.Expect(buf.String()).Should(HavePrefix("[\n  {\n    \"apiVersion\": \"v1\",\n"))
+	})
+
+	t.Run("writes an empty array for no objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteJSON(&buf, nil)).Should(Succeed())
+
+		g.Expect(buf.String()).Should(Equal("[]\n"))
+	})
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	t.Run("writes one object per line", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteNDJSON(&buf, objects)).Should(Succeed())
+
+		var kinds []string
+
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var obj map[string]any
+			g.Expect(json.Unmarshal(scanner.Bytes(), &obj)).Should(Succeed())
+
+			kinds = append(kinds, obj["kind"].(string))
+		}
+
+		g.Expect(kinds).Should(Equal([]string{"Namespace", "Deployment", "Service"}))
+	})
+}
@@ -0,0 +1,184 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/diff"
+)
+
+// PatchAction is the action a PatchBundleEntry requires.
+type PatchAction string
+
+const (
+	// PatchActionCreate creates an object only present in the new render.
+	PatchActionCreate PatchAction = "create"
+
+	// PatchActionDelete deletes an object only present in the old render.
+	PatchActionDelete PatchAction = "delete"
+
+	// PatchActionPatch patches an object present in both renders.
+	PatchActionPatch PatchAction = "patch"
+)
+
+// pointerEscaper escapes JSON pointer reference tokens (RFC 6901).
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// PatchOperation is an RFC 6902 JSON patch operation.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// PatchBundleEntry is the change of a single object between two renders.
+type PatchBundleEntry struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Namespace  string      `json:"namespace,omitempty"`
+	Name       string      `json:"name"`
+	Action     PatchAction `json:"action"`
+
+	// Object is the object to create; set for PatchActionCreate only.
+	Object map[string]any `json:"object,omitempty"`
+
+	// Patch transforms the old object into the new one; set for
+	// PatchActionPatch only.
+	Patch []PatchOperation `json:"patch,omitempty"`
+}
+
+// PatchBundleDocument lists the changes between two renders, ordered by
+// resource key.
+type PatchBundleDocument struct {
+	Entries []PatchBundleEntry `json:"entries"`
+}
+
+// PatchBundle compares two renders and returns, for each object that differs,
+// an RFC 6902 patch or a create or delete marker, for tools that apply patches
+// rather than full manifests. Objects are matched as in diff.Objects. Lists
+// of equal length are patched element by element; lists whose length changed
+// are replaced as a whole.
+func PatchBundle(before []unstructured.Unstructured, after []unstructured.Unstructured) (PatchBundleDocument, error) {
+	result, err := diff.Objects(before, after)
+	if err != nil {
+		return PatchBundleDocument{}, err
+	}
+
+	doc := PatchBundleDocument{
+		Entries: make([]PatchBundleEntry, 0, len(result.Changes)),
+	}
+
+	for _, c := range result.Changes {
+		obj := c.After
+		if obj == nil {
+			obj = c.Before
+		}
+
+		entry := PatchBundleEntry{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		}
+
+		switch c.Type {
+		case diff.Added:
+			entry.Action = PatchActionCreate
+			entry.Object = c.After.Object
+		case diff.Removed:
+			entry.Action = PatchActionDelete
+		case diff.Changed:
+			entry.Action = PatchActionPatch
+
+			entry.Patch, err = patchOperations("", c.Before.Object, c.After.Object, nil)
+			if err != nil {
+				return PatchBundleDocument{}, fmt.Errorf("%s: %w", c.Key, err)
+			}
+		}
+
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return doc, nil
+}
+
+// patchOperations appends to ops the operations transforming a into b, the
+// values at the JSON pointer ptr.
+func patchOperations(ptr string, a any, b any, ops []PatchOperation) ([]PatchOperation, error) {
+	var err error
+
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			return appendOperation(ops, "replace", ptr, b)
+		}
+
+		keys := slices.Collect(maps.Keys(av))
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			child := ptr + "/" + pointerEscaper.Replace(k)
+
+			aValue, inA := av[k]
+			bValue, inB := bv[k]
+
+			switch {
+			case !inB:
+				ops = append(ops, PatchOperation{Op: "remove", Path: child})
+			case !inA:
+				ops, err = appendOperation(ops, "add", child, bValue)
+			default:
+				ops, err = patchOperations(child, aValue, bValue, ops)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return ops, nil
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return appendOperation(ops, "replace", ptr, b)
+		}
+
+		for i := range av {
+			ops, err = patchOperations(ptr+"/"+strconv.Itoa(i), av[i], bv[i], ops)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return ops, nil
+	default:
+		if reflect.DeepEqual(a, b) {
+			return ops, nil
+		}
+
+		return appendOperation(ops, "replace", ptr, b)
+	}
+}
+
+func appendOperation(ops []PatchOperation, op string, ptr string, value any) ([]PatchOperation, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode value at %s: %w", ptr, err)
+	}
+
+	return append(ops, PatchOperation{Op: op, Path: ptr, Value: data}), nil
+}
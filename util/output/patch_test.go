@@ -0,0 +1,168 @@
+package output_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/diff"
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testPatchBeforeYAML = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: apps
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+  annotations:
+    example.com/owner: team-a
+    obsolete: "true"
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: web
+          image: web:1.0.0
+          args: ["--verbose"]
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: legacy
+  namespace: apps
+`
+
+const testPatchAfterYAML = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: apps
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+  annotations:
+    example.com/owner: team-b
+spec:
+  replicas: 3
+  paused: false
+  template:
+    spec:
+      containers:
+        - name: web
+          image: web:1.1.0
+          args: ["--verbose", "--trace"]
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: apps
+`
+
+func TestPatchBundle(t *testing.T) {
+	t.Run("should emit patches and create and delete markers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		bundle, err := output.PatchBundle(
+			decodeObjects(g, testPatchBeforeYAML),
+			decodeObjects(g, testPatchAfterYAML),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(bundle.Entries).Should(HaveLen(3))
+
+		g.Expect(bundle.Entries[0].Kind).Should(Equal("Deployment"))
+		g.Expect(bundle.Entries[0].Action).Should(Equal(output.PatchActionPatch))
+		g.Expect(bundle.Entries[0].Object).Should(BeNil())
+
+		g.Expect(bundle.Entries[1].Kind).Should(Equal("ConfigMap"))
+		g.Expect(bundle.Entries[1].Action).Should(Equal(output.PatchActionDelete))
+		g.Expect(bundle.Entries[1].Object).Should(BeNil())
+		g.Expect(bundle.Entries[1].Patch).Should(BeEmpty())
+
+		g.Expect(bundle.Entries[2].Kind).Should(Equal("Service"))
+		g.Expect(bundle.Entries[2].APIVersion).Should(Equal("v1"))
+		g.Expect(bundle.Entries[2].Namespace).Should(Equal("apps"))
+		g.Expect(bundle.Entries[2].Action).Should(Equal(output.PatchActionCreate))
+		g.Expect(bundle.Entries[2].Object).Should(HaveKeyWithValue("kind", "Service"))
+	})
+
+	t.Run("should produce minimal RFC 6902 operations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		bundle, err := output.PatchBundle(
+			decodeObjects(g, testPatchBeforeYAML),
+			decodeObjects(g, testPatchAfterYAML),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(bundle.Entries[0].Patch).Should(Equal([]output.PatchOperation{
+			{Op: "replace", Path: "/metadata/annotations/example.com~1owner", Value: json.RawMessage(`"team-b"`)},
+			{Op: "remove", Path: "/metadata/annotations/obsolete"},
+			{Op: "add", Path: "/spec/paused", Value: json.RawMessage(`false`)},
+			{Op: "replace", Path: "/spec/replicas", Value: json.RawMessage(`3`)},
+			{Op: "replace", Path: "/spec/template/spec/containers/0/args", Value: json.RawMessage(`["--verbose","--trace"]`)},
+			{Op: "replace", Path: "/spec/template/spec/containers/0/image", Value: json.RawMessage(`"web:1.1.0"`)},
+		}))
+	})
+
+	t.Run("should produce patches transforming the old objects into the new ones", func(t *testing.T) {
+		g := NewWithT(t)
+
+		before := decodeObjects(g, testPatchBeforeYAML)
+		after := decodeObjects(g, testPatchAfterYAML)
+
+		bundle, err := output.PatchBundle(before, after)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		ops, err := json.Marshal(bundle.Entries[0].Patch)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		patch, err := jsonpatch.DecodePatch(ops)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		doc, err := json.Marshal(before[1].Object)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		patched, err := patch.Apply(doc)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result := unstructured.Unstructured{}
+		g.Expect(result.UnmarshalJSON(patched)).Should(Succeed())
+		g.Expect(result.Object).Should(Equal(after[1].Object))
+	})
+
+	t.Run("should return an empty bundle for identical renders", func(t *testing.T) {
+		g := NewWithT(t)
+
+		bundle, err := output.PatchBundle(
+			decodeObjects(g, testPatchBeforeYAML),
+			decodeObjects(g, testPatchBeforeYAML),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(bundle.Entries).Should(BeEmpty())
+	})
+
+	t.Run("should fail on duplicate objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testPatchBeforeYAML)
+
+		_, err := output.PatchBundle(append(objects, objects[0]), objects)
+		g.Expect(err).Should(MatchError(diff.ErrDuplicateObject))
+	})
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"cmp"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// Orphans returns the entries of the inventory whose objects are missing from
+// the current render, i.e. the objects to delete after applying it, in
+// deletion order: the reverse of the apply order (see k8s.ApplyPriority), so
+// that custom resources go before their CustomResourceDefinitions and
+// workloads before their Namespaces. Ties are ordered by resource key.
+//
+// Objects are matched by resource key, so an object moved to another version
+// of its API group is not an orphan. Deleting the orphans is left to the
+// caller, which owns the cluster client; listing them without deleting is a
+// dry-run.
+func (d InventoryDocument) Orphans(current []unstructured.Unstructured, opts ...PruneOption) []InventoryEntry {
+	options := PruneOptions{}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	rendered := make(map[k8s.ResourceKey]bool, len(current))
+	for i := range current {
+		rendered[k8s.KeyOf(&current[i])] = true
+	}
+
+	var orphans []InventoryEntry
+
+	for _, e := range d.Entries {
+		if rendered[e.Key()] || slices.Contains(options.Exclude, schema.GroupKind{Group: e.Group, Kind: e.Kind}) {
+			continue
+		}
+
+		orphans = append(orphans, e)
+	}
+
+	slices.SortStableFunc(orphans, func(a InventoryEntry, b InventoryEntry) int {
+		return cmp.Or(
+			cmp.Compare(k8s.ApplyPriority(b.Kind), k8s.ApplyPriority(a.Kind)),
+			cmp.Compare(a.Key().String(), b.Key().String()),
+		)
+	})
+
+	return orphans
+}
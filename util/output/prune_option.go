@@ -0,0 +1,30 @@
+package output
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// PruneOption is a generic option for InventoryDocument.Orphans.
+type PruneOption = util.Option[PruneOptions]
+
+// PruneOptions is a struct-based option that can set multiple pruning options at once.
+type PruneOptions struct {
+	// Exclude lists the kinds never reported as orphans, whatever their
+	// version.
+	Exclude []schema.GroupKind
+}
+
+// ApplyTo applies the pruning options to the target configuration.
+func (opts PruneOptions) ApplyTo(target *PruneOptions) {
+	target.Exclude = append(target.Exclude, opts.Exclude...)
+}
+
+// WithPruneExclusions keeps objects of the given kinds out of the orphans,
+// e.g. Namespaces or PersistentVolumeClaims whose deletion loses data.
+func WithPruneExclusions(kinds ...schema.GroupKind) PruneOption {
+	return util.FunctionalOption[PruneOptions](func(opts *PruneOptions) {
+		opts.Exclude = append(opts.Exclude, kinds...)
+	})
+}
@@ -0,0 +1,93 @@
+package output_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testPreviousYAML = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: legacy
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+  namespace: legacy
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+  namespace: legacy
+---
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+  namespace: legacy
+`
+
+func TestOrphans(t *testing.T) {
+	t.Run("should list removed objects in deletion order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		inventory := output.Inventory(decodeObjects(g, testPreviousYAML))
+		orphans := inventory.Orphans(decodeObjects(g, testOutputYAML))
+
+		names := make([]string, 0, len(orphans))
+		for _, e := range orphans {
+			names = append(names, e.Kind+"/"+e.Name)
+		}
+
+		g.Expect(names).Should(Equal([]string{
+			"Widget/gadget",
+			"Deployment/worker",
+			"PersistentVolumeClaim/data",
+			"CustomResourceDefinition/widgets.example.com",
+			"Namespace/legacy",
+		}))
+	})
+
+	t.Run("should keep excluded kinds", func(t *testing.T) {
+		g := NewWithT(t)
+
+		inventory := output.Inventory(decodeObjects(g, testPreviousYAML))
+		orphans := inventory.Orphans(decodeObjects(g, testOutputYAML), output.WithPruneExclusions(
+			schema.GroupKind{Kind: "Namespace"},
+			schema.GroupKind{Kind: "PersistentVolumeClaim"},
+		))
+
+		g.Expect(orphans).Should(HaveExactElements(
+			HaveField("Kind", "Widget"),
+			HaveField("Kind", "Deployment"),
+			HaveField("Kind", "CustomResourceDefinition"),
+		))
+	})
+
+	t.Run("should report no orphans for an unchanged render", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+
+		g.Expect(output.Inventory(objects).Orphans(objects)).Should(BeEmpty())
+	})
+}
@@ -0,0 +1,342 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// unsetValue stands for a value missing on one side of a values diff.
+const unsetValue = "(unset)"
+
+// clusterScope stands for cluster-scoped objects in per-namespace counts.
+const clusterScope = "(cluster)"
+
+// ReportInput is the data of a render report.
+type ReportInput struct {
+	// Title names the render, e.g. the component or pull request.
+	Title string
+
+	// Objects are the rendered objects.
+	Objects []unstructured.Unstructured
+
+	// Values and Defaults are the effective and default values of the
+	// render; the report lists the values differing from the defaults.
+	Values   map[string]any
+	Defaults map[string]any
+
+	// Findings are the validation findings of the render.
+	Findings []ReportFinding
+
+	// Provenance describes how the render was produced, such as tool and
+	// chart versions.
+	Provenance map[string]string
+}
+
+// ReportFinding is a validation finding listed in a report.
+type ReportFinding struct {
+	Severity string
+	Object   string
+	Message  string
+}
+
+// ReportCount is the number of objects of a kind or in a namespace.
+type ReportCount struct {
+	Name  string
+	Count int
+}
+
+// ReportValue is a value differing from its default, rendered as JSON.
+type ReportValue struct {
+	Path    string
+	Default string
+	Value   string
+}
+
+// ReportEntry is a key/value pair of the provenance.
+type ReportEntry struct {
+	Key   string
+	Value string
+}
+
+// RenderReport is a human-readable summary of a render, written as Markdown
+// or HTML, e.g. to attach to pull requests.
+type RenderReport struct {
+	Title       string
+	Total       int
+	ByKind      []ReportCount
+	ByNamespace []ReportCount
+	Images      []string
+	Values      []ReportValue
+	Findings    []ReportFinding
+	Provenance  []ReportEntry
+}
+
+// Report summarizes a render: object counts per kind and namespace, the
+// container images (see ImageReport), the values differing from their
+// defaults, the validation findings and the provenance. Everything is sorted,
+// so the same render always produces the same report.
+func Report(input ReportInput) RenderReport {
+	report := RenderReport{
+		Title:    input.Title,
+		Total:    len(input.Objects),
+		Images:   ImageReport(input.Objects).Images,
+		Values:   valueChanges(input.Defaults, input.Values),
+		Findings: input.Findings,
+	}
+
+	kinds := make(map[string]int)
+	namespaces := make(map[string]int)
+
+	for i := range input.Objects {
+		kinds[input.Objects[i].GetKind()]++
+
+		namespace := input.Objects[i].GetNamespace()
+		if namespace == "" {
+			namespace = clusterScope
+		}
+
+		namespaces[namespace]++
+	}
+
+	report.ByKind = reportCounts(kinds)
+	report.ByNamespace = reportCounts(namespaces)
+
+	for _, k := range slices.Sorted(maps.Keys(input.Provenance)) {
+		report.Provenance = append(report.Provenance, ReportEntry{Key: k, Value: input.Provenance[k]})
+	}
+
+	return report
+}
+
+// WriteMarkdown writes the report to w as GitHub-flavored Markdown.
+func (r RenderReport) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	title := "Render report"
+	if r.Title != "" {
+		title += ": " + r.Title
+	}
+
+	fmt.Fprintf(&b, "# %s\n\n", markdownCell(title))
+	fmt.Fprintf(&b, "Objects: %d, images: %d, findings: %d.\n", r.Total, len(r.Images), len(r.Findings))
+
+	writeMarkdownCounts(&b, "Objects by kind", "Kind", r.ByKind)
+	writeMarkdownCounts(&b, "Objects by namespace", "Namespace", r.ByNamespace)
+
+	if len(r.Images) > 0 {
+		b.WriteString("\n## Images\n\n")
+
+		for _, image := range r.Images {
+			fmt.Fprintf(&b, "- `%s`\n", image)
+		}
+	}
+
+	if len(r.Values) > 0 {
+		b.WriteString("\n## Values\n\n| Path | Default | Value |\n|------|---------|-------|\n")
+
+		for _, v := range r.Values {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", v.Path, markdownCell(v.Default), markdownCell(v.Value))
+		}
+	}
+
+	if len(r.Findings) > 0 {
+		b.WriteString("\n## Findings\n\n| Severity | Object | Message |\n|----------|--------|---------|\n")
+
+		for _, f := range r.Findings {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", markdownCell(f.Severity), markdownCell(f.Object), markdownCell(f.Message))
+		}
+	}
+
+	if len(r.Provenance) > 0 {
+		b.WriteString("\n## Provenance\n\n| Key | Value |\n|-----|-------|\n")
+
+		for _, e := range r.Provenance {
+			fmt.Fprintf(&b, "| %s | %s |\n", markdownCell(e.Key), markdownCell(e.Value))
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("unable to write report: %w", err)
+	}
+
+	return nil
+}
+
+// WriteHTML writes the report to w as a standalone HTML document.
+func (r RenderReport) WriteHTML(w io.Writer) error {
+	if err := reportHTMLTemplate.Execute(w, r); err != nil {
+		return fmt.Errorf("unable to write report: %w", err)
+	}
+
+	return nil
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Render report{{if .Title}}: {{.Title}}{{end}}</title>
+</head>
+<body>
+<h1>Render report{{if .Title}}: {{.Title}}{{end}}</h1>
+<p>Objects: {{.Total}}, images: {{len .Images}}, findings: {{len .Findings}}.</p>
+{{- if .ByKind}}
+<h2>Objects by kind</h2>
+<table>
+<tr><th>Kind</th><th>Count</th></tr>
+{{- range .ByKind}}
+<tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .ByNamespace}}
+<h2>Objects by namespace</h2>
+<table>
+<tr><th>Namespace</th><th>Count</th></tr>
+{{- range .ByNamespace}}
+<tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Images}}
+<h2>Images</h2>
+<ul>
+{{- range .Images}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Values}}
+<h2>Values</h2>
+<table>
+<tr><th>Path</th><th>Default</th><th>Value</th></tr>
+{{- range .Values}}
+<tr><td><code>{{.Path}}</code></td><td>{{.Default}}</td><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Findings}}
+<h2>Findings</h2>
+<table>
+<tr><th>Severity</th><th>Object</th><th>Message</th></tr>
+{{- range .Findings}}
+<tr><td>{{.Severity}}</td><td>{{.Object}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Provenance}}
+<h2>Provenance</h2>
+<table>
+<tr><th>Key</th><th>Value</th></tr>
+{{- range .Provenance}}
+<tr><td>{{.Key}}</td><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+func writeMarkdownCounts(b *strings.Builder, title string, column string, counts []ReportCount) {
+	if len(counts) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "\n## %s\n\n| %s | Count |\n|%s|------:|\n", title, column, strings.Repeat("-", len(column)+2))
+
+	for _, c := range counts {
+		fmt.Fprintf(b, "| %s | %d |\n", markdownCell(c.Name), c.Count)
+	}
+}
+
+// markdownCell escapes s for use in a Markdown table cell.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(s)
+}
+
+func reportCounts(counts map[string]int) []ReportCount {
+	result := make([]ReportCount, 0, len(counts))
+	for _, name := range slices.Sorted(maps.Keys(counts)) {
+		result = append(result, ReportCount{Name: name, Count: counts[name]})
+	}
+
+	return result
+}
+
+// valueChanges returns the leaf values of values differing from defaults,
+// sorted by path.
+func valueChanges(defaults map[string]any, values map[string]any) []ReportValue {
+	before := make(map[string]any)
+	flattenValues("", defaults, before)
+
+	after := make(map[string]any)
+	flattenValues("", values, after)
+
+	paths := slices.Collect(maps.Keys(before))
+	for p := range after {
+		if _, ok := before[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+
+	slices.Sort(paths)
+
+	var result []ReportValue
+
+	for _, p := range paths {
+		b, inBefore := before[p]
+		a, inAfter := after[p]
+
+		if inBefore && inAfter && reflect.DeepEqual(a, b) {
+			continue
+		}
+
+		result = append(result, ReportValue{
+			Path:    p,
+			Default: reportValue(b, inBefore),
+			Value:   reportValue(a, inAfter),
+		})
+	}
+
+	return result
+}
+
+// flattenValues records the leaves of value in out by dotted path; lists and
+// empty maps are leaves.
+func flattenValues(prefix string, value map[string]any, out map[string]any) {
+	for k, v := range value {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		if m, ok := v.(map[string]any); ok && len(m) > 0 {
+			flattenValues(path, m, out)
+
+			continue
+		}
+
+		out[path] = v
+	}
+}
+
+func reportValue(v any, ok bool) string {
+	if !ok {
+		return unsetValue
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,128 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testReportMarkdown = "# Render report: web\n" +
+	"\n" +
+	"Objects: 3, images: 0, findings: 1.\n" +
+	"\n" +
+	"## Objects by kind\n" +
+	"\n" +
+	"| Kind | Count |\n" +
+	"|------|------:|\n" +
+	"| Deployment | 1 |\n" +
+	"| Namespace | 1 |\n" +
+	"| Service | 1 |\n" +
+	"\n" +
+	"## Objects by namespace\n" +
+	"\n" +
+	"| Namespace | Count |\n" +
+	"|-----------|------:|\n" +
+	"| (cluster) | 1 |\n" +
+	"| apps | 2 |\n" +
+	"\n" +
+	"## Values\n" +
+	"\n" +
+	"| Path | Default | Value |\n" +
+	"|------|---------|-------|\n" +
+	"| `debug` | (unset) | true |\n" +
+	"| `image.tag` | \"1.0\" | \"1.1\" |\n" +
+	"| `legacy` | \"on\" | (unset) |\n" +
+	"\n" +
+	"## Findings\n" +
+	"\n" +
+	"| Severity | Object | Message |\n" +
+	"|----------|--------|---------|\n" +
+	"| warning | apps/Deployment/apps/web | no resource limits \\| requests |\n" +
+	"\n" +
+	"## Provenance\n" +
+	"\n" +
+	"| Key | Value |\n" +
+	"|-----|-------|\n" +
+	"| chart | web-1.2.3 |\n" +
+	"| tool | platform-render 1.4.0 |\n"
+
+func testReportInput(g *WithT) output.ReportInput {
+	return output.ReportInput{
+		Title:   "web",
+		Objects: decodeObjects(g, testOutputYAML),
+		Defaults: map[string]any{
+			"replicas": int64(2),
+			"image":    map[string]any{"repository": "web", "tag": "1.0"},
+			"legacy":   "on",
+		},
+		Values: map[string]any{
+			"replicas": int64(2),
+			"image":    map[string]any{"repository": "web", "tag": "1.1"},
+			"debug":    true,
+		},
+		Findings: []output.ReportFinding{
+			{Severity: "warning", Object: "apps/Deployment/apps/web", Message: "no resource limits | requests"},
+		},
+		Provenance: map[string]string{
+			"tool":  "platform-render 1.4.0",
+			"chart": "web-1.2.3",
+		},
+	}
+}
+
+func TestReport(t *testing.T) {
+	t.Run("should summarize the render", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := output.Report(testReportInput(g))
+
+		g.Expect(report.Total).Should(Equal(3))
+		g.Expect(report.ByKind).Should(Equal([]output.ReportCount{
+			{Name: "Deployment", Count: 1},
+			{Name: "Namespace", Count: 1},
+			{Name: "Service", Count: 1},
+		}))
+		g.Expect(report.Values).Should(Equal([]output.ReportValue{
+			{Path: "debug", Default: "(unset)", Value: "true"},
+			{Path: "image.tag", Default: `"1.0"`, Value: `"1.1"`},
+			{Path: "legacy", Default: `"on"`, Value: "(unset)"},
+		}))
+	})
+
+	t.Run("should list images", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := output.Report(output.ReportInput{Objects: decodeObjects(g, testImagesYAML)})
+
+		g.Expect(report.Images).Should(HaveLen(3))
+	})
+
+	t.Run("should write Markdown", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(output.Report(testReportInput(g)).WriteMarkdown(&buf)).Should(Succeed())
+
+		g.Expect(buf.String()).Should(Equal(testReportMarkdown))
+	})
+
+	t.Run("should write escaped HTML", func(t *testing.T) {
+		g := NewWithT(t)
+
+		input := testReportInput(g)
+		input.Title = "<web>"
+
+		var buf bytes.Buffer
+		g.Expect(output.Report(input).WriteHTML(&buf)).Should(Succeed())
+
+		html := buf.String()
+		g.Expect(html).Should(HavePrefix("<!DOCTYPE html>\n"))
+		g.Expect(html).Should(ContainSubstring("<h1>Render report: &lt;web&gt;</h1>"))
+		g.Expect(html).Should(ContainSubstring("<tr><td>Deployment</td><td>1</td></tr>"))
+		g.Expect(html).Should(ContainSubstring("<tr><td><code>image.tag</code></td><td>&#34;1.0&#34;</td><td>&#34;1.1&#34;</td></tr>"))
+		g.Expect(html).Should(HaveSuffix("</html>\n"))
+	})
+}
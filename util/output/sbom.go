@@ -0,0 +1,203 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// CycloneDXSpecVersion is the CycloneDX specification version written by
+	// WriteCycloneDX.
+	CycloneDXSpecVersion = "1.5"
+
+	// ComponentTypeApplication is the CycloneDX type of charts and other
+	// packaged applications.
+	ComponentTypeApplication = "application"
+
+	// ComponentTypeContainer is the CycloneDX type of container images.
+	ComponentTypeContainer = "container"
+
+	// sha256Prefix prefixes SHA-256 digests.
+	sha256Prefix = "sha256:"
+)
+
+// SBOMComponent is a component of a render, such as a chart or an image.
+type SBOMComponent struct {
+	// Type is the CycloneDX component type; defaults to
+	// ComponentTypeApplication.
+	Type string
+
+	// Name and Version identify the component.
+	Name    string
+	Version string
+
+	// Digest is the content digest of the component, e.g. "sha256:...".
+	Digest string
+
+	// PURL is the package URL of the component, if known.
+	PURL string
+}
+
+type cycloneDXDocument struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Metadata    cycloneDXMetadata    `json:"metadata"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string          `json:"timestamp,omitempty"`
+	Tools     *cycloneDXTools `json:"tools,omitempty"`
+}
+
+type cycloneDXTools struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	BOMRef  string          `json:"bom-ref,omitempty"`
+	Type    string          `json:"type"`
+	Name    string          `json:"name"`
+	Version string          `json:"version,omitempty"`
+	Hashes  []cycloneDXHash `json:"hashes,omitempty"`
+	PURL    string          `json:"purl,omitempty"`
+}
+
+type cycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+// WriteCycloneDX writes a CycloneDX JSON document describing the render to w:
+// the container images referenced by objects (see ImageReport), the
+// components added with WithSBOMComponent, such as charts with their versions
+// and digests, and the generating tool. Components are sorted and no random
+// serial number is generated, so the same render produces the same document.
+func WriteCycloneDX(w io.Writer, objects []unstructured.Unstructured, opts ...SBOMOption) error {
+	options := SBOMOptions{}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	doc := cycloneDXDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: CycloneDXSpecVersion,
+		Version:     1,
+		Components:  make([]cycloneDXComponent, 0),
+	}
+
+	if !options.Timestamp.IsZero() {
+		doc.Metadata.Timestamp = options.Timestamp.UTC().Format(time.RFC3339)
+	}
+
+	if options.ToolName != "" {
+		doc.Metadata.Tools = &cycloneDXTools{
+			Components: []cycloneDXComponent{{
+				Type:    ComponentTypeApplication,
+				Name:    options.ToolName,
+				Version: options.ToolVersion,
+			}},
+		}
+	}
+
+	components := make([]SBOMComponent, 0, len(options.Components))
+	components = append(components, options.Components...)
+
+	for _, image := range ImageReport(objects).Images {
+		components = append(components, imageComponent(image))
+	}
+
+	for _, c := range components {
+		doc.Components = append(doc.Components, c.cycloneDX())
+	}
+
+	slices.SortStableFunc(doc.Components, func(a cycloneDXComponent, b cycloneDXComponent) int {
+		return strings.Compare(a.BOMRef, b.BOMRef)
+	})
+
+	doc.Components = slices.CompactFunc(doc.Components, func(a cycloneDXComponent, b cycloneDXComponent) bool {
+		return a.BOMRef == b.BOMRef
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("unable to encode CycloneDX document: %w", err)
+	}
+
+	return nil
+}
+
+func (c SBOMComponent) cycloneDX() cycloneDXComponent {
+	result := cycloneDXComponent{
+		Type:    c.Type,
+		Name:    c.Name,
+		Version: c.Version,
+		PURL:    c.PURL,
+	}
+
+	if result.Type == "" {
+		result.Type = ComponentTypeApplication
+	}
+
+	if hex, ok := strings.CutPrefix(c.Digest, sha256Prefix); ok {
+		result.Hashes = []cycloneDXHash{{Alg: "SHA-256", Content: hex}}
+	}
+
+	result.BOMRef = result.PURL
+	if result.BOMRef == "" {
+		result.BOMRef = result.Type + "/" + result.Name + "@" + result.Version
+	}
+
+	return result
+}
+
+// imageComponent describes an image reference such as
+// "registry.example.com/team/web:1.0@sha256:...".
+func imageComponent(image string) SBOMComponent {
+	repository, digest, _ := strings.Cut(image, "@")
+
+	tag := ""
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+
+	version := tag
+	if version == "" {
+		version = digest
+	}
+
+	// Package URL of an OCI artifact; see
+	// https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst#oci
+	query := url.Values{}
+	query.Set("repository_url", repository)
+
+	if tag != "" {
+		query.Set("tag", tag)
+	}
+
+	purl := "pkg:oci/" + path.Base(repository)
+	if digest != "" {
+		purl += "@" + url.QueryEscape(digest)
+	}
+
+	return SBOMComponent{
+		Type:    ComponentTypeContainer,
+		Name:    repository,
+		Version: version,
+		Digest:  digest,
+		PURL:    purl + "?" + query.Encode(),
+	}
+}
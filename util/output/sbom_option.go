@@ -0,0 +1,63 @@
+package output
+
+import (
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// SBOMOption is a generic option for WriteCycloneDX.
+type SBOMOption = util.Option[SBOMOptions]
+
+// SBOMOptions is a struct-based option that can set SBOM options.
+type SBOMOptions struct {
+	// ToolName and ToolVersion identify the tool that generated the render.
+	ToolName    string
+	ToolVersion string
+
+	// Components are additional components of the render, such as charts.
+	Components []SBOMComponent
+
+	// Timestamp is recorded as the creation time of the document; the zero
+	// value omits it, keeping the document reproducible.
+	Timestamp time.Time
+}
+
+// ApplyTo applies the SBOM options to the target configuration.
+func (opts SBOMOptions) ApplyTo(target *SBOMOptions) {
+	if opts.ToolName != "" {
+		target.ToolName = opts.ToolName
+	}
+
+	if opts.ToolVersion != "" {
+		target.ToolVersion = opts.ToolVersion
+	}
+
+	target.Components = append(target.Components, opts.Components...)
+
+	if !opts.Timestamp.IsZero() {
+		target.Timestamp = opts.Timestamp
+	}
+}
+
+// WithTool records the name and version of the tool that generated the render.
+func WithTool(name string, version string) SBOMOption {
+	return util.FunctionalOption[SBOMOptions](func(opts *SBOMOptions) {
+		opts.ToolName = name
+		opts.ToolVersion = version
+	})
+}
+
+// WithSBOMComponent adds a component, such as a chart, to the document.
+func WithSBOMComponent(component SBOMComponent) SBOMOption {
+	return util.FunctionalOption[SBOMOptions](func(opts *SBOMOptions) {
+		opts.Components = append(opts.Components, component)
+	})
+}
+
+// WithTimestamp records the creation time of the document.
+func WithTimestamp(timestamp time.Time) SBOMOption {
+	return util.FunctionalOption[SBOMOptions](func(opts *SBOMOptions) {
+		opts.Timestamp = timestamp
+	})
+}
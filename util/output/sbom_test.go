@@ -0,0 +1,122 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testSBOMImagesYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          image: registry.example.com/team/web:1.0.0@sha256:0123abcd
+        - name: proxy
+          image: envoyproxy/envoy:v1.30.0
+        - name: sidecar
+          image: envoyproxy/envoy:v1.30.0
+`
+
+type testCycloneDX struct {
+	BOMFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Metadata    struct {
+		Timestamp string `json:"timestamp"`
+		Tools     struct {
+			Components []testCycloneDXComponent `json:"components"`
+		} `json:"tools"`
+	} `json:"metadata"`
+	Components []testCycloneDXComponent `json:"components"`
+}
+
+type testCycloneDXComponent struct {
+	BOMRef  string `json:"bom-ref"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Hashes  []struct {
+		Alg     string `json:"alg"`
+		Content string `json:"content"`
+	} `json:"hashes"`
+	PURL string `json:"purl"`
+}
+
+func writeTestCycloneDX(g *WithT, opts ...output.SBOMOption) (string, testCycloneDX) {
+	var buf bytes.Buffer
+	g.Expect(output.WriteCycloneDX(&buf, decodeObjects(g, testSBOMImagesYAML), opts...)).Should(Succeed())
+
+	var doc testCycloneDX
+	g.Expect(json.Unmarshal(buf.Bytes(), &doc)).Should(Succeed())
+
+	return buf.String(), doc
+}
+
+func TestWriteCycloneDX(t *testing.T) {
+	t.Run("should describe images, components and the tool", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, doc := writeTestCycloneDX(g,
+			output.WithTool("platform-render", "1.4.0"),
+			output.WithSBOMComponent(output.SBOMComponent{
+				Name:    "podinfo",
+				Version: "6.5.0",
+				Digest:  "sha256:feedbeef",
+			}),
+		)
+
+		g.Expect(doc.BOMFormat).Should(Equal("CycloneDX"))
+		g.Expect(doc.SpecVersion).Should(Equal(output.CycloneDXSpecVersion))
+		g.Expect(doc.Metadata.Timestamp).Should(BeEmpty())
+		g.Expect(doc.Metadata.Tools.Components).Should(HaveLen(1))
+		g.Expect(doc.Metadata.Tools.Components[0].Name).Should(Equal("platform-render"))
+		g.Expect(doc.Metadata.Tools.Components[0].Version).Should(Equal("1.4.0"))
+
+		g.Expect(doc.Components).Should(HaveLen(3))
+
+		g.Expect(doc.Components[0].BOMRef).Should(Equal("application/podinfo@6.5.0"))
+		g.Expect(doc.Components[0].Type).Should(Equal(output.ComponentTypeApplication))
+		g.Expect(doc.Components[0].Hashes).Should(HaveLen(1))
+		g.Expect(doc.Components[0].Hashes[0].Alg).Should(Equal("SHA-256"))
+		g.Expect(doc.Components[0].Hashes[0].Content).Should(Equal("feedbeef"))
+
+		g.Expect(doc.Components[1].Type).Should(Equal(output.ComponentTypeContainer))
+		g.Expect(doc.Components[1].Name).Should(Equal("envoyproxy/envoy"))
+		g.Expect(doc.Components[1].Version).Should(Equal("v1.30.0"))
+		g.Expect(doc.Components[1].Hashes).Should(BeEmpty())
+		g.Expect(doc.Components[1].PURL).Should(Equal("pkg:oci/envoy?repository_url=envoyproxy%2Fenvoy&tag=v1.30.0"))
+
+		g.Expect(doc.Components[2].Name).Should(Equal("registry.example.com/team/web"))
+		g.Expect(doc.Components[2].Version).Should(Equal("1.0.0"))
+		g.Expect(doc.Components[2].Hashes[0].Content).Should(Equal("0123abcd"))
+		g.Expect(doc.Components[2].PURL).Should(HavePrefix("pkg:oci/web@sha256%3A0123abcd?"))
+	})
+
+	t.Run("should record the timestamp when set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, doc := writeTestCycloneDX(g, output.WithTimestamp(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+		g.Expect(doc.Metadata.Timestamp).Should(Equal("2026-01-02T03:04:05Z"))
+	})
+
+	t.Run("should produce identical documents for identical renders", func(t *testing.T) {
+		g := NewWithT(t)
+
+		first, _ := writeTestCycloneDX(g, output.WithTool("platform-render", "1.4.0"))
+		second, _ := writeTestCycloneDX(g, output.WithTool("platform-render", "1.4.0"))
+
+		g.Expect(first).Should(Equal(second))
+	})
+}
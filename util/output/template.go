@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	// ErrInvalidFileTemplate is returned when a file template cannot be parsed.
+	ErrInvalidFileTemplate = errors.New("invalid file template")
+
+	// ErrInvalidFilePath is returned when an object or a file template produces
+	// a path that is empty or leaves the output directory.
+	ErrInvalidFilePath = errors.New("invalid file path")
+)
+
+// fileTemplateFuncs are the functions available to file templates.
+var fileTemplateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"default": func(fallback string, value string) string {
+		if value == "" {
+			return fallback
+		}
+
+		return value
+	},
+}
+
+// FileTemplateData is the data passed to file templates.
+type FileTemplateData struct {
+	Group     string
+	Version   string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func parseFileTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("file").Funcs(fileTemplateFuncs).Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFileTemplate, err)
+	}
+
+	return t, nil
+}
+
+// templatePath renders the path of obj with t.
+func templatePath(t *template.Template, obj *unstructured.Unstructured) (string, error) {
+	gvk := obj.GroupVersionKind()
+
+	var buf bytes.Buffer
+
+	err := t.Execute(&buf, FileTemplateData{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidFileTemplate, err)
+	}
+
+	return cleanFilePath(buf.String())
+}
+
+// cleanFilePath returns p cleaned, rejecting paths that are empty, absolute or
+// leave the output directory.
+func cleanFilePath(p string) (string, error) {
+	clean := path.Clean(p)
+
+	if p == "" || strings.HasSuffix(p, "/") || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidFilePath, p)
+	}
+
+	return clean, nil
+}
@@ -0,0 +1,125 @@
+package output_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWithFileTemplate(t *testing.T) {
+	t.Run("should name files with the template", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		w, err := output.NewDirWriter(dir,
+			output.WithFileTemplate(`{{.Namespace | default "_cluster"}}/{{.Kind | lower}}/{{.Name}}.yaml`),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		g.Expect(listFiles(g, dir)).Should(ConsistOf(
+			filepath.Join("_cluster", "namespace", "apps.yaml"),
+			filepath.Join("apps", "deployment", "web.yaml"),
+			filepath.Join("apps", "service", "web.yaml"),
+		))
+	})
+
+	t.Run("should take precedence over the layout", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		w, err := output.NewDirWriter(dir,
+			output.WithLayout(output.LayoutSingleFile),
+			output.WithFileTemplate(`{{.Group | default "core"}}.{{.Kind}}.{{.Name}}.yaml`),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(w.Write(decodeObjects(g, testOutputYAML))).Should(Succeed())
+
+		g.Expect(listFiles(g, dir)).Should(ConsistOf(
+			"core.Namespace.apps.yaml",
+			"apps.Deployment.web.yaml",
+			"core.Service.web.yaml",
+		))
+	})
+
+	t.Run("should list templated files in the kustomization", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data, err := output.Archive(decodeObjects(g, testOutputYAML),
+			output.WithFileTemplate(`{{.Kind | upper}}-{{.Name}}.yaml`),
+			output.WithKustomization(output.Kustomization{}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, contents := readArchive(g, data)
+		g.Expect(contents[output.KustomizationFileName]).Should(ContainSubstring("  - DEPLOYMENT-web.yaml\n"))
+	})
+
+	t.Run("should fail when objects map to the same file", func(t *testing.T) {
+		g := NewWithT(t)
+
+		w, err := output.NewDirWriter(t.TempDir(), output.WithFileTemplate(`{{.Name}}.yaml`))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		err = w.Write(decodeObjects(g, testOutputYAML))
+		g.Expect(err).Should(MatchError(output.ErrFileCollision))
+		g.Expect(err).Should(MatchError(ContainSubstring("web.yaml")))
+	})
+
+	t.Run("should fail when a file is also a directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.Archive(decodeObjects(g, testOutputYAML),
+			output.WithFileTemplate(`{{if eq .Kind "Namespace"}}{{.Name}}{{else}}{{.Namespace}}/{{.Kind}}.yaml{{end}}`),
+		)
+		g.Expect(err).Should(MatchError(output.ErrFileCollision))
+	})
+
+	t.Run("should fail when objects map to the kustomization", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.Archive(decodeObjects(g, testOutputYAML)[:1],
+			output.WithFileTemplate(`kustomization.yaml`),
+			output.WithKustomization(output.Kustomization{}),
+		)
+		g.Expect(err).Should(MatchError(output.ErrFileCollision))
+	})
+
+	t.Run("should reject paths leaving the directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.Archive(decodeObjects(g, testOutputYAML),
+			output.WithFileTemplate(`../{{.Name}}.yaml`),
+		)
+		g.Expect(err).Should(MatchError(output.ErrInvalidFilePath))
+
+		_, err = output.Archive(decodeObjects(g, testOutputYAML),
+			output.WithFileTemplate(`/{{.Name}}.yaml`),
+		)
+		g.Expect(err).Should(MatchError(output.ErrInvalidFilePath))
+	})
+
+	t.Run("should reject empty paths", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.Archive(decodeObjects(g, testOutputYAML),
+			output.WithFileTemplate(`{{.Group}}`),
+		)
+		g.Expect(err).Should(MatchError(output.ErrInvalidFilePath))
+	})
+
+	t.Run("should reject invalid templates", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.NewDirWriter(t.TempDir(), output.WithFileTemplate(`{{.Name`))
+		g.Expect(err).Should(MatchError(output.ErrInvalidFileTemplate))
+
+		_, err = output.Archive(decodeObjects(g, testOutputYAML), output.WithFileTemplate(`{{.Missing}}`))
+		g.Expect(err).Should(MatchError(output.ErrInvalidFileTemplate))
+	})
+}
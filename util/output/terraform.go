@@ -0,0 +1,170 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// TerraformVariable is the variable set by the tfvars file written by
+// WriteTerraformVars.
+const TerraformVariable = "manifests"
+
+// ErrDuplicateAddress is returned when two objects map to the same Terraform
+// resource address.
+var ErrDuplicateAddress = errors.New("duplicate Terraform address")
+
+var (
+	// terraformNameInvalid matches characters not allowed in Terraform names.
+	terraformNameInvalid = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+	// terraformEscaper escapes template sequences in heredoc strings.
+	terraformEscaper = strings.NewReplacer("${", "$${", "%{", "%%{")
+)
+
+// TerraformAddress returns the Terraform resource name of obj, derived from its
+// k8s.ResourceKey as "<group>_<kind>_<namespace>_<name>" in lower case, with
+// characters not allowed in Terraform names replaced by underscores. The name
+// is stable across renders, so Terraform tracks each object across changes.
+func TerraformAddress(obj *unstructured.Unstructured) string {
+	key := k8s.KeyOf(obj)
+
+	group := key.Group
+	if group == "" {
+		group = "core"
+	}
+
+	parts := []string{group, key.Kind}
+	if key.Namespace != "" {
+		parts = append(parts, key.Namespace)
+	}
+
+	parts = append(parts, key.Name)
+
+	return terraformNameInvalid.ReplaceAllString(strings.ToLower(strings.Join(parts, "_")), "_")
+}
+
+// WriteTerraform writes objects to w as HCL kubernetes_manifest resources,
+// one per object, named with TerraformAddress. Each manifest is embedded as
+// canonical YAML decoded with yamldecode, which keeps the file readable and
+// diff-friendly.
+func WriteTerraform(w io.Writer, objects []unstructured.Unstructured) error {
+	addresses, err := terraformAddresses(objects)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	for i := range objects {
+		var manifest bytes.Buffer
+		if err := WriteYAML(&manifest, objects[i:i+1]); err != nil {
+			return err
+		}
+
+		content := terraformEscaper.Replace(manifest.String())
+		marker := heredocMarker(content)
+
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+
+		fmt.Fprintf(&buf, "resource \"kubernetes_manifest\" %s {\n", strconv.Quote(addresses[i]))
+		fmt.Fprintf(&buf, "  manifest = yamldecode(<<-%s\n", marker)
+
+		for line := range strings.SplitSeq(strings.TrimSuffix(content, "\n"), "\n") {
+			if line == "" {
+				buf.WriteByte('\n')
+
+				continue
+			}
+
+			buf.WriteString("    " + line + "\n")
+		}
+
+		fmt.Fprintf(&buf, "  %s\n  )\n}\n", marker)
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("unable to write Terraform configuration: %w", err)
+	}
+
+	return nil
+}
+
+// WriteTerraformVars writes objects to w as a tfvars JSON file setting the
+// TerraformVariable variable to a map from TerraformAddress to manifest, for
+// generic modules iterating over the map with for_each.
+func WriteTerraformVars(w io.Writer, objects []unstructured.Unstructured) error {
+	addresses, err := terraformAddresses(objects)
+	if err != nil {
+		return err
+	}
+
+	manifests := make(map[string]any, len(objects))
+	for i := range objects {
+		manifests[addresses[i]] = objects[i].Object
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(map[string]any{TerraformVariable: manifests}); err != nil {
+		return fmt.Errorf("unable to encode Terraform variables: %w", err)
+	}
+
+	return nil
+}
+
+func terraformAddresses(objects []unstructured.Unstructured) ([]string, error) {
+	addresses := make([]string, 0, len(objects))
+	owners := make(map[string]k8s.ResourceKey, len(objects))
+
+	for i := range objects {
+		address := TerraformAddress(&objects[i])
+		key := k8s.KeyOf(&objects[i])
+
+		if owner, ok := owners[address]; ok {
+			return nil, fmt.Errorf("%w: %s and %s both map to %s", ErrDuplicateAddress, owner, key, address)
+		}
+
+		owners[address] = key
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+// heredocMarker returns a heredoc delimiter that does not occur as a line of
+// content.
+func heredocMarker(content string) string {
+	marker := "EOT"
+
+	for i := 1; ; i++ {
+		found := false
+
+		for line := range strings.SplitSeq(content, "\n") {
+			if strings.TrimSpace(line) == marker {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return marker
+		}
+
+		marker = "EOT" + strconv.Itoa(i)
+	}
+}
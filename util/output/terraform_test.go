@@ -0,0 +1,130 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testTerraformYAML = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: apps
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web.config
+  namespace: apps
+data:
+  greeting: hello ${USER} %{if}
+  script: |
+    echo start
+
+    EOT
+`
+
+const testTerraformHCL = `resource "kubernetes_manifest" "core_namespace_apps" {
+  manifest = yamldecode(<<-EOT
+    apiVersion: v1
+    kind: Namespace
+    metadata:
+      name: apps
+  EOT
+  )
+}
+
+resource "kubernetes_manifest" "core_configmap_apps_web_config" {
+  manifest = yamldecode(<<-EOT1
+    apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: web.config
+      namespace: apps
+    data:
+      greeting: hello $${USER} %%{if}
+      script: |
+        echo start
+
+        EOT
+  EOT1
+  )
+}
+`
+
+const testTerraformDuplicateYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web_config
+  namespace: apps
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web.config
+  namespace: apps
+`
+
+func TestTerraformAddress(t *testing.T) {
+	t.Run("should derive addresses from resource keys", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+
+		g.Expect(output.TerraformAddress(&objects[0])).Should(Equal("core_namespace_apps"))
+		g.Expect(output.TerraformAddress(&objects[1])).Should(Equal("apps_deployment_apps_web"))
+		g.Expect(output.TerraformAddress(&objects[2])).Should(Equal("core_service_apps_web"))
+	})
+}
+
+func TestWriteTerraform(t *testing.T) {
+	t.Run("should write kubernetes_manifest resources", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteTerraform(&buf, decodeObjects(g, testTerraformYAML))).Should(Succeed())
+
+		g.Expect(buf.String()).Should(Equal(testTerraformHCL))
+	})
+
+	t.Run("should fail when objects map to the same address", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		err := output.WriteTerraform(&buf, decodeObjects(g, testTerraformDuplicateYAML))
+		g.Expect(err).Should(MatchError(output.ErrDuplicateAddress))
+		g.Expect(buf.Len()).Should(BeZero())
+	})
+}
+
+func TestWriteTerraformVars(t *testing.T) {
+	t.Run("should write manifests keyed by address", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteTerraformVars(&buf, objects)).Should(Succeed())
+
+		var vars map[string]map[string]map[string]any
+		g.Expect(json.Unmarshal(buf.Bytes(), &vars)).Should(Succeed())
+
+		g.Expect(vars).Should(HaveKey(output.TerraformVariable))
+		g.Expect(vars[output.TerraformVariable]).Should(HaveLen(3))
+		g.Expect(vars[output.TerraformVariable]["apps_deployment_apps_web"]).Should(HaveKeyWithValue("kind", "Deployment"))
+	})
+
+	t.Run("should fail when objects map to the same address", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		err := output.WriteTerraformVars(&buf, decodeObjects(g, testTerraformDuplicateYAML))
+		g.Expect(err).Should(MatchError(output.ErrDuplicateAddress))
+	})
+}
@@ -0,0 +1,217 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const (
+	yamlIndent = 2
+
+	// DefaultSeparator separates the documents written by WriteYAML.
+	DefaultSeparator = "---"
+)
+
+var (
+	// canonicalTopKeys are written first, in this order, at the top level of
+	// an object; other keys follow in sorted order.
+	canonicalTopKeys = []string{"apiVersion", "kind", "metadata"}
+
+	// canonicalMetadataKeys are written first, in this order, in metadata.
+	canonicalMetadataKeys = []string{"name", "generateName", "namespace", "labels", "annotations"}
+)
+
+// WriteYAML writes objects to w as a multi-document YAML stream, the
+// counterpart of k8s.DecodeYAML for emitting bundles.
+//
+// The output follows a diff-friendly canonical profile, so the same objects
+// always produce the same bytes and small changes produce small diffs:
+//
+//   - fields are written in canonical order: apiVersion, kind and metadata
+//     first (with name, namespace, labels and annotations leading metadata),
+//     every other map sorted by key;
+//   - nested blocks are indented by 2 spaces, sequences included;
+//   - scalars are quoted only when needed to keep their type, long strings
+//     are never wrapped and multi-line strings use literal blocks when they
+//     have no trailing spaces;
+//   - lines never end with whitespace, including header and comment lines.
+//
+// Example:
+//
+//	err := output.WriteYAML(os.Stdout, objects,
+//	    output.WithHeader("# Generated by platform-render, do not edit."),
+//	    output.WithApplyOrder(),
+//	)
+func WriteYAML(w io.Writer, objects []unstructured.Unstructured, opts ...YAMLOption) error {
+	options := YAMLOptions{
+		Separator: DefaultSeparator,
+	}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	if options.ApplyOrder {
+		objects = slices.Clone(objects)
+		k8s.SortForApply(objects)
+	}
+
+	var buf bytes.Buffer
+
+	if options.Header != "" {
+		for line := range strings.SplitSeq(strings.TrimSuffix(options.Header, "\n"), "\n") {
+			writeLine(&buf, strings.TrimRight(line, " \t"))
+		}
+	}
+
+	for i := range objects {
+		if i > 0 {
+			writeLine(&buf, options.Separator)
+		}
+
+		if options.Comment != nil {
+			if comment := options.Comment(&objects[i]); comment != "" {
+				for line := range strings.SplitSeq(comment, "\n") {
+					writeLine(&buf, strings.TrimRight("# "+line, " \t"))
+				}
+			}
+		}
+
+		if err := encodeDocument(&buf, objects[i].Object); err != nil {
+			return fmt.Errorf("unable to encode %s %s: %w", objects[i].GetKind(), objects[i].GetName(), err)
+		}
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("unable to write YAML: %w", err)
+	}
+
+	return nil
+}
+
+// FormatYAML rewrites a multi-document YAML stream of Kubernetes objects in
+// the canonical profile of WriteYAML, e.g. to normalize hand-written files
+// before committing them next to rendered output. Comments and documents
+// that are not Kubernetes objects are dropped. Formatting is idempotent.
+func FormatYAML(data []byte, opts ...YAMLOption) ([]byte, error) {
+	objects, err := k8s.DecodeYAML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, objects, opts...); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeLine(buf *bytes.Buffer, s string) {
+	buf.WriteString(s)
+
+	if !strings.HasSuffix(s, "\n") {
+		buf.WriteByte('\n')
+	}
+}
+
+func encodeDocument(buf *bytes.Buffer, obj map[string]any) error {
+	return encodeMap(buf, obj, canonicalTopKeys)
+}
+
+// encodeMap writes m as a YAML document, ordering its top-level keys with
+// leading first.
+func encodeMap(buf *bytes.Buffer, m map[string]any, leading []string) error {
+	node, err := toNode(m, leading)
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(yamlIndent)
+
+	if err := enc.Encode(node); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// toNode converts a value to a YAML node, ordering map keys with leading first.
+func toNode(value any, leading []string) (*yaml.Node, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+		for _, key := range orderedKeys(v, leading) {
+			// Object metadata, including that of pod templates, keeps its
+			// identifying fields first.
+			var childLeading []string
+			if key == "metadata" {
+				childLeading = canonicalMetadataKeys
+			}
+
+			child, err := toNode(v[key], childLeading)
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				child,
+			)
+		}
+
+		return node, nil
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+		for _, item := range v {
+			child, err := toNode(item, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content = append(node.Content, child)
+		}
+
+		return node, nil
+	default:
+		node := &yaml.Node{}
+		if err := node.Encode(v); err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	}
+}
+
+func orderedKeys(m map[string]any, leading []string) []string {
+	keys := make([]string, 0, len(m))
+
+	for _, key := range leading {
+		if _, ok := m[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+
+	rest := make([]string, 0, len(m))
+	for key := range m {
+		if !slices.Contains(leading, key) {
+			rest = append(rest, key)
+		}
+	}
+
+	slices.Sort(rest)
+
+	return append(keys, rest...)
+}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// YAMLOption is a generic option for WriteYAML.
+type YAMLOption = util.Option[YAMLOptions]
+
+// YAMLOptions is a struct-based option that can set multiple YAML writer options at once.
+type YAMLOptions struct {
+	// Separator is the line written between documents; defaults to "---".
+	Separator string
+
+	// Header is written once at the beginning of the stream.
+	Header string
+
+	// Comment returns the comment written before the document of an object,
+	// e.g. its source; empty comments are omitted.
+	Comment func(obj *unstructured.Unstructured) string
+
+	// ApplyOrder sorts the objects with k8s.SortForApply before writing them.
+	ApplyOrder bool
+}
+
+// ApplyTo applies the YAML writer options to the target configuration.
+func (opts YAMLOptions) ApplyTo(target *YAMLOptions) {
+	if opts.Separator != "" {
+		target.Separator = opts.Separator
+	}
+	if opts.Header != "" {
+		target.Header = opts.Header
+	}
+	if opts.Comment != nil {
+		target.Comment = opts.Comment
+	}
+	if opts.ApplyOrder {
+		target.ApplyOrder = true
+	}
+}
+
+// WithSeparator sets the line written between documents.
+func WithSeparator(separator string) YAMLOption {
+	return util.FunctionalOption[YAMLOptions](func(opts *YAMLOptions) {
+		opts.Separator = separator
+	})
+}
+
+// WithHeader sets the text written once at the beginning of the stream, such
+// as a "generated, do not edit" comment.
+func WithHeader(header string) YAMLOption {
+	return util.FunctionalOption[YAMLOptions](func(opts *YAMLOptions) {
+		opts.Header = header
+	})
+}
+
+// WithComment sets the function returning the comment written before each
+// document. Multi-line comments are supported.
+//
+// Example:
+//
+//	output.WithComment(func(obj *unstructured.Unstructured) string {
+//	    return "Source: " + obj.GetAnnotations()["example.com/source"]
+//	})
+func WithComment(fn func(obj *unstructured.Unstructured) string) YAMLOption {
+	return util.FunctionalOption[YAMLOptions](func(opts *YAMLOptions) {
+		opts.Comment = fn
+	})
+}
+
+// WithApplyOrder writes the objects in apply order (see k8s.SortForApply)
+// instead of the order they are given in.
+func WithApplyOrder() YAMLOption {
+	return util.FunctionalOption[YAMLOptions](func(opts *YAMLOptions) {
+		opts.ApplyOrder = true
+	})
+}
@@ -0,0 +1,217 @@
+package output_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/output"
+
+	. "github.com/onsi/gomega"
+)
+
+const testCanonicalYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+  labels:
+    app: web
+  annotations:
+    note: "yes"
+spec:
+  replicas: 2
+  template:
+    metadata:
+      name: ignored
+      labels:
+        app: web
+    spec:
+      containers:
+        - image: nginx:1.27
+          name: web
+          ports:
+            - containerPort: 8080
+status: {}
+`
+
+const testMessyYAML = `
+# hand-written
+kind: ConfigMap
+"apiVersion": "v1"
+data:
+    script: |
+        #!/bin/sh
+        echo hello
+    enabled: 'true'
+    long: "a rather long value that a formatter with a line width would wrap somewhere around here"
+metadata:
+    labels: {app: web}
+    name: 'config'
+`
+
+const testFormattedYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  labels:
+    app: web
+data:
+  enabled: "true"
+  long: a rather long value that a formatter with a line width would wrap somewhere around here
+  script: |
+    #!/bin/sh
+    echo hello
+`
+
+func testUnorderedDeployment() unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{
+							"ports": []any{map[string]any{"containerPort": int64(8080)}},
+							"name":  "web",
+							"image": "nginx:1.27",
+						},
+					},
+				},
+				"metadata": map[string]any{
+					"labels": map[string]any{"app": "web"},
+					"name":   "ignored",
+				},
+			},
+			"replicas": int64(2),
+		},
+		"metadata": map[string]any{
+			"annotations": map[string]any{"note": "yes"},
+			"labels":      map[string]any{"app": "web"},
+			"namespace":   "apps",
+			"name":        "web",
+		},
+		"kind":       "Deployment",
+		"apiVersion": "apps/v1",
+	}}
+}
+
+func TestWriteYAML(t *testing.T) {
+	t.Run("writes fields in canonical order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteYAML(&buf, []unstructured.Unstructured{testUnorderedDeployment()})).Should(Succeed())
+
+		g.Expect(buf.String()).Should(Equal(testCanonicalYAML))
+	})
+
+	t.Run("round trips through DecodeYAML", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteYAML(&buf, objects)).Should(Succeed())
+
+		decoded, err := k8s.DecodeYAML(buf.Bytes())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(decoded).Should(Equal(objects))
+	})
+
+	t.Run("writes header, comments and separators", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)[:2]
+
+		var buf bytes.Buffer
+		err := output.WriteYAML(&buf, objects,
+			output.WithHeader("# Generated, do not edit."),
+			output.WithSeparator("--- # next"),
+			output.WithComment(func(obj *unstructured.Unstructured) string {
+				return "Source: " + strings.ToLower(obj.GetKind())
+			}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(buf.String()).Should(HavePrefix("# Generated, do not edit.\n# Source: namespace\napiVersion: v1\n"))
+		g.Expect(buf.String()).Should(ContainSubstring("\n--- # next\n# Source: deployment\napiVersion: apps/v1\n"))
+	})
+
+	t.Run("never writes trailing whitespace", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := testUnorderedDeployment()
+		g.Expect(unstructured.SetNestedField(obj.Object, "trailing  \nspaces\n", "spec", "note")).Should(Succeed())
+
+		var buf bytes.Buffer
+		err := output.WriteYAML(&buf, []unstructured.Unstructured{obj},
+			output.WithHeader("# Generated  \n#\t\n"),
+			output.WithComment(func(_ *unstructured.Unstructured) string {
+				return "first \n\nthird"
+			}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		for line := range strings.SplitSeq(buf.String(), "\n") {
+			g.Expect(line).ShouldNot(MatchRegexp(`[ \t]$`))
+		}
+
+		g.Expect(buf.String()).Should(HavePrefix("# Generated\n#\n# first\n#\n# third\napiVersion: apps/v1\n"))
+	})
+
+	t.Run("sorts objects into apply order on request", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := decodeObjects(g, testOutputYAML)
+		reversed := []unstructured.Unstructured{objects[2], objects[1], objects[0]}
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteYAML(&buf, reversed, output.WithApplyOrder())).Should(Succeed())
+
+		decoded, err := k8s.DecodeYAML(buf.Bytes())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(names(decoded)).Should(Equal([]string{"Namespace/apps", "Service/web", "Deployment/web"}))
+		g.Expect(reversed[0].GetKind()).Should(Equal("Service"))
+	})
+
+	t.Run("writes nothing for no objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(output.WriteYAML(&buf, nil)).Should(Succeed())
+
+		g.Expect(buf.Len()).Should(BeZero())
+	})
+}
+
+func TestFormatYAML(t *testing.T) {
+	t.Run("rewrites objects in the canonical profile", func(t *testing.T) {
+		g := NewWithT(t)
+
+		formatted, err := output.FormatYAML([]byte(testMessyYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(formatted)).Should(Equal(testFormattedYAML))
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		g := NewWithT(t)
+
+		formatted, err := output.FormatYAML([]byte(testMessyYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		again, err := output.FormatYAML(formatted)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(again).Should(Equal(formatted))
+	})
+
+	t.Run("fails on invalid YAML", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := output.FormatYAML([]byte("kind: [unterminated"))
+		g.Expect(err).Should(HaveOccurred())
+	})
+}
@@ -0,0 +1,70 @@
+// Package process runs external binaries that exchange data over stdin and
+// stdout, such as exec plugins and KRM functions, with a sandboxed
+// environment and a bounded run time.
+package process
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+)
+
+// Output is the output of a process run.
+type Output struct {
+	Stdout []byte
+	Stderr []byte
+}
+
+// Run runs the binary at path with opts, writing stdin to the process and
+// returning its output. The process does not inherit the environment of the
+// caller unless InheritEnv is set: it only sees the variables listed in
+// EnvAllowlist and those set in Env, which override inherited ones. When the run is stopped by ctx or the
+// Timeout, the returned error is the context error.
+func Run(ctx context.Context, path string, stdin []byte, opts Options) (Output, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	//nolint:gosec // Running user-configured binaries is the purpose of this package.
+	cmd := exec.CommandContext(ctx, path, opts.Args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Dir = opts.WorkDir
+
+	cmd.Env = opts.environ()
+
+	err := cmd.Run()
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	return Output{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}, err
+}
+
+// environ returns the allowlisted variables of the current process, or all
+// of them with InheritEnv, followed by the explicitly configured ones. It
+// never returns nil, as a nil Env would make the process inherit the full
+// environment.
+func (opts Options) environ() []string {
+	if opts.InheritEnv {
+		return append(os.Environ(), opts.Env...)
+	}
+
+	env := make([]string, 0, len(opts.EnvAllowlist)+len(opts.Env))
+
+	for _, name := range opts.EnvAllowlist {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+
+	return append(env, opts.Env...)
+}
@@ -0,0 +1,111 @@
+package process
+
+import (
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// Options is a struct-based option that can set multiple process options at
+// once. Option structs of process-running types embed it, so that the With
+// functions of this package apply to them.
+type Options struct {
+	// Args are the arguments passed to the binary.
+	Args []string
+
+	// Env are environment variables in KEY=VALUE form set for the process.
+	Env []string
+
+	// EnvAllowlist names the variables of the calling process passed through
+	// to the process. All other variables are withheld.
+	EnvAllowlist []string
+
+	// InheritEnv passes the full environment of the calling process, e.g. to
+	// a trusted container runtime forwarding selected variables itself.
+	InheritEnv bool
+
+	// WorkDir is the working directory of the process.
+	WorkDir string
+
+	// Timeout bounds a single run; zero means no limit beyond the context
+	// passed to Run.
+	Timeout time.Duration
+}
+
+// ApplyTo applies the process options to the target configuration.
+func (opts Options) ApplyTo(target *Options) {
+	if len(opts.Args) > 0 {
+		target.Args = opts.Args
+	}
+
+	if len(opts.Env) > 0 {
+		target.Env = opts.Env
+	}
+
+	if len(opts.EnvAllowlist) > 0 {
+		target.EnvAllowlist = opts.EnvAllowlist
+	}
+
+	if opts.InheritEnv {
+		target.InheritEnv = opts.InheritEnv
+	}
+
+	if opts.WorkDir != "" {
+		target.WorkDir = opts.WorkDir
+	}
+
+	if opts.Timeout > 0 {
+		target.Timeout = opts.Timeout
+	}
+}
+
+// ProcessOptions returns opts; through embedding, it gives the With functions
+// of this package access to the process options of an option struct.
+func (opts *Options) ProcessOptions() *Options {
+	return opts
+}
+
+// Configurable is a pointer to an option struct embedding Options.
+type Configurable[T any] interface {
+	*T
+	ProcessOptions() *Options
+}
+
+// WithArgs adds arguments passed to the binary.
+func WithArgs[T any, P Configurable[T]](args ...string) util.Option[T] {
+	return util.FunctionalOption[T](func(opts *T) {
+		o := P(opts).ProcessOptions()
+		o.Args = append(o.Args, args...)
+	})
+}
+
+// WithEnv sets an environment variable for the process.
+func WithEnv[T any, P Configurable[T]](key string, value string) util.Option[T] {
+	return util.FunctionalOption[T](func(opts *T) {
+		o := P(opts).ProcessOptions()
+		o.Env = append(o.Env, key+"="+value)
+	})
+}
+
+// WithEnvAllowlist passes the named variables of the calling process through
+// to the process.
+func WithEnvAllowlist[T any, P Configurable[T]](names ...string) util.Option[T] {
+	return util.FunctionalOption[T](func(opts *T) {
+		o := P(opts).ProcessOptions()
+		o.EnvAllowlist = append(o.EnvAllowlist, names...)
+	})
+}
+
+// WithWorkDir sets the working directory of the process.
+func WithWorkDir[T any, P Configurable[T]](dir string) util.Option[T] {
+	return util.FunctionalOption[T](func(opts *T) {
+		P(opts).ProcessOptions().WorkDir = dir
+	})
+}
+
+// WithTimeout bounds the duration of a single run.
+func WithTimeout[T any, P Configurable[T]](timeout time.Duration) util.Option[T] {
+	return util.FunctionalOption[T](func(opts *T) {
+		P(opts).ProcessOptions().Timeout = timeout
+	})
+}
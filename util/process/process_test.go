@@ -0,0 +1,106 @@
+package process_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+	"github.com/k8s-manifest-kit/pkg/util/process"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testShell = "/bin/sh"
+
+	testEnvScript = `printf '%s' "${ALLOWED:-unset}-${DENIED:-unset}-${EXPLICIT:-unset}"`
+
+	testSleepScript = `while :; do :; done`
+)
+
+// testOptions is an option struct of a process-running type.
+type testOptions struct {
+	process.Options
+}
+
+func apply(opts ...util.Option[testOptions]) process.Options {
+	options := testOptions{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	return options.Options
+}
+
+func TestRun(t *testing.T) {
+	t.Run("writes stdin and returns the output", func(t *testing.T) {
+		g := NewWithT(t)
+
+		out, err := process.Run(t.Context(), testShell, []byte("hello"), apply(
+			process.WithArgs[testOptions]("-c", `read -r line; printf '%s' "$line"; printf 'done' >&2`),
+		))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(out.Stdout)).Should(Equal("hello"))
+		g.Expect(string(out.Stderr)).Should(Equal("done"))
+	})
+
+	t.Run("only exposes allowlisted and explicit variables", func(t *testing.T) {
+		g := NewWithT(t)
+
+		t.Setenv("ALLOWED", "yes")
+		t.Setenv("DENIED", "yes")
+
+		out, err := process.Run(t.Context(), testShell, nil, apply(
+			process.WithArgs[testOptions]("-c", testEnvScript),
+			process.WithEnvAllowlist[testOptions]("ALLOWED"),
+			process.WithEnv[testOptions]("EXPLICIT", "set"),
+		))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(out.Stdout)).Should(Equal("yes-unset-set"))
+	})
+
+	t.Run("inherits the environment when configured", func(t *testing.T) {
+		g := NewWithT(t)
+
+		t.Setenv("DENIED", "yes")
+
+		opts := apply(
+			process.WithArgs[testOptions]("-c", testEnvScript),
+			process.WithEnv[testOptions]("EXPLICIT", "set"),
+		)
+		opts.InheritEnv = true
+
+		out, err := process.Run(t.Context(), testShell, nil, opts)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(out.Stdout)).Should(Equal("unset-yes-set"))
+	})
+
+	t.Run("runs in the configured working directory", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+
+		out, err := process.Run(t.Context(), testShell, nil, apply(
+			process.WithArgs[testOptions]("-c", `printf '%s' "$PWD"`),
+			process.WithWorkDir[testOptions](dir),
+		))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(out.Stdout)).Should(Equal(dir))
+	})
+
+	t.Run("stops the process after the timeout", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := process.Run(t.Context(), testShell, nil, apply(
+			process.WithArgs[testOptions]("-c", testSleepScript),
+			process.WithTimeout[testOptions](50*time.Millisecond),
+		))
+
+		g.Expect(err).Should(MatchError(context.DeadlineExceeded))
+	})
+}
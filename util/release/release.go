@@ -0,0 +1,104 @@
+// Package release carries release information through the render pipeline.
+package release
+
+import (
+	"context"
+	"maps"
+	"strconv"
+)
+
+// Info describes the release being rendered. It is attached to the context
+// with WithInfo so that every renderer exposes the same values: Helm as
+// .Release, Go templates as part of their data, jsonnet as external variables.
+//
+// Example:
+//
+//	ctx := release.WithInfo(context.Background(), &release.Info{
+//		Name:      "web",
+//		Namespace: "apps",
+//		Revision:  2,
+//		Fields:    map[string]string{"cluster": "prod-eu"},
+//	})
+//	objects, err := engine.Render(ctx)
+//
+// A nil Info, as returned by FromContext without release information,
+// describes an install with empty fields, so that renderers can use the result
+// of FromContext directly.
+type Info struct {
+	// Name is the release name.
+	Name string
+
+	// Namespace is the namespace the release is installed into.
+	Namespace string
+
+	// Revision is the release revision, starting at 1 for an install.
+	Revision int
+
+	// Fields are additional caller-defined values.
+	Fields map[string]string
+}
+
+type contextKey struct{}
+
+// WithInfo returns a context with the release information attached.
+func WithInfo(ctx context.Context, info *Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext extracts the release information from context, or returns nil
+// if not present.
+//
+// This is primarily used internally by renderers.
+func FromContext(ctx context.Context) *Info {
+	if info, ok := ctx.Value(contextKey{}).(*Info); ok {
+		return info
+	}
+
+	return nil
+}
+
+// IsInstall reports whether the render is for a first install.
+func (i *Info) IsInstall() bool {
+	return i == nil || i.Revision <= 1
+}
+
+// TemplateData returns the release as template data, using the field names
+// of Helm's .Release object (Name, Namespace, Revision, IsInstall, IsUpgrade)
+// plus the custom fields under Fields.
+func (i *Info) TemplateData() map[string]any {
+	if i == nil {
+		i = &Info{}
+	}
+
+	fields := make(map[string]any, len(i.Fields))
+	for k, v := range i.Fields {
+		fields[k] = v
+	}
+
+	return map[string]any{
+		"Name":      i.Name,
+		"Namespace": i.Namespace,
+		"Revision":  i.Revision,
+		"IsInstall": i.IsInstall(),
+		"IsUpgrade": !i.IsInstall(),
+		"Fields":    fields,
+	}
+}
+
+// ExtVars returns the release as string variables, as used for jsonnet
+// external variables: releaseName, namespace and revision, plus the custom
+// fields. Custom fields never override the built-in names.
+func (i *Info) ExtVars() map[string]string {
+	if i == nil {
+		i = &Info{}
+	}
+
+	result := make(map[string]string, len(i.Fields)+3)
+	maps.Copy(result, i.Fields)
+
+	result["releaseName"] = i.Name
+	result["namespace"] = i.Namespace
+	result["revision"] = strconv.Itoa(i.Revision)
+
+	return result
+}
@@ -0,0 +1,98 @@
+package release_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/release"
+
+	. "github.com/onsi/gomega"
+)
+
+func testInfo() *release.Info {
+	return &release.Info{
+		Name:      "web",
+		Namespace: "apps",
+		Revision:  2,
+		Fields:    map[string]string{"cluster": "prod-eu", "namespace": "ignored"},
+	}
+}
+
+func TestInfoContext(t *testing.T) {
+	t.Run("stores and retrieves info from context", func(t *testing.T) {
+		g := NewWithT(t)
+
+		info := testInfo()
+		ctx := release.WithInfo(t.Context(), info)
+
+		g.Expect(release.FromContext(ctx)).Should(BeIdenticalTo(info))
+	})
+
+	t.Run("returns nil when info not in context", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(release.FromContext(t.Context())).Should(BeNil())
+	})
+}
+
+func TestInfoTemplateData(t *testing.T) {
+	t.Run("uses Helm release field names", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(testInfo().TemplateData()).Should(Equal(map[string]any{
+			"Name":      "web",
+			"Namespace": "apps",
+			"Revision":  2,
+			"IsInstall": false,
+			"IsUpgrade": true,
+			"Fields":    map[string]any{"cluster": "prod-eu", "namespace": "ignored"},
+		}))
+	})
+
+	t.Run("treats the first revision as an install", func(t *testing.T) {
+		g := NewWithT(t)
+
+		data := (&release.Info{Name: "web", Revision: 1}).TemplateData()
+
+		g.Expect(data).Should(HaveKeyWithValue("IsInstall", true))
+		g.Expect(data).Should(HaveKeyWithValue("IsUpgrade", false))
+	})
+
+	t.Run("treats missing info as an install with empty fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		info := release.FromContext(t.Context())
+
+		g.Expect(info.IsInstall()).Should(BeTrue())
+		g.Expect(info.TemplateData()).Should(Equal(map[string]any{
+			"Name":      "",
+			"Namespace": "",
+			"Revision":  0,
+			"IsInstall": true,
+			"IsUpgrade": false,
+			"Fields":    map[string]any{},
+		}))
+	})
+}
+
+func TestInfoExtVars(t *testing.T) {
+	t.Run("exposes release and custom fields as strings", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(testInfo().ExtVars()).Should(Equal(map[string]string{
+			"releaseName": "web",
+			"namespace":   "apps",
+			"revision":    "2",
+			"cluster":     "prod-eu",
+		}))
+	})
+
+	t.Run("treats missing info as empty variables", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(release.FromContext(t.Context()).ExtVars()).Should(Equal(map[string]string{
+			"releaseName": "",
+			"namespace":   "",
+			"revision":    "0",
+		}))
+	})
+}
@@ -0,0 +1,330 @@
+// Package status computes the health of Kubernetes objects from their status,
+// and waits for applied objects to become healthy.
+package status
+
+import (
+	"cmp"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Status summarizes the health of an object, following the kstatus
+// conventions.
+type Status string
+
+const (
+	// Unknown marks an object whose status has not been computed yet.
+	Unknown Status = "Unknown"
+
+	// InProgress marks an object that is being reconciled, e.g. a Deployment
+	// rolling out.
+	InProgress Status = "InProgress"
+
+	// Current marks an object whose status matches its spec, e.g. a
+	// Deployment with all replicas available.
+	Current Status = "Current"
+
+	// Failed marks an object whose reconciliation failed and needs attention,
+	// e.g. a failed Job.
+	Failed Status = "Failed"
+
+	// Terminating marks an object being deleted.
+	Terminating Status = "Terminating"
+
+	// NotFound marks an object missing from the cluster.
+	NotFound Status = "NotFound"
+)
+
+// Result is the computed status of an object.
+type Result struct {
+	Status Status
+
+	// Message explains the status, e.g. "2 of 3 replicas are available".
+	Message string
+}
+
+// Func computes the status of objects of a kind.
+type Func func(obj *unstructured.Unstructured) Result
+
+// kindStatus lists the status computations of the built-in kinds.
+var kindStatus = map[schema.GroupKind]Func{
+	{Group: "apps", Kind: "Deployment"}:                               deploymentStatus,
+	{Group: "apps", Kind: "StatefulSet"}:                              statefulSetStatus,
+	{Group: "apps", Kind: "DaemonSet"}:                                daemonSetStatus,
+	{Group: "apps", Kind: "ReplicaSet"}:                               replicaSetStatus,
+	{Group: "batch", Kind: "Job"}:                                     jobStatus,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: crdStatus,
+	{Kind: "Pod"}:                   podStatus,
+	{Kind: "PersistentVolumeClaim"}: claimStatus,
+	{Kind: "Service"}:               serviceStatus,
+}
+
+// Compute returns the status of an object read from the cluster:
+//
+//   - objects being deleted are Terminating
+//   - objects whose status.observedGeneration lags metadata.generation are
+//     InProgress, since their controller has not seen the latest spec
+//   - Deployments, StatefulSets, DaemonSets and ReplicaSets are Current once
+//     all replicas are updated and available
+//   - Jobs are Current once complete, and Failed when failed
+//   - CustomResourceDefinitions are Current once established
+//   - Pods are Current once ready or succeeded, PersistentVolumeClaims once
+//     bound and LoadBalancer Services once they have an address
+//   - other objects, including custom resources, are Failed with a true
+//     Stalled condition, InProgress with a true Reconciling or a non-true
+//     Ready condition, and Current otherwise
+func Compute(obj *unstructured.Unstructured) Result {
+	if obj.GetDeletionTimestamp() != nil {
+		return Result{Status: Terminating, Message: "is being deleted"}
+	}
+
+	observed, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if found && observed < obj.GetGeneration() {
+		return Result{Status: InProgress, Message: fmt.Sprintf("generation %d is not observed yet", obj.GetGeneration())}
+	}
+
+	if fn, ok := kindStatus[obj.GroupVersionKind().GroupKind()]; ok {
+		return fn(obj)
+	}
+
+	return conditionStatus(obj)
+}
+
+// Condition returns a Func for custom resources reporting their health with
+// a condition: objects are Current when the condition is true and InProgress
+// otherwise.
+func Condition(conditionType string) Func {
+	return func(obj *unstructured.Unstructured) Result {
+		c, ok := findCondition(obj, conditionType)
+		if !ok {
+			return Result{Status: InProgress, Message: fmt.Sprintf("has no %s condition yet", conditionType)}
+		}
+
+		if c.status != "True" {
+			return Result{Status: InProgress, Message: c.describe()}
+		}
+
+		return Result{Status: Current, Message: c.describe()}
+	}
+}
+
+// condition is a status condition of an object.
+type condition struct {
+	conditionType string
+	status        string
+	reason        string
+	message       string
+}
+
+// describe returns the message of the condition, falling back to its reason.
+func (c condition) describe() string {
+	switch {
+	case c.message != "":
+		return c.message
+	case c.reason != "":
+		return c.reason
+	default:
+		return fmt.Sprintf("%s is %s", c.conditionType, c.status)
+	}
+}
+
+// findCondition returns the condition of an object of the given type.
+func findCondition(obj *unstructured.Unstructured, conditionType string) (condition, bool) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+
+	for _, item := range conditions {
+		m, ok := item.(map[string]any)
+		if !ok || m["type"] != conditionType {
+			continue
+		}
+
+		c := condition{conditionType: conditionType}
+		c.status, _ = m["status"].(string)
+		c.reason, _ = m["reason"].(string)
+		c.message, _ = m["message"].(string)
+
+		return c, true
+	}
+
+	return condition{}, false
+}
+
+// isTrue reports whether an object has a true condition of the given type.
+func isTrue(obj *unstructured.Unstructured, conditionType string) (condition, bool) {
+	c, ok := findCondition(obj, conditionType)
+
+	return c, ok && c.status == "True"
+}
+
+func conditionStatus(obj *unstructured.Unstructured) Result {
+	if c, ok := isTrue(obj, "Stalled"); ok {
+		return Result{Status: Failed, Message: c.describe()}
+	}
+
+	if c, ok := isTrue(obj, "Reconciling"); ok {
+		return Result{Status: InProgress, Message: c.describe()}
+	}
+
+	if c, ok := findCondition(obj, "Ready"); ok && c.status != "True" {
+		return Result{Status: InProgress, Message: c.describe()}
+	}
+
+	return Result{Status: Current, Message: "is current"}
+}
+
+// replicas returns an int64 field of status, or zero.
+func replicas(obj *unstructured.Unstructured, field string) int64 {
+	value, _, _ := unstructured.NestedInt64(obj.Object, "status", field)
+
+	return value
+}
+
+// desiredReplicas returns spec.replicas, defaulting to one.
+func desiredReplicas(obj *unstructured.Unstructured) int64 {
+	value, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+
+	return value
+}
+
+func deploymentStatus(obj *unstructured.Unstructured) Result {
+	if c, ok := findCondition(obj, "Progressing"); ok && c.reason == "ProgressDeadlineExceeded" {
+		return Result{Status: Failed, Message: c.describe()}
+	}
+
+	desired := desiredReplicas(obj)
+	updated := replicas(obj, "updatedReplicas")
+	available := replicas(obj, "availableReplicas")
+
+	switch {
+	case updated < desired:
+		return Result{Status: InProgress, Message: fmt.Sprintf("%d of %d replicas are updated", updated, desired)}
+	case replicas(obj, "replicas") > updated:
+		return Result{Status: InProgress, Message: fmt.Sprintf("%d old replicas are pending termination", replicas(obj, "replicas")-updated)}
+	case available < updated:
+		return Result{Status: InProgress, Message: fmt.Sprintf("%d of %d replicas are available", available, desired)}
+	default:
+		return Result{Status: Current, Message: fmt.Sprintf("%d replicas are available", available)}
+	}
+}
+
+func statefulSetStatus(obj *unstructured.Unstructured) Result {
+	desired := desiredReplicas(obj)
+	ready := replicas(obj, "readyReplicas")
+
+	current, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+	update, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+	strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type")
+
+	switch {
+	case ready < desired:
+		return Result{Status: InProgress, Message: fmt.Sprintf("%d of %d replicas are ready", ready, desired)}
+	case strategy != "OnDelete" && current != update:
+		return Result{Status: InProgress, Message: fmt.Sprintf("%d of %d replicas are updated", replicas(obj, "updatedReplicas"), desired)}
+	default:
+		return Result{Status: Current, Message: fmt.Sprintf("%d replicas are ready", ready)}
+	}
+}
+
+func daemonSetStatus(obj *unstructured.Unstructured) Result {
+	if _, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration"); !found {
+		return Result{Status: InProgress, Message: "generation is not observed yet"}
+	}
+
+	desired := replicas(obj, "desiredNumberScheduled")
+	updated := replicas(obj, "updatedNumberScheduled")
+	available := replicas(obj, "numberAvailable")
+
+	switch {
+	case updated < desired:
+		return Result{Status: InProgress, Message: fmt.Sprintf("%d of %d pods are updated", updated, desired)}
+	case available < desired:
+		return Result{Status: InProgress, Message: fmt.Sprintf("%d of %d pods are available", available, desired)}
+	default:
+		return Result{Status: Current, Message: fmt.Sprintf("%d pods are available", available)}
+	}
+}
+
+func replicaSetStatus(obj *unstructured.Unstructured) Result {
+	desired := desiredReplicas(obj)
+	available := replicas(obj, "availableReplicas")
+
+	if available < desired {
+		return Result{Status: InProgress, Message: fmt.Sprintf("%d of %d replicas are available", available, desired)}
+	}
+
+	return Result{Status: Current, Message: fmt.Sprintf("%d replicas are available", available)}
+}
+
+func jobStatus(obj *unstructured.Unstructured) Result {
+	if c, ok := isTrue(obj, "Failed"); ok {
+		return Result{Status: Failed, Message: c.describe()}
+	}
+
+	if _, ok := isTrue(obj, "Complete"); ok {
+		return Result{Status: Current, Message: "is complete"}
+	}
+
+	return Result{Status: InProgress, Message: fmt.Sprintf("%d pods succeeded", replicas(obj, "succeeded"))}
+}
+
+func crdStatus(obj *unstructured.Unstructured) Result {
+	if c, ok := findCondition(obj, "NamesAccepted"); ok && c.status == "False" {
+		return Result{Status: Failed, Message: c.describe()}
+	}
+
+	if _, ok := isTrue(obj, "Established"); !ok {
+		return Result{Status: InProgress, Message: "is not established yet"}
+	}
+
+	return Result{Status: Current, Message: "is established"}
+}
+
+func podStatus(obj *unstructured.Unstructured) Result {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+
+	switch phase {
+	case "Succeeded":
+		return Result{Status: Current, Message: "has succeeded"}
+	case "Failed":
+		message, _, _ := unstructured.NestedString(obj.Object, "status", "message")
+
+		return Result{Status: Failed, Message: cmp.Or(message, "has failed")}
+	case "Running":
+		if _, ok := isTrue(obj, "Ready"); ok {
+			return Result{Status: Current, Message: "is ready"}
+		}
+
+		return Result{Status: InProgress, Message: "is running but not ready"}
+	default:
+		return Result{Status: InProgress, Message: fmt.Sprintf("is %s", cmp.Or(phase, "Pending"))}
+	}
+}
+
+func claimStatus(obj *unstructured.Unstructured) Result {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+
+	switch phase {
+	case "Bound":
+		return Result{Status: Current, Message: "is bound"}
+	case "Lost":
+		return Result{Status: Failed, Message: "has lost its volume"}
+	default:
+		return Result{Status: InProgress, Message: "is not bound yet"}
+	}
+}
+
+func serviceStatus(obj *unstructured.Unstructured) Result {
+	serviceType, _, _ := unstructured.NestedString(obj.Object, "spec", "type")
+	ingress, _, _ := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
+
+	if serviceType == "LoadBalancer" && len(ingress) == 0 {
+		return Result{Status: InProgress, Message: "has no load balancer address yet"}
+	}
+
+	return Result{Status: Current, Message: "is current"}
+}
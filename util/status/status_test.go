@@ -0,0 +1,146 @@
+package status_test
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/status"
+
+	. "github.com/onsi/gomega"
+)
+
+func newObject(apiVersion string, kind string, content map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName("test")
+	obj.SetNamespace("apps")
+
+	return obj
+}
+
+func newDeployment(generation int64, observed int64, updated int64, available int64) *unstructured.Unstructured {
+	obj := newObject("apps/v1", "Deployment", map[string]any{
+		"spec": map[string]any{"replicas": int64(3)},
+		"status": map[string]any{
+			"observedGeneration": observed,
+			"replicas":           int64(3),
+			"updatedReplicas":    updated,
+			"availableReplicas":  available,
+		},
+	})
+	obj.SetGeneration(generation)
+
+	return obj
+}
+
+func withCondition(obj *unstructured.Unstructured, conditionType string, conditionStatus string, reason string) *unstructured.Unstructured {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	conditions = append(conditions, map[string]any{"type": conditionType, "status": conditionStatus, "reason": reason})
+	_ = unstructured.SetNestedSlice(obj.Object, conditions, "status", "conditions")
+
+	return obj
+}
+
+func TestCompute(t *testing.T) {
+	t.Run("should follow Deployment rollouts", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(status.Compute(newDeployment(2, 1, 3, 3))).Should(Equal(status.Result{
+			Status:  status.InProgress,
+			Message: "generation 2 is not observed yet",
+		}))
+		g.Expect(status.Compute(newDeployment(2, 2, 1, 1))).Should(Equal(status.Result{
+			Status:  status.InProgress,
+			Message: "1 of 3 replicas are updated",
+		}))
+		g.Expect(status.Compute(newDeployment(2, 2, 3, 2))).Should(Equal(status.Result{
+			Status:  status.InProgress,
+			Message: "2 of 3 replicas are available",
+		}))
+		g.Expect(status.Compute(newDeployment(2, 2, 3, 3))).Should(Equal(status.Result{
+			Status:  status.Current,
+			Message: "3 replicas are available",
+		}))
+	})
+
+	t.Run("should fail Deployments exceeding their progress deadline", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := withCondition(newDeployment(1, 1, 1, 0), "Progressing", "False", "ProgressDeadlineExceeded")
+
+		g.Expect(status.Compute(obj).Status).Should(Equal(status.Failed))
+	})
+
+	t.Run("should follow Jobs", func(t *testing.T) {
+		g := NewWithT(t)
+
+		job := newObject("batch/v1", "Job", map[string]any{"status": map[string]any{"succeeded": int64(1)}})
+		g.Expect(status.Compute(job)).Should(Equal(status.Result{Status: status.InProgress, Message: "1 pods succeeded"}))
+
+		complete := withCondition(job.DeepCopy(), "Complete", "True", "")
+		g.Expect(status.Compute(complete).Status).Should(Equal(status.Current))
+
+		failed := withCondition(job.DeepCopy(), "Failed", "True", "BackoffLimitExceeded")
+		g.Expect(status.Compute(failed)).Should(Equal(status.Result{Status: status.Failed, Message: "BackoffLimitExceeded"}))
+	})
+
+	t.Run("should wait for CRDs to be established", func(t *testing.T) {
+		g := NewWithT(t)
+
+		crd := newObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", map[string]any{})
+		g.Expect(status.Compute(crd).Status).Should(Equal(status.InProgress))
+
+		g.Expect(status.Compute(withCondition(crd, "Established", "True", "")).Status).Should(Equal(status.Current))
+
+		conflicting := newObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", map[string]any{})
+		g.Expect(status.Compute(withCondition(conflicting, "NamesAccepted", "False", "ConflictingNames")).Status).
+			Should(Equal(status.Failed))
+	})
+
+	t.Run("should wait for LoadBalancer addresses", func(t *testing.T) {
+		g := NewWithT(t)
+
+		svc := newObject("v1", "Service", map[string]any{"spec": map[string]any{"type": "LoadBalancer"}})
+		g.Expect(status.Compute(svc).Status).Should(Equal(status.InProgress))
+
+		_ = unstructured.SetNestedSlice(svc.Object, []any{map[string]any{"ip": "10.0.0.1"}}, "status", "loadBalancer", "ingress")
+		g.Expect(status.Compute(svc).Status).Should(Equal(status.Current))
+	})
+
+	t.Run("should use conditions of other objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(status.Compute(newObject("v1", "ConfigMap", map[string]any{})).Status).Should(Equal(status.Current))
+
+		notReady := withCondition(newObject("example.com/v1", "Widget", map[string]any{}), "Ready", "False", "Provisioning")
+		g.Expect(status.Compute(notReady)).Should(Equal(status.Result{Status: status.InProgress, Message: "Provisioning"}))
+
+		stalled := withCondition(newObject("example.com/v1", "Widget", map[string]any{}), "Stalled", "True", "InvalidSpec")
+		g.Expect(status.Compute(stalled).Status).Should(Equal(status.Failed))
+	})
+
+	t.Run("should report deleted objects as terminating", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := newDeployment(1, 1, 3, 3)
+		deleted := metav1.Now()
+		obj.SetDeletionTimestamp(&deleted)
+
+		g.Expect(status.Compute(obj).Status).Should(Equal(status.Terminating))
+	})
+}
+
+func TestCondition(t *testing.T) {
+	t.Run("should follow a custom condition", func(t *testing.T) {
+		g := NewWithT(t)
+
+		synced := status.Condition("Synced")
+		obj := newObject("example.com/v1", "Widget", map[string]any{})
+
+		g.Expect(synced(obj)).Should(Equal(status.Result{Status: status.InProgress, Message: "has no Synced condition yet"}))
+		g.Expect(synced(withCondition(obj, "Synced", "True", "")).Status).Should(Equal(status.Current))
+	})
+}
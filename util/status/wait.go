@@ -0,0 +1,147 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+var (
+	// ErrFailed is returned by Wait when an object is Failed.
+	ErrFailed = errors.New("object failed")
+
+	// ErrTimeout is returned by Wait when an object does not become Current
+	// in time.
+	ErrTimeout = errors.New("timed out waiting for object")
+)
+
+// ObjectGetter returns an object from the target cluster, and whether it
+// exists. It has the signature of validate.ObjectGetter, whose documentation
+// shows a client-go implementation.
+type ObjectGetter func(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, bool, error)
+
+// ObjectStatus is the status of an object waited for.
+type ObjectStatus struct {
+	Key k8s.ResourceKey
+	Result
+}
+
+// Event is a status change of an object observed by Wait: a change of status,
+// e.g. from InProgress to Current, or of message within a status, e.g. from
+// "1 of 3 replicas are available" to "2 of 3 replicas are available".
+type Event struct {
+	Key k8s.ResourceKey
+
+	// Previous is the status before the change, Unknown for the first
+	// observation.
+	Previous Status
+
+	// Result is the new status.
+	Result
+}
+
+// Wait polls the cluster with get until all objects are Current, e.g. after
+// applying a render, so that callers can block until it is actually healthy.
+// It returns the last status of every object, in order, with an error
+// wrapping ErrFailed for each Failed object and ErrTimeout for each object
+// not Current in time; it stops waiting for an object as soon as it is
+// Current, Failed or timed out, and for all objects when ctx is done.
+func Wait(ctx context.Context, get ObjectGetter, objects []unstructured.Unstructured, opts ...WaitOption) ([]ObjectStatus, error) {
+	options := WaitOptions{
+		Timeout:  DefaultTimeout,
+		Interval: DefaultInterval,
+	}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	start := time.Now()
+	statuses := make([]ObjectStatus, len(objects))
+	pending := make([]int, 0, len(objects))
+
+	for i := range objects {
+		statuses[i] = ObjectStatus{Key: k8s.KeyOf(&objects[i]), Result: Result{Status: Unknown}}
+		pending = append(pending, i)
+	}
+
+	var errs []error
+
+	for {
+		waiting := pending[:0]
+
+		for _, i := range pending {
+			result, err := options.compute(ctx, get, &objects[i])
+			if err != nil {
+				return statuses, fmt.Errorf("unable to get %s: %w", statuses[i].Key, err)
+			}
+
+			if result != statuses[i].Result && options.Events != nil {
+				options.Events(Event{Key: statuses[i].Key, Previous: statuses[i].Status, Result: result})
+			}
+
+			statuses[i].Result = result
+
+			switch {
+			case result.Status == Failed:
+				errs = append(errs, fmt.Errorf("%w: %s: %s", ErrFailed, statuses[i].Key, result.Message))
+			case result.Status != Current && time.Since(start) >= options.timeout(&objects[i]):
+				errs = append(errs, fmt.Errorf("%w: %s is %s: %s", ErrTimeout, statuses[i].Key, result.Status, result.Message))
+			case result.Status != Current:
+				waiting = append(waiting, i)
+			}
+		}
+
+		pending = waiting
+
+		if options.Progress != nil {
+			options.Progress(statuses)
+		}
+
+		if len(pending) == 0 {
+			return statuses, errors.Join(errs...)
+		}
+
+		timer := time.NewTimer(options.Interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return statuses, errors.Join(append(errs, ctx.Err())...)
+		case <-timer.C:
+		}
+	}
+}
+
+// compute reads obj from the cluster and returns its status.
+func (opts WaitOptions) compute(ctx context.Context, get ObjectGetter, obj *unstructured.Unstructured) (Result, error) {
+	live, found, err := get(ctx, obj)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if !found {
+		return Result{Status: NotFound, Message: "does not exist"}, nil
+	}
+
+	if fn, ok := opts.Funcs[obj.GroupVersionKind().GroupKind()]; ok {
+		return fn(live), nil
+	}
+
+	return Compute(live), nil
+}
+
+// timeout returns how long to wait for obj.
+func (opts WaitOptions) timeout(obj *unstructured.Unstructured) time.Duration {
+	if timeout, ok := opts.KindTimeouts[obj.GroupVersionKind().GroupKind()]; ok {
+		return timeout
+	}
+
+	return opts.Timeout
+}
@@ -0,0 +1,139 @@
+package status
+
+import (
+	"maps"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+const (
+	// DefaultTimeout is how long Wait waits for each object by default.
+	DefaultTimeout = 5 * time.Minute
+
+	// DefaultInterval is how often Wait reads the objects by default.
+	DefaultInterval = 2 * time.Second
+)
+
+// WaitOption is a generic option for Wait.
+type WaitOption = util.Option[WaitOptions]
+
+// ProgressFunc receives the statuses of all objects after each poll of Wait.
+type ProgressFunc func(statuses []ObjectStatus)
+
+// EventFunc receives the status changes of objects during Wait.
+type EventFunc func(event Event)
+
+// WaitOptions is a struct-based option that can set multiple wait options at once.
+type WaitOptions struct {
+	// Timeout bounds the wait for each object; defaults to DefaultTimeout.
+	Timeout time.Duration
+
+	// KindTimeouts overrides Timeout for objects of a kind, e.g. for slow
+	// database StatefulSets.
+	KindTimeouts map[schema.GroupKind]time.Duration
+
+	// Interval is the delay between polls; defaults to DefaultInterval.
+	Interval time.Duration
+
+	// Funcs overrides Compute for objects of a kind, e.g. for custom
+	// resources reporting their health with a custom condition.
+	Funcs map[schema.GroupKind]Func
+
+	// Progress, when set, receives the statuses after each poll.
+	Progress ProgressFunc
+
+	// Events, when set, receives each status change.
+	Events EventFunc
+}
+
+// ApplyTo applies the wait options to the target configuration.
+func (opts WaitOptions) ApplyTo(target *WaitOptions) {
+	if opts.Timeout > 0 {
+		target.Timeout = opts.Timeout
+	}
+
+	if len(opts.KindTimeouts) > 0 {
+		if target.KindTimeouts == nil {
+			target.KindTimeouts = make(map[schema.GroupKind]time.Duration, len(opts.KindTimeouts))
+		}
+
+		maps.Copy(target.KindTimeouts, opts.KindTimeouts)
+	}
+
+	if opts.Interval > 0 {
+		target.Interval = opts.Interval
+	}
+
+	if len(opts.Funcs) > 0 {
+		if target.Funcs == nil {
+			target.Funcs = make(map[schema.GroupKind]Func, len(opts.Funcs))
+		}
+
+		maps.Copy(target.Funcs, opts.Funcs)
+	}
+
+	if opts.Progress != nil {
+		target.Progress = opts.Progress
+	}
+
+	if opts.Events != nil {
+		target.Events = opts.Events
+	}
+}
+
+// WithTimeout bounds the wait for each object; defaults to DefaultTimeout.
+func WithTimeout(timeout time.Duration) WaitOption {
+	return util.FunctionalOption[WaitOptions](func(opts *WaitOptions) {
+		opts.Timeout = timeout
+	})
+}
+
+// WithKindTimeout bounds the wait for objects of a kind, overriding
+// WithTimeout.
+func WithKindTimeout(kind schema.GroupKind, timeout time.Duration) WaitOption {
+	return util.FunctionalOption[WaitOptions](func(opts *WaitOptions) {
+		if opts.KindTimeouts == nil {
+			opts.KindTimeouts = make(map[schema.GroupKind]time.Duration, 1)
+		}
+
+		opts.KindTimeouts[kind] = timeout
+	})
+}
+
+// WithInterval sets the delay between polls; defaults to DefaultInterval.
+func WithInterval(interval time.Duration) WaitOption {
+	return util.FunctionalOption[WaitOptions](func(opts *WaitOptions) {
+		opts.Interval = interval
+	})
+}
+
+// WithStatusFunc computes the status of objects of a kind with fn instead of
+// Compute, e.g. WithStatusFunc(kind, Condition("Synced")).
+func WithStatusFunc(kind schema.GroupKind, fn Func) WaitOption {
+	return util.FunctionalOption[WaitOptions](func(opts *WaitOptions) {
+		if opts.Funcs == nil {
+			opts.Funcs = make(map[schema.GroupKind]Func, 1)
+		}
+
+		opts.Funcs[kind] = fn
+	})
+}
+
+// WithProgress calls fn with the statuses of all objects after each poll.
+func WithProgress(fn ProgressFunc) WaitOption {
+	return util.FunctionalOption[WaitOptions](func(opts *WaitOptions) {
+		opts.Progress = fn
+	})
+}
+
+// WithEvents calls fn with each status change, as it is observed, e.g. to
+// show live progress; see Event. To consume events from a channel, fn sends
+// them to it.
+func WithEvents(fn EventFunc) WaitOption {
+	return util.FunctionalOption[WaitOptions](func(opts *WaitOptions) {
+		opts.Events = fn
+	})
+}
@@ -0,0 +1,173 @@
+package status_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/status"
+
+	. "github.com/onsi/gomega"
+)
+
+var errGetFailed = errors.New("get failed")
+
+// rollout returns a getter serving successive states of objects by name, the
+// last state repeating; a nil state is a missing object.
+func rollout(states map[string][]*unstructured.Unstructured) status.ObjectGetter {
+	calls := make(map[string]int)
+
+	return func(_ context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+		name := obj.GetName()
+		sequence := states[name]
+		state := sequence[min(calls[name], len(sequence)-1)]
+		calls[name]++
+
+		return state, state != nil, nil
+	}
+}
+
+func named(name string, obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj.SetName(name)
+
+	return obj
+}
+
+func TestWait(t *testing.T) {
+	objects := []unstructured.Unstructured{
+		*named("web", newDeployment(1, 1, 3, 3)),
+		*named("db", newDeployment(1, 1, 3, 3)),
+	}
+
+	t.Run("should wait until all objects are current", func(t *testing.T) {
+		g := NewWithT(t)
+
+		get := rollout(map[string][]*unstructured.Unstructured{
+			"web": {nil, newDeployment(1, 1, 1, 0), newDeployment(1, 1, 3, 3)},
+			"db":  {newDeployment(1, 1, 3, 3)},
+		})
+
+		var polls int
+
+		statuses, err := status.Wait(t.Context(), get, objects,
+			status.WithInterval(time.Millisecond),
+			status.WithProgress(func([]status.ObjectStatus) { polls++ }),
+		)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(polls).Should(Equal(3))
+		g.Expect(statuses).Should(HaveExactElements(
+			And(HaveField("Key.Name", "web"), HaveField("Status", status.Current)),
+			And(HaveField("Key.Name", "db"), HaveField("Status", status.Current)),
+		))
+	})
+
+	t.Run("should stream status changes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		get := rollout(map[string][]*unstructured.Unstructured{
+			"web": {nil, newDeployment(1, 1, 3, 1), newDeployment(1, 1, 3, 1), newDeployment(1, 1, 3, 2), newDeployment(1, 1, 3, 3)},
+			"db":  {newDeployment(1, 1, 3, 3)},
+		})
+
+		var events []status.Event
+
+		_, err := status.Wait(t.Context(), get, objects,
+			status.WithInterval(time.Millisecond),
+			status.WithEvents(func(event status.Event) { events = append(events, event) }),
+		)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(events).Should(HaveExactElements(
+			And(HaveField("Key.Name", "web"), HaveField("Previous", status.Unknown), HaveField("Status", status.NotFound)),
+			And(HaveField("Key.Name", "db"), HaveField("Previous", status.Unknown), HaveField("Status", status.Current)),
+			And(HaveField("Previous", status.NotFound), HaveField("Message", "1 of 3 replicas are available")),
+			And(HaveField("Previous", status.InProgress), HaveField("Message", "2 of 3 replicas are available")),
+			And(HaveField("Previous", status.InProgress), HaveField("Status", status.Current)),
+		))
+	})
+
+	t.Run("should report failed objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		get := rollout(map[string][]*unstructured.Unstructured{
+			"web": {withCondition(newDeployment(1, 1, 1, 0), "Progressing", "False", "ProgressDeadlineExceeded")},
+			"db":  {newDeployment(1, 1, 3, 3)},
+		})
+
+		statuses, err := status.Wait(t.Context(), get, objects, status.WithInterval(time.Millisecond))
+
+		g.Expect(err).Should(MatchError(status.ErrFailed))
+		g.Expect(err).Should(MatchError(ContainSubstring("apps/Deployment/apps/web: ProgressDeadlineExceeded")))
+		g.Expect(statuses[0].Status).Should(Equal(status.Failed))
+		g.Expect(statuses[1].Status).Should(Equal(status.Current))
+	})
+
+	t.Run("should time out objects per kind", func(t *testing.T) {
+		g := NewWithT(t)
+
+		get := rollout(map[string][]*unstructured.Unstructured{
+			"web": {newDeployment(1, 1, 1, 0)},
+			"db":  {newDeployment(1, 1, 3, 3)},
+		})
+
+		_, err := status.Wait(t.Context(), get, objects,
+			status.WithInterval(time.Millisecond),
+			status.WithTimeout(time.Hour),
+			status.WithKindTimeout(schema.GroupKind{Group: "apps", Kind: "Deployment"}, 5*time.Millisecond),
+		)
+
+		g.Expect(err).Should(MatchError(status.ErrTimeout))
+		g.Expect(err).Should(MatchError(ContainSubstring("web is InProgress: 1 of 3 replicas are updated")))
+	})
+
+	t.Run("should use custom status functions", func(t *testing.T) {
+		g := NewWithT(t)
+
+		widget := newObject("example.com/v1", "Widget", map[string]any{})
+		get := rollout(map[string][]*unstructured.Unstructured{
+			"test": {widget, withCondition(widget.DeepCopy(), "Synced", "True", "")},
+		})
+
+		statuses, err := status.Wait(t.Context(), get, []unstructured.Unstructured{*widget},
+			status.WithInterval(time.Millisecond),
+			status.WithStatusFunc(schema.GroupKind{Group: "example.com", Kind: "Widget"}, status.Condition("Synced")),
+		)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(statuses[0].Message).Should(Equal("Synced is True"))
+	})
+
+	t.Run("should stop when the context is done", func(t *testing.T) {
+		g := NewWithT(t)
+
+		get := rollout(map[string][]*unstructured.Unstructured{
+			"web": {nil},
+			"db":  {nil},
+		})
+
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Millisecond)
+		defer cancel()
+
+		statuses, err := status.Wait(ctx, get, objects, status.WithInterval(time.Millisecond))
+
+		g.Expect(err).Should(MatchError(context.DeadlineExceeded))
+		g.Expect(statuses[0].Status).Should(Equal(status.NotFound))
+	})
+
+	t.Run("should fail when objects cannot be read", func(t *testing.T) {
+		g := NewWithT(t)
+
+		failing := func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+			return nil, false, errGetFailed
+		}
+
+		_, err := status.Wait(t.Context(), failing, objects)
+
+		g.Expect(err).Should(MatchError(errGetFailed))
+	})
+}
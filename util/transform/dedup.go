@@ -0,0 +1,132 @@
+package transform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/maps"
+)
+
+var (
+	// ErrDuplicateObject is returned by DedupError when an object is emitted more than once.
+	ErrDuplicateObject = errors.New("duplicate object")
+
+	// ErrUnknownDedupPolicy is returned for an unsupported deduplication policy.
+	ErrUnknownDedupPolicy = errors.New("unknown dedup policy")
+)
+
+// DedupPolicy decides what happens when several objects share the same
+// group, kind, namespace and name, typically because multiple sources emit
+// them.
+type DedupPolicy string
+
+const (
+	// DedupError fails the render.
+	DedupError DedupPolicy = "error"
+
+	// DedupFirstWins keeps the first object.
+	DedupFirstWins DedupPolicy = "first-wins"
+
+	// DedupLastWins keeps the last object.
+	DedupLastWins DedupPolicy = "last-wins"
+
+	// DedupMerge deep merges the duplicates in order, later objects taking
+	// precedence (see maps.DeepMerge).
+	DedupMerge DedupPolicy = "deep-merge"
+)
+
+// Conflict describes an object emitted more than once.
+type Conflict struct {
+	// Key identifies the duplicated object.
+	Key k8s.ResourceKey
+
+	// Count is the number of objects sharing Key.
+	Count int
+}
+
+// Dedup returns a Transformer resolving duplicate objects according to
+// policy. The resolved object takes the position of the first duplicate, so
+// the order of the set is otherwise preserved. Conflicts are reported to the
+// handler set with WithConflictHandler, whatever the policy.
+func Dedup(policy DedupPolicy, opts ...DedupOption) Transformer {
+	options := DedupOptions{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	return Func(func(ctx context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		switch policy {
+		case DedupError, DedupFirstWins, DedupLastWins, DedupMerge:
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownDedupPolicy, string(policy))
+		}
+
+		type group struct {
+			key   k8s.ResourceKey
+			items []int
+		}
+
+		groups := make([]*group, 0, len(objects))
+		byKey := make(map[k8s.ResourceKey]*group, len(objects))
+
+		for i := range objects {
+			key := k8s.KeyOf(&objects[i])
+
+			grp, ok := byKey[key]
+			if !ok {
+				grp = &group{key: key}
+				byKey[key] = grp
+				groups = append(groups, grp)
+			}
+
+			grp.items = append(grp.items, i)
+		}
+
+		if len(groups) == len(objects) {
+			return objects, nil
+		}
+
+		var duplicates []string
+
+		result := make([]unstructured.Unstructured, 0, len(groups))
+
+		for _, grp := range groups {
+			if len(grp.items) == 1 {
+				result = append(result, objects[grp.items[0]])
+
+				continue
+			}
+
+			if options.OnConflict != nil {
+				options.OnConflict(ctx, Conflict{Key: grp.key, Count: len(grp.items)})
+			}
+
+			switch policy {
+			case DedupError:
+				duplicates = append(duplicates, grp.key.String())
+			case DedupFirstWins:
+				result = append(result, objects[grp.items[0]])
+			case DedupLastWins:
+				result = append(result, objects[grp.items[len(grp.items)-1]])
+			case DedupMerge:
+				merged := objects[grp.items[0]].Object
+				for _, i := range grp.items[1:] {
+					merged = maps.DeepMerge(merged, objects[i].Object)
+				}
+
+				result = append(result, unstructured.Unstructured{Object: merged})
+			}
+		}
+
+		if len(duplicates) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateObject, strings.Join(duplicates, ", "))
+		}
+
+		return result, nil
+	})
+}
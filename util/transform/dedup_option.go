@@ -0,0 +1,32 @@
+package transform
+
+import (
+	"context"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// DedupOption is a generic option for Dedup.
+type DedupOption = util.Option[DedupOptions]
+
+// DedupOptions is a struct-based option that can set multiple deduplication options at once.
+type DedupOptions struct {
+	// OnConflict is called once per duplicated object, in order of first
+	// appearance.
+	OnConflict func(ctx context.Context, conflict Conflict)
+}
+
+// ApplyTo applies the deduplication options to the target configuration.
+func (opts DedupOptions) ApplyTo(target *DedupOptions) {
+	if opts.OnConflict != nil {
+		target.OnConflict = opts.OnConflict
+	}
+}
+
+// WithConflictHandler sets the function receiving the detected conflicts,
+// e.g. to include them in a render report.
+func WithConflictHandler(fn func(ctx context.Context, conflict Conflict)) DedupOption {
+	return util.FunctionalOption[DedupOptions](func(opts *DedupOptions) {
+		opts.OnConflict = fn
+	})
+}
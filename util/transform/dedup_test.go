@@ -0,0 +1,111 @@
+package transform_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+
+	. "github.com/onsi/gomega"
+)
+
+const testDedupYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shared
+  namespace: apps
+data:
+  a: "1"
+  b: "1"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: apps
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shared
+  namespace: apps
+data:
+  b: "2"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shared
+  namespace: other
+`
+
+func TestDedup(t *testing.T) {
+	t.Run("fails on duplicates with the error policy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := transform.Dedup(transform.DedupError).Transform(t.Context(), decodeObjects(g, testDedupYAML))
+
+		g.Expect(err).Should(MatchError(transform.ErrDuplicateObject))
+		g.Expect(err).Should(MatchError(ContainSubstring("core/ConfigMap/apps/shared")))
+	})
+
+	t.Run("keeps the first object", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.Dedup(transform.DedupFirstWins).Transform(t.Context(), decodeObjects(g, testDedupYAML))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(3))
+		g.Expect(result[0].Object).Should(HaveKeyWithValue("data", map[string]any{"a": "1", "b": "1"}))
+		g.Expect(result[1].GetKind()).Should(Equal("Service"))
+		g.Expect(result[2].GetNamespace()).Should(Equal("other"))
+	})
+
+	t.Run("keeps the last object at the first position", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.Dedup(transform.DedupLastWins).Transform(t.Context(), decodeObjects(g, testDedupYAML))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(3))
+		g.Expect(result[0].Object).Should(HaveKeyWithValue("data", map[string]any{"b": "2"}))
+	})
+
+	t.Run("deep merges duplicates", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.Dedup(transform.DedupMerge).Transform(t.Context(), decodeObjects(g, testDedupYAML))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(3))
+		g.Expect(result[0].Object).Should(HaveKeyWithValue("data", map[string]any{"a": "1", "b": "2"}))
+	})
+
+	t.Run("reports conflicts", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var conflicts []transform.Conflict
+
+		_, err := transform.Dedup(transform.DedupLastWins,
+			transform.WithConflictHandler(func(_ context.Context, c transform.Conflict) {
+				conflicts = append(conflicts, c)
+			}),
+		).Transform(t.Context(), decodeObjects(g, testDedupYAML))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(conflicts).Should(Equal([]transform.Conflict{{
+			Key:   k8s.ResourceKey{Kind: "ConfigMap", Namespace: "apps", Name: "shared"},
+			Count: 2,
+		}}))
+	})
+
+	t.Run("rejects unknown policies", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := transform.Dedup("random").Transform(t.Context(), nil)
+
+		g.Expect(err).Should(MatchError(transform.ErrUnknownDedupPolicy))
+	})
+}
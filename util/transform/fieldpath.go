@@ -0,0 +1,263 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFieldPath is returned when a field path cannot be parsed or does
+// not match the structure of an object.
+var ErrInvalidFieldPath = errors.New("invalid field path")
+
+// pathSegment is one element of a field path: a map key, a list index, or a
+// list element selected by the value of one of its fields.
+type pathSegment struct {
+	key        string
+	index      int
+	matchKey   string
+	matchValue string
+}
+
+func (s pathSegment) isIndex() bool {
+	return s.key == "" && s.matchKey == ""
+}
+
+func (s pathSegment) isMatch() bool {
+	return s.matchKey != ""
+}
+
+// parseFieldPath parses a kustomize-style field path such as
+// "spec.template.spec.containers.[name=app].env.0.value". Segments are
+// separated by dots, but brackets and escapes are parsed first, so keys and
+// selector values may contain dots: a bracketed segment without "=" is a map
+// key, as in "metadata.labels.[app.kubernetes.io/name]", and a dot escaped
+// with a backslash is part of the key, as in "data.app\.properties".
+func parseFieldPath(path string) ([]pathSegment, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("%w: empty path", ErrInvalidFieldPath)
+	}
+
+	var segments []pathSegment
+
+	for rest := path; ; {
+		seg, next, err := parseSegment(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w in %q", err, path)
+		}
+
+		segments = append(segments, seg)
+
+		if next == "" {
+			return segments, nil
+		}
+
+		rest = next[1:]
+	}
+}
+
+// parseSegment parses the segment at the start of path and returns it with
+// the remainder of path, which is empty or starts with the separating dot.
+func parseSegment(path string) (pathSegment, string, error) {
+	if strings.HasPrefix(path, "[") {
+		end := strings.Index(path, "]")
+		if end < 0 {
+			return pathSegment{}, "", fmt.Errorf("%w: unterminated %q", ErrInvalidFieldPath, path)
+		}
+
+		content, rest := path[1:end], path[end+1:]
+		if rest != "" && !strings.HasPrefix(rest, ".") {
+			return pathSegment{}, "", fmt.Errorf("%w: unexpected %q after %q", ErrInvalidFieldPath, rest, path[:end+1])
+		}
+
+		k, v, ok := strings.Cut(content, "=")
+
+		switch {
+		case k == "":
+			return pathSegment{}, "", fmt.Errorf("%w: invalid bracketed segment %q", ErrInvalidFieldPath, path[:end+1])
+		case ok:
+			return pathSegment{matchKey: k, matchValue: v}, rest, nil
+		default:
+			return pathSegment{key: k}, rest, nil
+		}
+	}
+
+	var key strings.Builder
+
+	i := 0
+	for ; i < len(path) && path[i] != '.'; i++ {
+		if path[i] == '\\' && i+1 < len(path) && path[i+1] == '.' {
+			i++
+		}
+
+		key.WriteByte(path[i])
+	}
+
+	if i == 0 {
+		return pathSegment{}, "", fmt.Errorf("%w: empty segment", ErrInvalidFieldPath)
+	}
+
+	// Only a plain number is an index; an escaped key is always a key.
+	if n, err := strconv.Atoi(path[:i]); err == nil && n >= 0 {
+		return pathSegment{index: n}, path[i:], nil
+	}
+
+	return pathSegment{key: key.String()}, path[i:], nil
+}
+
+// getField returns the value at the given path, and whether it was found.
+func getField(obj map[string]any, segments []pathSegment) (any, bool) {
+	var current any = obj
+
+	for _, seg := range segments {
+		switch {
+		case seg.isIndex():
+			list, ok := current.([]any)
+			if !ok || seg.index >= len(list) {
+				return nil, false
+			}
+
+			current = list[seg.index]
+		case seg.isMatch():
+			list, ok := current.([]any)
+			if !ok {
+				return nil, false
+			}
+
+			i := findElement(list, seg)
+			if i < 0 {
+				return nil, false
+			}
+
+			current = list[i]
+		default:
+			m, ok := current.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+
+			v, ok := m[seg.key]
+			if !ok {
+				return nil, false
+			}
+
+			current = v
+		}
+	}
+
+	return current, true
+}
+
+// setField sets the value at the given path. When create is true, missing
+// maps, lists and selected list elements are created, and an index equal to
+// the length of a list appends an element; an index further past the end is
+// an error. Otherwise a missing path is left untouched and reported as not
+// set.
+func setField(obj map[string]any, segments []pathSegment, value any, create bool) (bool, error) {
+	if len(segments) == 0 {
+		return false, fmt.Errorf("%w: empty path", ErrInvalidFieldPath)
+	}
+
+	_, ok, err := setIn(obj, segments, value, create)
+
+	return ok, err
+}
+
+// setIn sets value at segments below node and returns the updated node, as
+// appending to a list yields a new slice that must be stored in its parent.
+func setIn(node any, segments []pathSegment, value any, create bool) (any, bool, error) {
+	if len(segments) == 0 {
+		return value, true, nil
+	}
+
+	seg := segments[0]
+	rest := segments[1:]
+
+	switch n := node.(type) {
+	case map[string]any:
+		if seg.key == "" {
+			return n, false, fmt.Errorf("%w: cannot select a list element of a map", ErrInvalidFieldPath)
+		}
+
+		child, exists := n[seg.key]
+		if !exists || child == nil {
+			if !create {
+				return n, false, nil
+			}
+
+			child = newContainer(rest)
+		}
+
+		updated, ok, err := setIn(child, rest, value, create)
+		if err != nil || !ok {
+			return n, ok, err
+		}
+
+		n[seg.key] = updated
+
+		return n, true, nil
+	case []any:
+		i := seg.index
+
+		switch {
+		case seg.isMatch():
+			i = findElement(n, seg)
+			if i < 0 {
+				if !create {
+					return n, false, nil
+				}
+
+				n = append(n, map[string]any{seg.matchKey: seg.matchValue})
+				i = len(n) - 1
+			}
+		case !seg.isIndex():
+			return n, false, fmt.Errorf("%w: cannot use key %q on a list", ErrInvalidFieldPath, seg.key)
+		case i >= len(n) && !create:
+			return n, false, nil
+		case i > len(n):
+			return n, false, fmt.Errorf("%w: index %d is past the end of a list of %d elements", ErrInvalidFieldPath, i, len(n))
+		case i == len(n):
+			n = append(n, newContainer(rest))
+		}
+
+		updated, ok, err := setIn(n[i], rest, value, create)
+		if err != nil || !ok {
+			return n, ok, err
+		}
+
+		n[i] = updated
+
+		return n, true, nil
+	default:
+		return node, false, fmt.Errorf("%w: cannot traverse %T", ErrInvalidFieldPath, node)
+	}
+}
+
+// newContainer returns an empty container suited to the given segments.
+func newContainer(segments []pathSegment) any {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	if segments[0].isIndex() || segments[0].isMatch() {
+		return []any{}
+	}
+
+	return map[string]any{}
+}
+
+func findElement(list []any, seg pathSegment) int {
+	for i, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if v, ok := m[seg.matchKey]; ok && fmt.Sprint(v) == seg.matchValue {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,166 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// fieldSpec identifies a string map field of an object that common labels or
+// annotations propagate into. The field is only set when anchor exists, so
+// that e.g. a Service without a selector does not suddenly get one.
+type fieldSpec struct {
+	anchor []string
+	path   []string
+}
+
+func templateField(prefix ...string) fieldSpec {
+	return fieldSpec{
+		anchor: prefix,
+		path:   slices.Concat(prefix, []string{"metadata"}),
+	}
+}
+
+var (
+	workloadTemplate = templateField("spec", "template")
+	matchLabels      = fieldSpec{anchor: []string{"spec", "selector"}, path: []string{"spec", "selector", "matchLabels"}}
+	plainSelector    = fieldSpec{anchor: []string{"spec", "selector"}, path: []string{"spec", "selector"}}
+
+	// templateFields lists, per kind, the embedded object templates whose
+	// metadata receives common labels and annotations.
+	templateFields = map[schema.GroupKind][]fieldSpec{
+		{Group: "apps", Kind: "Deployment"}:  {workloadTemplate},
+		{Group: "apps", Kind: "ReplicaSet"}:  {workloadTemplate},
+		{Group: "apps", Kind: "StatefulSet"}: {workloadTemplate},
+		{Group: "apps", Kind: "DaemonSet"}:   {workloadTemplate},
+		{Group: "batch", Kind: "Job"}:        {workloadTemplate},
+		{Group: "batch", Kind: "CronJob"}: {
+			templateField("spec", "jobTemplate"),
+			templateField("spec", "jobTemplate", "spec", "template"),
+		},
+		{Kind: "ReplicationController"}: {workloadTemplate},
+	}
+
+	// selectorFields lists, per kind, the label selectors receiving common
+	// labels. Job selectors are generated by the API server and are skipped.
+	selectorFields = map[schema.GroupKind][]fieldSpec{
+		{Group: "apps", Kind: "Deployment"}:            {matchLabels},
+		{Group: "apps", Kind: "ReplicaSet"}:            {matchLabels},
+		{Group: "apps", Kind: "StatefulSet"}:           {matchLabels},
+		{Group: "apps", Kind: "DaemonSet"}:             {matchLabels},
+		{Group: "policy", Kind: "PodDisruptionBudget"}: {matchLabels},
+		{Kind: "Service"}:                              {plainSelector},
+		{Kind: "ReplicationController"}:                {plainSelector},
+		{Group: "networking.k8s.io", Kind: "NetworkPolicy"}: {{
+			anchor: []string{"spec", "podSelector"},
+			path:   []string{"spec", "podSelector", "matchLabels"},
+		}},
+	}
+)
+
+// CommonLabels returns a Transformer adding the given labels to every object.
+//
+// Labels are propagated into the pod (and job) templates of workload kinds and,
+// unless disabled with WithSelectorPropagation(false), into the label selectors
+// of workloads, Services, PodDisruptionBudgets and NetworkPolicies. Selectors
+// are immutable on most workloads, so disable selector propagation when adding
+// labels to already deployed resources.
+func CommonLabels(values map[string]string, opts ...MetaOption) Transformer {
+	options := MetaOptions{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	return ObjectFunc(func(_ context.Context, obj *unstructured.Unstructured) error {
+		if len(values) == 0 {
+			return nil
+		}
+
+		k8s.SetLabels(obj, values)
+
+		gk := obj.GroupVersionKind().GroupKind()
+
+		if !options.SkipTemplates {
+			for _, spec := range templateFields[gk] {
+				if err := mergeStringMap(obj, values, spec.anchor, slices.Concat(spec.path, []string{"labels"})); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !options.SkipSelectors {
+			for _, spec := range selectorFields[gk] {
+				if err := mergeStringMap(obj, values, spec.anchor, spec.path); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// CommonAnnotations returns a Transformer adding the given annotations to every
+// object and, unless disabled with WithTemplatePropagation(false), to the pod
+// (and job) templates of workload kinds.
+func CommonAnnotations(values map[string]string, opts ...MetaOption) Transformer {
+	options := MetaOptions{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	return ObjectFunc(func(_ context.Context, obj *unstructured.Unstructured) error {
+		if len(values) == 0 {
+			return nil
+		}
+
+		k8s.SetAnnotations(obj, values)
+
+		if options.SkipTemplates {
+			return nil
+		}
+
+		for _, spec := range templateFields[obj.GroupVersionKind().GroupKind()] {
+			if err := mergeStringMap(obj, values, spec.anchor, slices.Concat(spec.path, []string{"annotations"})); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// mergeStringMap merges values into the string map at path if anchor exists.
+func mergeStringMap(
+	obj *unstructured.Unstructured,
+	values map[string]string,
+	anchor []string,
+	path []string,
+) error {
+	if _, found, _ := unstructured.NestedFieldNoCopy(obj.Object, anchor...); !found {
+		return nil
+	}
+
+	current, _, err := unstructured.NestedStringMap(obj.Object, path...)
+	if err != nil {
+		return fmt.Errorf("unable to read %v: %w", path, err)
+	}
+
+	if current == nil {
+		current = make(map[string]string, len(values))
+	}
+
+	maps.Copy(current, values)
+
+	if err := unstructured.SetNestedStringMap(obj.Object, current, path...); err != nil {
+		return fmt.Errorf("unable to set %v: %w", path, err)
+	}
+
+	return nil
+}
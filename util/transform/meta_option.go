@@ -0,0 +1,43 @@
+package transform
+
+import (
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// MetaOption is a generic option for CommonLabels and CommonAnnotations.
+type MetaOption = util.Option[MetaOptions]
+
+// MetaOptions is a struct-based option that can set multiple propagation options at once.
+type MetaOptions struct {
+	// SkipSelectors disables the propagation of common labels into label selectors.
+	SkipSelectors bool
+
+	// SkipTemplates disables the propagation into pod and job templates.
+	SkipTemplates bool
+}
+
+// ApplyTo applies the propagation options to the target configuration.
+func (opts MetaOptions) ApplyTo(target *MetaOptions) {
+	if opts.SkipSelectors {
+		target.SkipSelectors = true
+	}
+	if opts.SkipTemplates {
+		target.SkipTemplates = true
+	}
+}
+
+// WithSelectorPropagation enables or disables the propagation of common labels
+// into label selectors. Propagation is enabled by default.
+func WithSelectorPropagation(enabled bool) MetaOption {
+	return util.FunctionalOption[MetaOptions](func(opts *MetaOptions) {
+		opts.SkipSelectors = !enabled
+	})
+}
+
+// WithTemplatePropagation enables or disables the propagation of common labels
+// and annotations into pod and job templates. Propagation is enabled by default.
+func WithTemplatePropagation(enabled bool) MetaOption {
+	return util.FunctionalOption[MetaOptions](func(opts *MetaOptions) {
+		opts.SkipTemplates = !enabled
+	})
+}
@@ -0,0 +1,178 @@
+package transform_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+
+	. "github.com/onsi/gomega"
+)
+
+const testMetaYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  schedule: "0 * * * *"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: cleanup
+            image: busybox
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: external
+spec:
+  type: ExternalName
+  externalName: example.com
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`
+
+var testCommonValues = map[string]string{"team": "platform"}
+
+func nestedStringMap(g *WithT, obj unstructured.Unstructured, path ...string) map[string]string {
+	result, _, err := unstructured.NestedStringMap(obj.Object, path...)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return result
+}
+
+func TestCommonLabels(t *testing.T) {
+	t.Run("adds labels to every object", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.CommonLabels(testCommonValues).Transform(t.Context(), decodeObjects(g, testMetaYAML))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		for _, obj := range result {
+			g.Expect(obj.GetLabels()).Should(HaveKeyWithValue("team", "platform"))
+		}
+
+		g.Expect(result[0].GetLabels()).Should(HaveKeyWithValue("app", "web"))
+	})
+
+	t.Run("propagates into templates and selectors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.CommonLabels(testCommonValues).Transform(t.Context(), decodeObjects(g, testMetaYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		deployment := result[0]
+		g.Expect(nestedStringMap(g, deployment, "spec", "template", "metadata", "labels")).Should(Equal(map[string]string{
+			"app":  "web",
+			"team": "platform",
+		}))
+		g.Expect(nestedStringMap(g, deployment, "spec", "selector", "matchLabels")).Should(HaveKeyWithValue("team", "platform"))
+
+		cronJob := result[1]
+		g.Expect(nestedStringMap(g, cronJob, "spec", "jobTemplate", "metadata", "labels")).Should(HaveKeyWithValue("team", "platform"))
+		g.Expect(nestedStringMap(g, cronJob, "spec", "jobTemplate", "spec", "template", "metadata", "labels")).Should(HaveKeyWithValue("team", "platform"))
+
+		service := result[2]
+		g.Expect(nestedStringMap(g, service, "spec", "selector")).Should(HaveKeyWithValue("team", "platform"))
+	})
+
+	t.Run("does not create missing selectors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.CommonLabels(testCommonValues).Transform(t.Context(), decodeObjects(g, testMetaYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, found, err := unstructured.NestedFieldNoCopy(result[3].Object, "spec", "selector")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(found).Should(BeFalse())
+	})
+
+	t.Run("skips selectors when propagation is disabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.CommonLabels(testCommonValues, transform.WithSelectorPropagation(false))
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testMetaYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(nestedStringMap(g, result[0], "spec", "selector", "matchLabels")).ShouldNot(HaveKey("team"))
+		g.Expect(nestedStringMap(g, result[2], "spec", "selector")).ShouldNot(HaveKey("team"))
+		g.Expect(nestedStringMap(g, result[0], "spec", "template", "metadata", "labels")).Should(HaveKey("team"))
+	})
+
+	t.Run("skips templates with struct-based options", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.CommonLabels(testCommonValues, transform.MetaOptions{SkipTemplates: true, SkipSelectors: true})
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testMetaYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(result[0].GetLabels()).Should(HaveKey("team"))
+		g.Expect(nestedStringMap(g, result[0], "spec", "template", "metadata", "labels")).ShouldNot(HaveKey("team"))
+		g.Expect(nestedStringMap(g, result[0], "spec", "selector", "matchLabels")).ShouldNot(HaveKey("team"))
+	})
+}
+
+func TestCommonAnnotations(t *testing.T) {
+	t.Run("adds annotations to objects and templates only", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.CommonAnnotations(testCommonValues).Transform(t.Context(), decodeObjects(g, testMetaYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		for _, obj := range result {
+			g.Expect(obj.GetAnnotations()).Should(HaveKeyWithValue("team", "platform"))
+		}
+
+		g.Expect(nestedStringMap(g, result[0], "spec", "template", "metadata", "annotations")).Should(HaveKeyWithValue("team", "platform"))
+		g.Expect(nestedStringMap(g, result[0], "spec", "selector", "matchLabels")).ShouldNot(HaveKey("team"))
+		g.Expect(nestedStringMap(g, result[2], "spec", "selector")).ShouldNot(HaveKey("team"))
+	})
+
+	t.Run("skips templates when propagation is disabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.CommonAnnotations(testCommonValues, transform.WithTemplatePropagation(false))
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testMetaYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(result[0].GetAnnotations()).Should(HaveKey("team"))
+		g.Expect(nestedStringMap(g, result[0], "spec", "template", "metadata", "annotations")).Should(BeNil())
+	})
+}
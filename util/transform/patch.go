@@ -0,0 +1,184 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	"gopkg.in/yaml.v3"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	"github.com/k8s-manifest-kit/pkg/util/maps"
+)
+
+// PatchType is the format of a patch.
+type PatchType string
+
+const (
+	// PatchTypeStrategicMerge is a Kubernetes strategic merge patch.
+	PatchTypeStrategicMerge PatchType = "strategic"
+
+	// PatchTypeMerge is an RFC 7386 JSON merge patch.
+	PatchTypeMerge PatchType = "merge"
+
+	// PatchTypeJSON6902 is an RFC 6902 JSON patch.
+	PatchTypeJSON6902 PatchType = "json6902"
+)
+
+// ErrUnknownPatchType is returned when a patch has an unsupported type.
+var ErrUnknownPatchType = errors.New("unknown patch type")
+
+// PatchSpec describes a patch and the objects it applies to.
+type PatchSpec struct {
+	// Target selects the objects to patch.
+	Target Selector
+
+	// Patch is the patch content, in YAML or JSON.
+	Patch []byte
+
+	// Type is the format of the patch.
+	Type PatchType
+}
+
+// Patch returns a Transformer applying a single patch to the objects selected
+// by target. The patch content may be YAML or JSON.
+//
+// Example:
+//
+//	transform.Patch(
+//	    transform.Selector{Group: "apps", Kind: "Deployment"},
+//	    []byte(`{"spec":{"template":{"spec":{"tolerations":[{"key":"dedicated","operator":"Exists"}]}}}}`),
+//	    transform.PatchTypeStrategicMerge,
+//	)
+func Patch(target Selector, patch []byte, patchType PatchType) Transformer {
+	return Patches(PatchSpec{Target: target, Patch: patch, Type: patchType})
+}
+
+// Patches returns a Transformer applying the given patches. Objects are
+// processed in order and, for each object, matching patches are applied in
+// the order they are given, so the result is deterministic.
+//
+// Strategic merge patches use the merge keys of the built-in Kubernetes types
+// (containers and volumes by name, container ports by containerPort, ...);
+// lists without a known merge key, and all lists of other types such as
+// custom resources, are replaced, as with a JSON merge patch.
+func Patches(specs ...PatchSpec) Transformer {
+	patchers := make([]patcher, 0, len(specs))
+
+	for i, spec := range specs {
+		p, err := newPatcher(spec)
+		if err != nil {
+			return Func(func(_ context.Context, _ []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+				return nil, fmt.Errorf("patch[%d]: %w", i, err)
+			})
+		}
+
+		patchers = append(patchers, p)
+	}
+
+	return ObjectFunc(func(_ context.Context, obj *unstructured.Unstructured) error {
+		for i, p := range patchers {
+			if !p.matches(obj) {
+				continue
+			}
+
+			patched, err := p.apply(obj.Object)
+			if err != nil {
+				return fmt.Errorf("patch[%d]: %w", i, err)
+			}
+
+			obj.Object = patched
+		}
+
+		return nil
+	})
+}
+
+type patcher struct {
+	matches func(obj *unstructured.Unstructured) bool
+	apply   func(obj map[string]any) (map[string]any, error)
+}
+
+func newPatcher(spec PatchSpec) (patcher, error) {
+	matches, err := spec.Target.Matcher()
+	if err != nil {
+		return patcher{}, err
+	}
+
+	// Patches are normalized to JSON so that YAML and JSON content behave
+	// the same and numbers use the int64/float64 types of unstructured.
+	var content any
+	if err := yaml.Unmarshal(spec.Patch, &content); err != nil {
+		return patcher{}, fmt.Errorf("unable to decode patch: %w", err)
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return patcher{}, fmt.Errorf("unable to encode patch: %w", err)
+	}
+
+	p := patcher{matches: matches}
+
+	switch spec.Type {
+	case PatchTypeStrategicMerge:
+		patch := make(map[string]any)
+		if err := utiljson.Unmarshal(data, &patch); err != nil {
+			return patcher{}, fmt.Errorf("strategic merge patch must be an object: %w", err)
+		}
+
+		p.apply = func(obj map[string]any) (map[string]any, error) {
+			// The patch is cloned so that patched objects never share values.
+			result, err := strategicpatch.StrategicMergeMapPatchUsingLookupPatchMeta(obj, maps.DeepCloneMap(patch), patchMetaFor(obj))
+			if err != nil {
+				return nil, fmt.Errorf("unable to apply strategic merge patch: %w", err)
+			}
+
+			return result, nil
+		}
+	case PatchTypeMerge:
+		p.apply = func(obj map[string]any) (map[string]any, error) {
+			return applyJSON(obj, func(doc []byte) ([]byte, error) {
+				return jsonpatch.MergePatch(doc, data)
+			})
+		}
+	case PatchTypeJSON6902:
+		ops, err := jsonpatch.DecodePatch(data)
+		if err != nil {
+			return patcher{}, fmt.Errorf("unable to decode JSON patch: %w", err)
+		}
+
+		p.apply = func(obj map[string]any) (map[string]any, error) {
+			return applyJSON(obj, ops.Apply)
+		}
+	default:
+		return patcher{}, fmt.Errorf("%w: %q", ErrUnknownPatchType, spec.Type)
+	}
+
+	return p, nil
+}
+
+// applyJSON round trips obj through its JSON representation to apply a
+// byte-oriented patch function.
+func applyJSON(obj map[string]any, fn func(doc []byte) ([]byte, error)) (map[string]any, error) {
+	doc, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode object: %w", err)
+	}
+
+	patched, err := fn(doc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply patch: %w", err)
+	}
+
+	u := unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(patched); err != nil {
+		return nil, fmt.Errorf("unable to decode patched object: %w", err)
+	}
+
+	return u.Object, nil
+}
@@ -0,0 +1,110 @@
+package transform
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// builtinGroups are the API groups of the built-in Kubernetes types, whose
+// lists are merged using mergeKeys.
+var builtinGroups = map[string]bool{
+	"":                             true,
+	"admissionregistration.k8s.io": true,
+	"apiextensions.k8s.io":         true,
+	"apiregistration.k8s.io":       true,
+	"apps":                         true,
+	"authentication.k8s.io":        true,
+	"authorization.k8s.io":         true,
+	"autoscaling":                  true,
+	"batch":                        true,
+	"certificates.k8s.io":          true,
+	"coordination.k8s.io":          true,
+	"discovery.k8s.io":             true,
+	"events.k8s.io":                true,
+	"flowcontrol.apiserver.k8s.io": true,
+	"networking.k8s.io":            true,
+	"node.k8s.io":                  true,
+	"policy":                       true,
+	"rbac.authorization.k8s.io":    true,
+	"resource.k8s.io":              true,
+	"scheduling.k8s.io":            true,
+	"storage.k8s.io":               true,
+}
+
+// containerKeys are the fields holding lists of containers, used to tell
+// container ports apart from Service ports.
+var containerKeys = map[string]bool{
+	"containers":          true,
+	"initContainers":      true,
+	"ephemeralContainers": true,
+}
+
+// mergeKeys maps list fields to the merge key declared by the built-in
+// Kubernetes types. Fields not listed here are replaced as a whole.
+var mergeKeys = map[string]string{
+	"containers":          "name",
+	"initContainers":      "name",
+	"ephemeralContainers": "name",
+	"env":                 "name",
+	"volumes":             "name",
+	"volumeMounts":        "mountPath",
+	"volumeDevices":       "devicePath",
+	"imagePullSecrets":    "name",
+	"hostAliases":         "ip",
+	"resourceClaims":      "name",
+	"conditions":          "type",
+	"ownerReferences":     "uid",
+}
+
+// builtinPatchMeta provides strategic merge patch metadata for unstructured
+// objects without requiring the typed Kubernetes API. The lookup is keyed by
+// field name and the name of the enclosing field, which is enough to resolve
+// the merge keys of the built-in types. For other types, such as custom
+// resources, no merge keys are declared and lists are replaced, as with a
+// JSON merge patch.
+type builtinPatchMeta struct {
+	name   string
+	custom bool
+}
+
+// patchMetaFor returns the patch metadata for obj, declaring merge keys only
+// if obj is of a built-in type.
+func patchMetaFor(obj map[string]any) builtinPatchMeta {
+	u := unstructured.Unstructured{Object: obj}
+
+	return builtinPatchMeta{custom: !builtinGroups[u.GroupVersionKind().Group]}
+}
+
+var _ strategicpatch.LookupPatchMeta = builtinPatchMeta{}
+
+func (m builtinPatchMeta) LookupPatchMetadataForStruct(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	return builtinPatchMeta{name: key, custom: m.custom}, strategicpatch.PatchMeta{}, nil
+}
+
+func (m builtinPatchMeta) LookupPatchMetadataForSlice(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	meta := strategicpatch.PatchMeta{}
+	next := builtinPatchMeta{name: key, custom: m.custom}
+
+	if m.custom {
+		return next, meta, nil
+	}
+
+	mergeKey := mergeKeys[key]
+	if key == "ports" {
+		mergeKey = "port"
+		if containerKeys[m.name] {
+			mergeKey = "containerPort"
+		}
+	}
+
+	if mergeKey != "" {
+		meta.SetPatchStrategies([]string{"merge"})
+		meta.SetPatchMergeKey(mergeKey)
+	}
+
+	return next, meta, nil
+}
+
+func (m builtinPatchMeta) Name() string {
+	return m.name
+}
@@ -0,0 +1,240 @@
+package transform_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+
+	. "github.com/onsi/gomega"
+)
+
+const testPatchYAML = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+  template:
+    spec:
+      tolerations:
+      - key: old
+        operator: Exists
+      containers:
+      - name: web
+        image: nginx:1.25
+        ports:
+        - containerPort: 80
+          name: http
+      - name: sidecar
+        image: envoy
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+    name: http
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: value
+`
+
+const testTolerationsPatch = `
+spec:
+  template:
+    spec:
+      tolerations:
+      - key: dedicated
+        operator: Exists
+      containers:
+      - name: web
+        image: nginx:1.26
+        ports:
+        - containerPort: 8443
+          name: https
+`
+
+const testServicePortsPatch = `
+spec:
+  ports:
+  - port: 443
+    name: https
+`
+
+const testServiceEntryYAML = `
+apiVersion: networking.istio.io/v1
+kind: ServiceEntry
+metadata:
+  name: external
+spec:
+  hosts:
+  - api.example.com
+  ports:
+  - number: 80
+    name: http
+    protocol: HTTP
+`
+
+const testServiceEntryPatch = `
+spec:
+  ports:
+  - number: 443
+    name: https
+    protocol: TLS
+`
+
+const testMergePatch = `{"spec":{"replicas":3,"template":{"spec":{"containers":[{"name":"only","image":"busybox"}]}}}}`
+
+const testJSON6902Patch = `
+- op: replace
+  path: /spec/replicas
+  value: 5
+- op: add
+  path: /metadata/annotations
+  value:
+    patched: "true"
+`
+
+const testReplicasTwoPatch = `[{"op":"replace","path":"/spec/replicas","value":2}]`
+
+const testReplicasTestPatch = `[{"op":"test","path":"/spec/replicas","value":2},{"op":"replace","path":"/spec/replicas","value":4}]`
+
+const testInvalidJSON6902Patch = `[{"op":"replace","path":"/missing/field","value":1}]`
+
+var testDeploymentSelector = transform.Selector{Group: "apps", Kind: "Deployment"}
+
+func TestPatch(t *testing.T) {
+	t.Run("applies strategic merge patches using built-in merge keys", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Patch(testDeploymentSelector, []byte(testTolerationsPatch), transform.PatchTypeStrategicMerge)
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testPatchYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		tolerations, _, err := unstructured.NestedSlice(result[0].Object, "spec", "template", "spec", "tolerations")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(tolerations).Should(HaveLen(1))
+		g.Expect(tolerations[0]).Should(HaveKeyWithValue("key", "dedicated"))
+
+		containers, _, err := unstructured.NestedSlice(result[0].Object, "spec", "template", "spec", "containers")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(containers).Should(HaveLen(2))
+
+		web := containers[0].(map[string]any)
+		g.Expect(web).Should(HaveKeyWithValue("image", "nginx:1.26"))
+		g.Expect(web["ports"]).Should(HaveLen(2))
+		g.Expect(containers[1]).Should(HaveKeyWithValue("name", "sidecar"))
+	})
+
+	t.Run("merges Service ports by port", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Patch(transform.Selector{Kind: "Service"}, []byte(testServicePortsPatch), transform.PatchTypeStrategicMerge)
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testPatchYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		ports, _, err := unstructured.NestedSlice(result[1].Object, "spec", "ports")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ports).Should(HaveLen(2))
+	})
+
+	t.Run("replaces lists of custom resources", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Patch(transform.Selector{Kind: "ServiceEntry"}, []byte(testServiceEntryPatch), transform.PatchTypeStrategicMerge)
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testServiceEntryYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		ports, _, err := unstructured.NestedSlice(result[0].Object, "spec", "ports")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ports).Should(HaveLen(1))
+		g.Expect(ports[0]).Should(HaveKeyWithValue("number", int64(443)))
+
+		hosts, _, err := unstructured.NestedStringSlice(result[0].Object, "spec", "hosts")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(hosts).Should(Equal([]string{"api.example.com"}))
+	})
+
+	t.Run("applies JSON merge patches replacing lists", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Patch(testDeploymentSelector, []byte(testMergePatch), transform.PatchTypeMerge)
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testPatchYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		replicas, _, err := unstructured.NestedInt64(result[0].Object, "spec", "replicas")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(replicas).Should(Equal(int64(3)))
+
+		containers, _, err := unstructured.NestedSlice(result[0].Object, "spec", "template", "spec", "containers")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(containers).Should(HaveLen(1))
+	})
+
+	t.Run("applies JSON 6902 patches only to selected objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Patch(testDeploymentSelector, []byte(testJSON6902Patch), transform.PatchTypeJSON6902)
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testPatchYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		replicas, _, err := unstructured.NestedInt64(result[0].Object, "spec", "replicas")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(replicas).Should(Equal(int64(5)))
+		g.Expect(result[0].GetAnnotations()).Should(HaveKeyWithValue("patched", "true"))
+		g.Expect(result[1].GetAnnotations()).Should(BeEmpty())
+		g.Expect(result[2].GetAnnotations()).Should(BeEmpty())
+	})
+
+	t.Run("fails when a JSON 6902 patch does not apply", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Patch(testDeploymentSelector, []byte(testInvalidJSON6902Patch), transform.PatchTypeJSON6902)
+
+		_, err := tr.Transform(t.Context(), decodeObjects(g, testPatchYAML))
+
+		g.Expect(err).Should(HaveOccurred())
+	})
+
+	t.Run("reports unknown patch types", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Patch(testDeploymentSelector, []byte(testMergePatch), "unknown")
+
+		_, err := tr.Transform(t.Context(), decodeObjects(g, testPatchYAML))
+
+		g.Expect(err).Should(MatchError(transform.ErrUnknownPatchType))
+	})
+}
+
+func TestPatches(t *testing.T) {
+	t.Run("applies patches in order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Patches(
+			transform.PatchSpec{Target: testDeploymentSelector, Patch: []byte(testReplicasTwoPatch), Type: transform.PatchTypeJSON6902},
+			transform.PatchSpec{Target: testDeploymentSelector, Patch: []byte(testReplicasTestPatch), Type: transform.PatchTypeJSON6902},
+		)
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testPatchYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		replicas, _, err := unstructured.NestedInt64(result[0].Object, "spec", "replicas")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(replicas).Should(Equal(int64(4)))
+	})
+}
@@ -0,0 +1,188 @@
+package transform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/maps"
+)
+
+const defaultSourceFieldPath = "metadata.name"
+
+// ErrReplacementSource is returned when a replacement source does not select
+// exactly one object, or the selected object lacks the source field.
+var ErrReplacementSource = errors.New("invalid replacement source")
+
+// Replacement copies a value from a field of a source object into fields of
+// target objects, like kustomize replacements.
+//
+// Example, injecting a generated ConfigMap name into a Deployment:
+//
+//	transform.Replacements(transform.Replacement{
+//	    Source: transform.ReplacementSource{
+//	        Selector: transform.Selector{Kind: "ConfigMap", LabelSelector: "app=web"},
+//	    },
+//	    Targets: []transform.ReplacementTarget{{
+//	        Selector:   transform.Selector{Group: "apps", Kind: "Deployment", Name: "web"},
+//	        FieldPaths: []string{"spec.template.spec.containers.[name=web].env.[name=CONFIG].valueFrom.configMapKeyRef.name"},
+//	        Create:     true,
+//	    }},
+//	})
+type Replacement struct {
+	Source  ReplacementSource
+	Targets []ReplacementTarget
+}
+
+// ReplacementSource identifies the value copied by a Replacement.
+type ReplacementSource struct {
+	// Selector must select exactly one object.
+	Selector Selector
+
+	// FieldPath is the path of the copied value; defaults to metadata.name.
+	FieldPath string
+}
+
+// ReplacementTarget identifies the fields receiving the value of a Replacement.
+//
+// Field paths are dot-separated and support list indexes ("ports.0.port")
+// and list elements selected by field value ("containers.[name=web].image").
+type ReplacementTarget struct {
+	// Selector selects the target objects.
+	Selector Selector
+
+	// Reject excludes objects otherwise selected by Selector.
+	Reject []Selector
+
+	// FieldPaths are the paths receiving the value.
+	FieldPaths []string
+
+	// Create creates missing fields and list elements, appending an element
+	// for an index equal to the length of a list; when false, missing fields
+	// are left untouched.
+	Create bool
+}
+
+// Replacements returns a Transformer applying the given replacements in order,
+// so that a replacement sees the values set by the previous ones.
+func Replacements(rules ...Replacement) Transformer {
+	return Func(func(_ context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		for i, rule := range rules {
+			if err := applyReplacement(objects, rule); err != nil {
+				return nil, fmt.Errorf("replacement[%d]: %w", i, err)
+			}
+		}
+
+		return objects, nil
+	})
+}
+
+func applyReplacement(objects []unstructured.Unstructured, rule Replacement) error {
+	value, err := sourceValue(objects, rule.Source)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range rule.Targets {
+		if err := applyReplacementTarget(objects, target, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sourceValue(objects []unstructured.Unstructured, source ReplacementSource) (any, error) {
+	matches, err := source.Selector.Matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	fieldPath := source.FieldPath
+	if fieldPath == "" {
+		fieldPath = defaultSourceFieldPath
+	}
+
+	segments, err := parseFieldPath(fieldPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *unstructured.Unstructured
+	for i := range objects {
+		if !matches(&objects[i]) {
+			continue
+		}
+
+		if selected != nil {
+			return nil, fmt.Errorf("%w: %+v selects multiple objects", ErrReplacementSource, source.Selector)
+		}
+
+		selected = &objects[i]
+	}
+
+	if selected == nil {
+		return nil, fmt.Errorf("%w: %+v selects no object", ErrReplacementSource, source.Selector)
+	}
+
+	value, found := getField(selected.Object, segments)
+	if !found {
+		return nil, fmt.Errorf("%w: field %q not found in %s %s", ErrReplacementSource, fieldPath, selected.GetKind(), selected.GetName())
+	}
+
+	return value, nil
+}
+
+func applyReplacementTarget(objects []unstructured.Unstructured, target ReplacementTarget, value any) error {
+	matches, err := target.Selector.Matcher()
+	if err != nil {
+		return err
+	}
+
+	rejects := make([]func(*unstructured.Unstructured) bool, 0, len(target.Reject))
+	for _, r := range target.Reject {
+		reject, err := r.Matcher()
+		if err != nil {
+			return err
+		}
+
+		rejects = append(rejects, reject)
+	}
+
+	paths := make([][]pathSegment, 0, len(target.FieldPaths))
+	for _, fp := range target.FieldPaths {
+		segments, err := parseFieldPath(fp)
+		if err != nil {
+			return err
+		}
+
+		paths = append(paths, segments)
+	}
+
+	for i := range objects {
+		obj := &objects[i]
+		if !matches(obj) || rejected(obj, rejects) {
+			continue
+		}
+
+		for j, segments := range paths {
+			if _, err := setField(obj.Object, segments, maps.DeepCloneValue(value), target.Create); err != nil {
+				return fmt.Errorf("unable to set %q in %s %s: %w", target.FieldPaths[j], obj.GetKind(), obj.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func rejected(obj *unstructured.Unstructured, rejects []func(*unstructured.Unstructured) bool) bool {
+	for _, reject := range rejects {
+		if reject(obj) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,304 @@
+package transform_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+
+	. "github.com/onsi/gomega"
+)
+
+const testReplacementsYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config-5f6g7h
+  labels:
+    app: web
+data:
+  port: "8080"
+  app.properties: mode=fast
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx
+        env:
+        - name: LOG_LEVEL
+          value: info
+      - name: sidecar
+        image: envoy
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  template:
+    spec:
+      containers:
+      - name: worker
+        image: busybox
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+    targetPort: 80
+`
+
+const (
+	testConfigRefPath = "spec.template.spec.containers.[name=web].env.[name=CONFIG].valueFrom.configMapKeyRef.name"
+	testEnvValuePath  = "spec.template.spec.containers.0.env.[name=LOG_LEVEL].value"
+	testConfigName    = "web-config-5f6g7h"
+)
+
+var testConfigSource = transform.ReplacementSource{
+	Selector: transform.Selector{Kind: "ConfigMap", LabelSelector: "app=web"},
+}
+
+func TestReplacements(t *testing.T) {
+	t.Run("injects a source name creating missing fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: testConfigSource,
+			Targets: []transform.ReplacementTarget{{
+				Selector:   transform.Selector{Kind: "Deployment"},
+				FieldPaths: []string{testConfigRefPath},
+				Create:     true,
+			}},
+		})
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		env, _, err := unstructured.NestedSlice(result[1].Object, "spec", "template", "spec", "containers")
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		webEnv := env[0].(map[string]any)["env"].([]any)
+		g.Expect(webEnv).Should(HaveLen(2))
+		g.Expect(webEnv[1]).Should(Equal(map[string]any{
+			"name": "CONFIG",
+			"valueFrom": map[string]any{
+				"configMapKeyRef": map[string]any{"name": testConfigName},
+			},
+		}))
+
+		// The worker Deployment has no "web" container, which gets created.
+		workerContainers, _, err := unstructured.NestedSlice(result[2].Object, "spec", "template", "spec", "containers")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(workerContainers).Should(HaveLen(2))
+	})
+
+	t.Run("leaves missing fields untouched without create", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: testConfigSource,
+			Targets: []transform.ReplacementTarget{{
+				Selector:   transform.Selector{Kind: "Deployment"},
+				FieldPaths: []string{testConfigRefPath, testEnvValuePath},
+			}},
+		})
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		containers, _, err := unstructured.NestedSlice(result[1].Object, "spec", "template", "spec", "containers")
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		webEnv := containers[0].(map[string]any)["env"].([]any)
+		g.Expect(webEnv).Should(HaveLen(1))
+		g.Expect(webEnv[0]).Should(HaveKeyWithValue("value", testConfigName))
+
+		workerContainers, _, err := unstructured.NestedSlice(result[2].Object, "spec", "template", "spec", "containers")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(workerContainers).Should(HaveLen(1))
+	})
+
+	t.Run("copies arbitrary source fields and honors rejects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: transform.ReplacementSource{
+				Selector:  transform.Selector{Kind: "ConfigMap"},
+				FieldPath: "data.port",
+			},
+			Targets: []transform.ReplacementTarget{{
+				Selector:   transform.Selector{Kind: "Deployment"},
+				Reject:     []transform.Selector{{Name: "worker"}},
+				FieldPaths: []string{"metadata.annotations.port"},
+				Create:     true,
+			}},
+		})
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(result[1].GetAnnotations()).Should(HaveKeyWithValue("port", "8080"))
+		g.Expect(result[2].GetAnnotations()).Should(BeEmpty())
+	})
+
+	t.Run("copies structured values without sharing them", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: transform.ReplacementSource{
+				Selector:  transform.Selector{Kind: "ConfigMap"},
+				FieldPath: "metadata.labels",
+			},
+			Targets: []transform.ReplacementTarget{{
+				Selector:   transform.Selector{Kind: "Service"},
+				FieldPaths: []string{"spec.selector"},
+				Create:     true,
+			}},
+		})
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		result[3].Object["spec"].(map[string]any)["selector"].(map[string]any)["app"] = "changed"
+		g.Expect(result[0].GetLabels()).Should(HaveKeyWithValue("app", "web"))
+	})
+
+	t.Run("addresses keys and selector values containing dots", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: transform.ReplacementSource{
+				Selector:  transform.Selector{Kind: "ConfigMap"},
+				FieldPath: `data.app\.properties`,
+			},
+			Targets: []transform.ReplacementTarget{{
+				Selector: transform.Selector{Kind: "Deployment", Name: "web"},
+				FieldPaths: []string{
+					"metadata.annotations.[example.io/app.properties]",
+					"spec.template.spec.containers.[name=web].env.[name=APP.PROPERTIES].value",
+				},
+				Create: true,
+			}},
+		})
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(result[1].GetAnnotations()).Should(HaveKeyWithValue("example.io/app.properties", "mode=fast"))
+
+		containers, _, err := unstructured.NestedSlice(result[1].Object, "spec", "template", "spec", "containers")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(containers[0].(map[string]any)["env"]).Should(ContainElement(map[string]any{
+			"name":  "APP.PROPERTIES",
+			"value": "mode=fast",
+		}))
+	})
+
+	t.Run("appends list elements at the end of a list", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: transform.ReplacementSource{
+				Selector:  transform.Selector{Kind: "ConfigMap"},
+				FieldPath: "data.port",
+			},
+			Targets: []transform.ReplacementTarget{{
+				Selector:   transform.Selector{Kind: "Service"},
+				FieldPaths: []string{"spec.ports.1.name"},
+				Create:     true,
+			}},
+		})
+
+		result, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		ports, _, err := unstructured.NestedSlice(result[3].Object, "spec", "ports")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ports).Should(HaveLen(2))
+		g.Expect(ports[1]).Should(Equal(map[string]any{"name": "8080"}))
+	})
+
+	t.Run("rejects indexes past the end of a list", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: testConfigSource,
+			Targets: []transform.ReplacementTarget{{
+				Selector:   transform.Selector{Kind: "Service"},
+				FieldPaths: []string{"spec.ports.3.name"},
+				Create:     true,
+			}},
+		})
+
+		_, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+
+		g.Expect(err).Should(MatchError(transform.ErrInvalidFieldPath))
+	})
+
+	t.Run("rejects sources selecting no object", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: transform.ReplacementSource{Selector: transform.Selector{Kind: "Secret"}},
+		})
+
+		_, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+
+		g.Expect(err).Should(MatchError(transform.ErrReplacementSource))
+	})
+
+	t.Run("rejects sources selecting multiple objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: transform.ReplacementSource{Selector: transform.Selector{Kind: "Deployment"}},
+		})
+
+		_, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+
+		g.Expect(err).Should(MatchError(transform.ErrReplacementSource))
+	})
+
+	t.Run("rejects missing source fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.Replacements(transform.Replacement{
+			Source: transform.ReplacementSource{
+				Selector:  transform.Selector{Kind: "ConfigMap"},
+				FieldPath: "data.missing",
+			},
+		})
+
+		_, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+
+		g.Expect(err).Should(MatchError(transform.ErrReplacementSource))
+	})
+
+	t.Run("rejects invalid field paths", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for _, path := range []string{"spec..template", "spec.", "metadata.labels.[app", "metadata.labels.[]", "metadata.labels.[a]b"} {
+			tr := transform.Replacements(transform.Replacement{
+				Source: testConfigSource,
+				Targets: []transform.ReplacementTarget{{
+					Selector:   transform.Selector{Kind: "Deployment"},
+					FieldPaths: []string{path},
+				}},
+			})
+
+			_, err := tr.Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+
+			g.Expect(err).Should(MatchError(transform.ErrInvalidFieldPath), path)
+		}
+	})
+}
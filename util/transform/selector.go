@@ -0,0 +1,55 @@
+package transform
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Selector selects the objects a transformer applies to.
+// Empty fields match any value; all non-empty fields must match.
+type Selector struct {
+	Group     string
+	Version   string
+	Kind      string
+	Namespace string
+	Name      string
+
+	// LabelSelector is a label selector expression such as "app=web,tier!=db".
+	LabelSelector string
+}
+
+// Matcher returns a function reporting whether an object is selected, or an
+// error if the label selector cannot be parsed.
+func (s Selector) Matcher() (func(obj *unstructured.Unstructured) bool, error) {
+	selector := labels.Everything()
+
+	if s.LabelSelector != "" {
+		parsed, err := labels.Parse(s.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q: %w", s.LabelSelector, err)
+		}
+
+		selector = parsed
+	}
+
+	return func(obj *unstructured.Unstructured) bool {
+		gvk := obj.GroupVersionKind()
+
+		switch {
+		case s.Group != "" && s.Group != gvk.Group:
+			return false
+		case s.Version != "" && s.Version != gvk.Version:
+			return false
+		case s.Kind != "" && s.Kind != gvk.Kind:
+			return false
+		case s.Namespace != "" && s.Namespace != obj.GetNamespace():
+			return false
+		case s.Name != "" && s.Name != obj.GetName():
+			return false
+		default:
+			return selector.Matches(labels.Set(obj.GetLabels()))
+		}
+	}, nil
+}
@@ -0,0 +1,75 @@
+package transform_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+
+	. "github.com/onsi/gomega"
+)
+
+func testSelectorObject() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":      "web",
+			"namespace": "apps",
+			"labels":    map[string]any{"tier": "frontend"},
+		},
+	}}
+}
+
+func TestSelector(t *testing.T) {
+	t.Run("empty selector matches everything", func(t *testing.T) {
+		g := NewWithT(t)
+
+		matches, err := transform.Selector{}.Matcher()
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(matches(testSelectorObject())).Should(BeTrue())
+	})
+
+	t.Run("matches all non-empty fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		matches, err := transform.Selector{
+			Group:         "apps",
+			Version:       "v1",
+			Kind:          "Deployment",
+			Namespace:     "apps",
+			Name:          "web",
+			LabelSelector: "tier=frontend",
+		}.Matcher()
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(matches(testSelectorObject())).Should(BeTrue())
+	})
+
+	t.Run("rejects mismatching fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for _, s := range []transform.Selector{
+			{Group: "batch"},
+			{Version: "v1beta1"},
+			{Kind: "StatefulSet"},
+			{Namespace: "other"},
+			{Name: "api"},
+			{LabelSelector: "tier!=frontend"},
+		} {
+			matches, err := s.Matcher()
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(matches(testSelectorObject())).Should(BeFalse(), "selector %+v", s)
+		}
+	})
+
+	t.Run("reports invalid label selectors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := transform.Selector{LabelSelector: "tier in (a"}.Matcher()
+
+		g.Expect(err).Should(HaveOccurred())
+	})
+}
@@ -0,0 +1,20 @@
+package transform
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// SortForApply returns a Transformer sorting the rendered set into apply order
+// (see k8s.SortForApply), so that consumers can apply the result in sequence
+// without re-sorting. It is meant to be the last stage of a pipeline.
+func SortForApply() Transformer {
+	return Func(func(_ context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		k8s.SortForApply(objects)
+
+		return objects, nil
+	})
+}
@@ -0,0 +1,31 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSortForApply(t *testing.T) {
+	t.Run("sorts the rendered set into apply order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.SortForApply().Transform(t.Context(), decodeObjects(g, testReplacementsYAML))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(4))
+
+		order := make([]string, 0, len(result))
+		for _, obj := range result {
+			order = append(order, obj.GetKind()+"/"+obj.GetName())
+		}
+
+		g.Expect(order).Should(Equal([]string{
+			"ConfigMap/web-config-5f6g7h",
+			"Service/web",
+			"Deployment/web",
+			"Deployment/worker",
+		}))
+	})
+}
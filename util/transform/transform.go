@@ -0,0 +1,81 @@
+// Package transform provides the transformer pipeline API used to post-process
+// rendered objects (labels, namespaces, patches, filters, ...) before they are
+// cached or returned to the caller.
+package transform
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Transformer transforms a set of rendered objects.
+//
+// Transformers operate on the whole rendered set so that they can add, remove,
+// reorder, or cross-reference objects. Implementations may mutate the objects
+// they receive; callers that need the original objects must clone them first.
+type Transformer interface {
+	Transform(ctx context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error)
+}
+
+// Func adapts a plain function to the Transformer interface.
+type Func func(ctx context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error)
+
+// Transform calls f(ctx, objects).
+func (f Func) Transform(ctx context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	return f(ctx, objects)
+}
+
+// ObjectFunc adapts a function mutating a single object in place to the
+// Transformer interface. The function is invoked for each object in order.
+type ObjectFunc func(ctx context.Context, object *unstructured.Unstructured) error
+
+// Transform calls f for each object, stopping at the first error.
+func (f ObjectFunc) Transform(ctx context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	for i := range objects {
+		if err := f(ctx, &objects[i]); err != nil {
+			return nil, fmt.Errorf("unable to transform %s %s: %w", objects[i].GroupVersionKind().Kind, objects[i].GetName(), err)
+		}
+	}
+
+	return objects, nil
+}
+
+// Chain returns a Transformer that applies the given transformers in order.
+// Nil transformers are skipped.
+func Chain(transformers ...Transformer) Transformer {
+	return Func(func(ctx context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		return Apply(ctx, objects, transformers...)
+	})
+}
+
+// Apply runs the given transformers in order, feeding the output of each one
+// into the next. Processing stops at the first error or when ctx is done.
+// Nil transformers are skipped.
+func Apply(
+	ctx context.Context,
+	objects []unstructured.Unstructured,
+	transformers ...Transformer,
+) ([]unstructured.Unstructured, error) {
+	result := objects
+
+	for i, t := range transformers {
+		if t == nil {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("transformer[%d]: %w", i, err)
+		}
+
+		out, err := t.Transform(ctx, result)
+		if err != nil {
+			return nil, fmt.Errorf("transformer[%d]: %w", i, err)
+		}
+
+		result = out
+	}
+
+	return result, nil
+}
@@ -0,0 +1,174 @@
+package transform_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testLabelKey   = "example.io/stage"
+	testLabelValue = "transformed"
+)
+
+var errTestTransform = errors.New("transform failed")
+
+func testObjects() []unstructured.Unstructured {
+	return []unstructured.Unstructured{
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "first"},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]any{"name": "second"},
+		}},
+	}
+}
+
+func decodeObjects(g *WithT, content string) []unstructured.Unstructured {
+	objects, err := k8s.DecodeYAML([]byte(content))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return objects
+}
+
+func appendName(suffix string) transform.Transformer {
+	return transform.ObjectFunc(func(_ context.Context, obj *unstructured.Unstructured) error {
+		obj.SetName(obj.GetName() + suffix)
+
+		return nil
+	})
+}
+
+func TestObjectFunc(t *testing.T) {
+	t.Run("mutates every object in place", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.ObjectFunc(func(_ context.Context, obj *unstructured.Unstructured) error {
+			obj.SetLabels(map[string]string{testLabelKey: testLabelValue})
+
+			return nil
+		})
+
+		result, err := tr.Transform(t.Context(), testObjects())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(2))
+		for _, obj := range result {
+			g.Expect(obj.GetLabels()).Should(HaveKeyWithValue(testLabelKey, testLabelValue))
+		}
+	})
+
+	t.Run("wraps errors with the failing object", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tr := transform.ObjectFunc(func(_ context.Context, obj *unstructured.Unstructured) error {
+			if obj.GetKind() == "Secret" {
+				return errTestTransform
+			}
+
+			return nil
+		})
+
+		_, err := tr.Transform(t.Context(), testObjects())
+
+		g.Expect(err).Should(MatchError(errTestTransform))
+		g.Expect(err.Error()).Should(ContainSubstring("Secret second"))
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("applies transformers in order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.Apply(t.Context(), testObjects(), appendName("-a"), nil, appendName("-b"))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result[0].GetName()).Should(Equal("first-a-b"))
+		g.Expect(result[1].GetName()).Should(Equal("second-a-b"))
+	})
+
+	t.Run("allows transformers to change the set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dropSecrets := transform.Func(func(_ context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+			out := make([]unstructured.Unstructured, 0, len(objects))
+			for _, obj := range objects {
+				if obj.GetKind() != "Secret" {
+					out = append(out, obj)
+				}
+			}
+
+			return out, nil
+		})
+
+		result, err := transform.Apply(t.Context(), testObjects(), dropSecrets)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveLen(1))
+		g.Expect(result[0].GetKind()).Should(Equal("ConfigMap"))
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		g := NewWithT(t)
+
+		called := false
+		failing := transform.Func(func(_ context.Context, _ []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+			return nil, errTestTransform
+		})
+		next := transform.Func(func(_ context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+			called = true
+
+			return objects, nil
+		})
+
+		_, err := transform.Apply(t.Context(), testObjects(), failing, next)
+
+		g.Expect(err).Should(MatchError(errTestTransform))
+		g.Expect(err.Error()).Should(ContainSubstring("transformer[0]"))
+		g.Expect(called).Should(BeFalse())
+	})
+
+	t.Run("honors context cancellation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := transform.Apply(ctx, testObjects(), appendName("-a"))
+
+		g.Expect(err).Should(MatchError(context.Canceled))
+	})
+
+	t.Run("returns input when no transformers are given", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := transform.Apply(t.Context(), testObjects())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(Equal(testObjects()))
+	})
+}
+
+func TestChain(t *testing.T) {
+	t.Run("composes transformers into one", func(t *testing.T) {
+		g := NewWithT(t)
+
+		chain := transform.Chain(appendName("-a"), transform.Chain(appendName("-b"), appendName("-c")))
+
+		result, err := chain.Transform(t.Context(), testObjects())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result[0].GetName()).Should(Equal("first-a-b-c"))
+	})
+}
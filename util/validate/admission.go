@@ -0,0 +1,169 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const (
+	// RuleAdmissionDenied identifies findings reporting objects the target
+	// cluster refuses to admit, e.g. because a validating webhook denies them.
+	RuleAdmissionDenied = "admission-denied"
+
+	// RuleAdmissionMutated identifies findings reporting fields of objects
+	// changed on admission, e.g. by a mutating webhook.
+	RuleAdmissionMutated = "admission-mutated"
+)
+
+// ErrAdmissionDenied is wrapped by DryRunFunc implementations when the target
+// cluster refuses an object.
+var ErrAdmissionDenied = errors.New("admission denied")
+
+// DryRunFunc submits an object to the target cluster with server-side dry-run,
+// so that it goes through admission, including validating and mutating
+// webhooks, without being persisted, and returns the object as admitted. It
+// returns an error wrapping ErrAdmissionDenied when the object is refused.
+// With client-go, it is typically implemented with a dynamic client:
+//
+//	func(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+//	    gvk := obj.GroupVersionKind()
+//	    mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    admitted, err := client.Resource(mapping.Resource).Namespace(obj.GetNamespace()).Apply(ctx, obj.GetName(), obj,
+//	        metav1.ApplyOptions{FieldManager: "preflight", Force: true, DryRun: []string{metav1.DryRunAll}})
+//	    if apierrors.IsForbidden(err) || apierrors.IsInvalid(err) {
+//	        return nil, fmt.Errorf("%w: %s", validate.ErrAdmissionDenied, apierrors.ReasonForError(err))
+//	    }
+//	    return admitted, err
+//	}
+type DryRunFunc func(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+
+// metadataMaps are the metadata fields whose added entries are reported as
+// mutations, since webhooks commonly inject labels and annotations.
+var metadataMaps = []string{"metadata.labels", "metadata.annotations"}
+
+// AdmissionDryRun returns a Validator submitting objects to the target
+// cluster with dryRun, reporting objects refused on admission as
+// RuleAdmissionDenied errors, and the fields of rendered objects changed on
+// admission as RuleAdmissionMutated info findings, so that webhook denials
+// and mutations surface before a real apply. The API server selects the
+// webhooks matching each object, as it does on apply.
+//
+// Only fields set in the rendered objects are compared, along with labels and
+// annotations, so that defaults filled in by the API server are not reported
+// as mutations; a list changing length, such as containers of an injected
+// sidecar, is reported as a whole.
+func AdmissionDryRun(dryRun DryRunFunc) Validator {
+	return Func(func(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+		var findings Findings
+
+		for i := range objects {
+			obj := &objects[i]
+			key := k8s.KeyOf(obj)
+
+			admitted, err := dryRun(ctx, obj)
+
+			switch {
+			case errors.Is(err, ErrAdmissionDenied):
+				findings = append(findings, Finding{
+					Rule:     RuleAdmissionDenied,
+					Severity: SeverityError,
+					Object:   key,
+					Message:  err.Error(),
+				})
+
+				continue
+			case err != nil:
+				return nil, fmt.Errorf("unable to dry-run %s: %w", key, err)
+			}
+
+			for _, path := range mutatedPaths("", obj.Object, admitted.Object, nil) {
+				findings = append(findings, Finding{
+					Rule:     RuleAdmissionMutated,
+					Severity: SeverityInfo,
+					Object:   key,
+					Path:     path,
+					Message:  "is changed on admission",
+				})
+			}
+		}
+
+		return findings, nil
+	})
+}
+
+// mutatedPaths appends to paths the paths of the fields set in rendered that
+// differ in admitted, and of the entries added to metadataMaps.
+func mutatedPaths(prefix string, rendered any, admitted any, paths []string) []string {
+	switch r := rendered.(type) {
+	case map[string]any:
+		a, ok := admitted.(map[string]any)
+		if !ok {
+			return append(paths, prefix)
+		}
+
+		for _, k := range comparedKeys(prefix, r, a) {
+			child := k
+			if prefix != "" {
+				child = prefix + "." + k
+			}
+
+			if _, ok := r[k]; !ok {
+				paths = append(paths, child)
+
+				continue
+			}
+
+			paths = mutatedPaths(child, r[k], a[k], paths)
+		}
+
+		return paths
+	case []any:
+		a, ok := admitted.([]any)
+		if !ok || len(a) != len(r) {
+			return append(paths, prefix)
+		}
+
+		for i := range r {
+			paths = mutatedPaths(prefix+"["+strconv.Itoa(i)+"]", r[i], a[i], paths)
+		}
+
+		return paths
+	case nil:
+		// Null fields are dropped on admission.
+		return paths
+	default:
+		if fmt.Sprint(rendered) != fmt.Sprint(admitted) {
+			return append(paths, prefix)
+		}
+
+		return paths
+	}
+}
+
+// comparedKeys returns the sorted keys of the rendered map at prefix, along
+// with the keys added on admission to metadataMaps.
+func comparedKeys(prefix string, rendered map[string]any, admitted map[string]any) []string {
+	keys := make(map[string]bool, len(rendered))
+	for k := range rendered {
+		keys[k] = true
+	}
+
+	for k := range admitted {
+		if slices.Contains(metadataMaps, prefix) || slices.Contains(metadataMaps, prefix+"."+k) {
+			keys[k] = true
+		}
+	}
+
+	return slices.Sorted(maps.Keys(keys))
+}
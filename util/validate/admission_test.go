@@ -0,0 +1,88 @@
+package validate_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+// admit simulates admission: it denies objects labeled "deny", injects a
+// sidecar and an annotation into Deployments and fills in defaults.
+func admit(_ context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if obj.GetLabels()["deny"] != "" {
+		return nil, fmt.Errorf("%w: policy %q rejects the object", validate.ErrAdmissionDenied, obj.GetLabels()["deny"])
+	}
+
+	admitted := obj.DeepCopy()
+	admitted.SetUID("0f3c")
+	admitted.SetResourceVersion("42")
+	_ = unstructured.SetNestedField(admitted.Object, "ClusterIP", "spec", "type")
+
+	if obj.GetKind() == "Deployment" {
+		admitted.SetAnnotations(map[string]string{"sidecar.example.com/status": "injected"})
+
+		containers, _, _ := unstructured.NestedSlice(admitted.Object, "spec", "template", "spec", "containers")
+		containers = append(containers, map[string]any{"name": "proxy", "image": "proxy:1.0"})
+		_ = unstructured.SetNestedSlice(admitted.Object, containers, "spec", "template", "spec", "containers")
+	}
+
+	return admitted, nil
+}
+
+func TestAdmissionDryRun(t *testing.T) {
+	t.Run("should report denials and mutations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		denied := newObject("v1", "ConfigMap", "denied", nil)
+		denied.SetLabels(map[string]string{"deny": "require-owner"})
+
+		objects := []unstructured.Unstructured{
+			newObject("v1", "Service", "web", map[string]any{"spec": map[string]any{"clusterIP": nil}}),
+			denied,
+			newObject("apps/v1", "Deployment", "web", map[string]any{
+				"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+					"containers": []any{map[string]any{"name": "web", "image": "web:1.0"}},
+				}}},
+			}),
+		}
+
+		findings, err := validate.AdmissionDryRun(admit).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(
+			And(
+				HaveField("Rule", validate.RuleAdmissionDenied),
+				HaveField("Severity", validate.SeverityError),
+				HaveField("Object.Name", "denied"),
+				HaveField("Message", `admission denied: policy "require-owner" rejects the object`),
+			),
+			And(
+				HaveField("Rule", validate.RuleAdmissionMutated),
+				HaveField("Severity", validate.SeverityInfo),
+				HaveField("Object.Kind", "Deployment"),
+				HaveField("Path", "metadata.annotations"),
+			),
+			HaveField("Path", "spec.template.spec.containers"),
+		))
+	})
+
+	t.Run("should fail when objects cannot be submitted", func(t *testing.T) {
+		g := NewWithT(t)
+
+		failing := func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+			return nil, errGetFailed
+		}
+
+		_, err := validate.AdmissionDryRun(failing).Validate(t.Context(), []unstructured.Unstructured{
+			newObject("v1", "ConfigMap", "a", nil),
+		})
+
+		g.Expect(err).Should(MatchError(errGetFailed))
+	})
+}
@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/cache"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// Cached returns a Validator caching the findings of v per object, keyed by
+// the k8s.ContentHash of the object, so that objects left unchanged across
+// repeated renders are not validated again. Only the objects missing from the
+// cache are passed to v, in one call, and findings are returned in object
+// order.
+//
+// Caching is only correct for validators checking each object on its own,
+// such as Kubeconform, OpenAPI or APILifecycle, or policies evaluated per
+// object. Validators whose findings depend on other objects or on the target
+// cluster, such as lint rules relating objects, BundledCRDs or Preflight, must
+// not be cached. Findings about objects that were not validated are returned
+// but not cached.
+//
+// Example:
+//
+//	v := validate.Cached(validate.Kubeconform(dirs, "v1.31.0"), validate.WithCacheTTL(time.Hour))
+func Cached(v Validator, opts ...CachedOption) Validator {
+	options := CachedOptions{}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	findingsCache := options.Cache
+	if findingsCache == nil {
+		var cacheOpts []cache.Option
+		if options.TTL > 0 {
+			cacheOpts = append(cacheOpts, cache.WithTTL(options.TTL))
+		}
+
+		findingsCache = cache.New[Findings](cacheOpts...)
+	}
+
+	return Func(func(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+		hashes := make([]string, len(objects))
+		hits := make([]bool, len(objects))
+		cached := make([]Findings, len(objects))
+
+		var missing []unstructured.Unstructured
+
+		for i := range objects {
+			hashes[i] = k8s.ContentHash(&objects[i])
+
+			cached[i], hits[i] = findingsCache.Get(hashes[i])
+			if !hits[i] {
+				missing = append(missing, objects[i])
+			}
+		}
+
+		var fresh Findings
+
+		if len(missing) > 0 {
+			var err error
+
+			fresh, err = v.Validate(ctx, missing)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		byKey := make(map[k8s.ResourceKey]Findings)
+		for _, f := range fresh {
+			byKey[f.Object] = append(byKey[f.Object], f)
+		}
+
+		var findings Findings
+
+		for i := range objects {
+			if hits[i] {
+				findings = append(findings, cached[i]...)
+
+				continue
+			}
+
+			key := k8s.KeyOf(&objects[i])
+			result := byKey[key]
+			delete(byKey, key)
+
+			findingsCache.Set(hashes[i], result)
+			findings = append(findings, result...)
+		}
+
+		for _, f := range fresh {
+			if _, ok := byKey[f.Object]; ok {
+				findings = append(findings, f)
+			}
+		}
+
+		return findings, nil
+	})
+}
@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+	"github.com/k8s-manifest-kit/pkg/util/cache"
+)
+
+// CachedOption is a generic option for Cached.
+type CachedOption = util.Option[CachedOptions]
+
+// CachedOptions is a struct-based option that can set validation cache
+// options.
+type CachedOptions struct {
+	// TTL is the time-to-live of cached findings; defaults to the cache
+	// default.
+	TTL time.Duration
+
+	// Cache stores the findings by object content hash; defaults to a new
+	// in-memory cache. A cache must only be shared by Cached validators
+	// wrapping the same validator.
+	Cache cache.Interface[Findings]
+}
+
+// ApplyTo applies the validation cache options to the target configuration.
+func (opts CachedOptions) ApplyTo(target *CachedOptions) {
+	if opts.TTL > 0 {
+		target.TTL = opts.TTL
+	}
+
+	if opts.Cache != nil {
+		target.Cache = opts.Cache
+	}
+}
+
+// WithCacheTTL sets the time-to-live of cached findings.
+func WithCacheTTL(ttl time.Duration) CachedOption {
+	return util.FunctionalOption[CachedOptions](func(opts *CachedOptions) {
+		opts.TTL = ttl
+	})
+}
+
+// WithCache stores findings in c, e.g. to keep them across validators
+// created for each render.
+func WithCache(c cache.Interface[Findings]) CachedOption {
+	return util.FunctionalOption[CachedOptions](func(opts *CachedOptions) {
+		opts.Cache = c
+	})
+}
@@ -0,0 +1,113 @@
+package validate_test
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/cache"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+// countingValidator reports objects without labels and records the names of
+// the objects it validates.
+type countingValidator struct {
+	validated []string
+	err       error
+}
+
+func (c *countingValidator) Validate(_ context.Context, objects []unstructured.Unstructured) (validate.Findings, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	var findings validate.Findings
+
+	for i := range objects {
+		c.validated = append(c.validated, objects[i].GetName())
+
+		if len(objects[i].GetLabels()) == 0 {
+			findings = append(findings, validate.Finding{
+				Rule:     "labels",
+				Severity: validate.SeverityWarning,
+				Object:   k8s.KeyOf(&objects[i]),
+				Message:  "has no labels",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+func TestCached(t *testing.T) {
+	newObjects := func() []unstructured.Unstructured {
+		labeled := newObject("v1", "ConfigMap", "labeled", nil)
+		labeled.SetLabels(map[string]string{"app": "web"})
+
+		return []unstructured.Unstructured{
+			newObject("v1", "ConfigMap", "a", nil),
+			labeled,
+			newObject("v1", "ConfigMap", "b", nil),
+		}
+	}
+
+	t.Run("should only validate changed objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		counting := &countingValidator{}
+		v := validate.Cached(counting)
+
+		first, err := v.Validate(t.Context(), newObjects())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		objects := newObjects()
+		objects[2].SetLabels(map[string]string{"app": "db"})
+
+		second, err := v.Validate(t.Context(), objects)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(counting.validated).Should(Equal([]string{"a", "labeled", "b", "b"}))
+		g.Expect(first).Should(HaveExactElements(
+			HaveField("Object.Name", "a"),
+			HaveField("Object.Name", "b"),
+		))
+		g.Expect(second).Should(HaveExactElements(HaveField("Object.Name", "a")))
+	})
+
+	t.Run("should share a cache across validators", func(t *testing.T) {
+		g := NewWithT(t)
+
+		shared := cache.New[validate.Findings]()
+		counting := &countingValidator{}
+
+		_, err := validate.Cached(counting, validate.WithCache(shared)).Validate(t.Context(), newObjects())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		findings, err := validate.Cached(counting, validate.WithCache(shared)).Validate(t.Context(), newObjects())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(counting.validated).Should(HaveLen(3))
+		g.Expect(findings).Should(HaveLen(2))
+	})
+
+	t.Run("should not cache failures", func(t *testing.T) {
+		g := NewWithT(t)
+
+		counting := &countingValidator{err: errValidatorBroken}
+		v := validate.Cached(counting)
+
+		_, err := v.Validate(t.Context(), newObjects())
+		g.Expect(err).Should(MatchError(errValidatorBroken))
+
+		counting.err = nil
+
+		findings, err := v.Validate(t.Context(), newObjects())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveLen(2))
+		g.Expect(counting.validated).Should(HaveLen(3))
+	})
+}
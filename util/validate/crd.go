@@ -0,0 +1,198 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/jsonschema"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/maps"
+)
+
+const (
+	// RuleCRDSchema identifies findings reporting custom resources not
+	// matching the schema of a CRD of the same render.
+	RuleCRDSchema = "crd-schema"
+
+	// RuleCRDVersionMissing identifies findings reporting custom resources
+	// using a version their bundled CRD does not define.
+	RuleCRDVersionMissing = "crd-version-missing"
+)
+
+// BundledCRDs returns a Validator checking custom resources against the
+// apiextensions.k8s.io/v1 CustomResourceDefinitions of the same render, so
+// that mismatches are caught even before the CRDs are installed in a cluster.
+//
+// Defaults declared by the structural schema are applied to a copy of each
+// custom resource before it is validated, as the API server does, so fields
+// that are required but defaulted do not produce findings.
+// x-kubernetes-validations (CEL) rules are not evaluated. Objects of groups no
+// bundled CRD defines are ignored, and CRDs whose schemas cannot be compiled
+// are reported as RuleCRDSchema findings on the CRD.
+func BundledCRDs() Validator {
+	return Func(func(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+		crds, findings := bundledSchemas(objects)
+
+		for i := range objects {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			obj := &objects[i]
+			gvk := obj.GroupVersionKind()
+
+			versions, ok := crds[gvk.GroupKind()]
+			if !ok {
+				continue
+			}
+
+			s, ok := versions[gvk.Version]
+			if !ok {
+				findings = append(findings, Finding{
+					Rule:     RuleCRDVersionMissing,
+					Severity: SeverityError,
+					Object:   k8s.KeyOf(obj),
+					Path:     "apiVersion",
+					Message:  fmt.Sprintf("the bundled CRD of %s does not define version %s", gvk.GroupKind(), gvk.Version),
+				})
+
+				continue
+			}
+
+			if s == nil {
+				// The CRD version has no schema, or an invalid one that is
+				// already reported.
+				continue
+			}
+
+			err := s.schema.Validate(applyDefaults(s.root, maps.DeepCloneMap(obj.Object)))
+
+			var verr *jsonschema.ValidationError
+			if errors.As(err, &verr) {
+				for _, fe := range verr.Errors {
+					findings = append(findings, Finding{
+						Rule:     RuleCRDSchema,
+						Severity: SeverityError,
+						Object:   k8s.KeyOf(obj),
+						Path:     fieldPath(fe.Path),
+						Message:  fe.Message,
+					})
+				}
+			}
+		}
+
+		return findings, nil
+	})
+}
+
+// crdSchema is the compiled schema of a CRD version.
+type crdSchema struct {
+	root   map[string]any
+	schema *jsonschema.Schema
+}
+
+// bundledSchemas returns the schemas of the CRDs among objects by group kind
+// and version, with findings for the schemas that cannot be compiled. Versions
+// without a usable schema map to nil.
+func bundledSchemas(objects []unstructured.Unstructured) (map[schema.GroupKind]map[string]*crdSchema, Findings) {
+	crds := make(map[schema.GroupKind]map[string]*crdSchema)
+
+	var findings Findings
+
+	for i := range objects {
+		crd := &objects[i]
+		if crd.GroupVersionKind() != (schema.GroupVersionKind{
+			Group:   "apiextensions.k8s.io",
+			Version: "v1",
+			Kind:    "CustomResourceDefinition",
+		}) {
+			continue
+		}
+
+		group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+		versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+
+		gk := schema.GroupKind{Group: group, Kind: kind}
+		crds[gk] = make(map[string]*crdSchema, len(versions))
+
+		for j, v := range versions {
+			version, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			name, _ := version["name"].(string)
+			crds[gk][name] = nil
+
+			root, ok, _ := unstructured.NestedMap(version, "schema", "openAPIV3Schema")
+			if !ok {
+				continue
+			}
+
+			s, err := jsonschema.New(root)
+			if err != nil {
+				findings = append(findings, Finding{
+					Rule:     RuleCRDSchema,
+					Severity: SeverityError,
+					Object:   k8s.KeyOf(crd),
+					Path:     "spec.versions[" + strconv.Itoa(j) + "].schema.openAPIV3Schema",
+					Message:  err.Error(),
+				})
+
+				continue
+			}
+
+			crds[gk][name] = &crdSchema{root: root, schema: s}
+		}
+	}
+
+	return crds, findings
+}
+
+// applyDefaults sets the defaults declared by the structural schema node on
+// the missing fields of value, which it modifies in place, and returns value.
+func applyDefaults(node map[string]any, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := node["properties"].(map[string]any)
+
+		for name, p := range properties {
+			property, ok := p.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if _, exists := v[name]; !exists {
+				if d, hasDefault := property["default"]; hasDefault {
+					v[name] = maps.DeepCloneValue(d)
+				}
+			}
+
+			if child, exists := v[name]; exists {
+				v[name] = applyDefaults(property, child)
+			}
+		}
+
+		if additional, ok := node["additionalProperties"].(map[string]any); ok {
+			for name, child := range v {
+				if _, declared := properties[name]; !declared {
+					v[name] = applyDefaults(additional, child)
+				}
+			}
+		}
+	case []any:
+		if items, ok := node["items"].(map[string]any); ok {
+			for i := range v {
+				v[i] = applyDefaults(items, v[i])
+			}
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,174 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+const testBundledCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              required: [size, replicas]
+              properties:
+                size:
+                  type: string
+                  enum: [small, large]
+                replicas:
+                  type: integer
+                  default: 1
+                ports:
+                  type: array
+                  items:
+                    type: object
+                    required: [port, protocol]
+                    properties:
+                      port: {type: integer, maximum: 65535}
+                      protocol: {type: string, default: TCP}
+    - name: v1alpha1
+      served: false
+      storage: false
+`
+
+const testWidgets = `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: good
+  namespace: default
+spec:
+  size: small
+  ports:
+    - port: 8080
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: bad
+  namespace: default
+spec:
+  size: medium
+  ports:
+    - port: 80000
+---
+apiVersion: example.com/v1alpha1
+kind: Widget
+metadata:
+  name: legacy
+  namespace: default
+spec:
+  anything: goes
+---
+apiVersion: example.com/v2
+kind: Widget
+metadata:
+  name: future
+  namespace: default
+---
+apiVersion: other.example.com/v1
+kind: Gadget
+metadata:
+  name: unknown
+  namespace: default
+`
+
+const testInvalidCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: gadgets.other.example.com
+spec:
+  group: other.example.com
+  names:
+    kind: Gadget
+    plural: gadgets
+  scope: Namespaced
+  versions:
+    - name: v1
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: string
+              pattern: "(["
+`
+
+func TestBundledCRDs(t *testing.T) {
+	t.Run("should validate custom resources against bundled CRDs", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(testBundledCRD + "---" + testWidgets))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		findings, err := validate.BundledCRDs().Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(ConsistOf(
+			And(
+				HaveField("Rule", validate.RuleCRDSchema),
+				HaveField("Object.Name", "bad"),
+				HaveField("Path", "spec.size"),
+			),
+			And(
+				HaveField("Rule", validate.RuleCRDSchema),
+				HaveField("Object.Name", "bad"),
+				HaveField("Path", "spec.ports[0].port"),
+			),
+			And(
+				HaveField("Rule", validate.RuleCRDVersionMissing),
+				HaveField("Object.Name", "future"),
+				HaveField("Message", ContainSubstring("does not define version v2")),
+			),
+		))
+	})
+
+	t.Run("should not modify the objects when applying defaults", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(testBundledCRD + "---" + testWidgets))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = validate.BundledCRDs().Validate(t.Context(), objects)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(objects[1].Object["spec"]).ShouldNot(HaveKey("replicas"))
+	})
+
+	t.Run("should report invalid CRD schemas", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects, err := k8s.DecodeYAML([]byte(testInvalidCRD + "---" + testWidgets))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		findings, err := validate.BundledCRDs().Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(And(
+			HaveField("Rule", validate.RuleCRDSchema),
+			HaveField("Object.Kind", "CustomResourceDefinition"),
+			HaveField("Path", "spec.versions[0].schema.openAPIV3Schema"),
+		)))
+	})
+}
@@ -0,0 +1,222 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// RuleImmutableField identifies findings reporting changes to fields the API
+// server refuses to update on existing objects.
+const RuleImmutableField = "immutable-field"
+
+// ObjectGetter returns the live counterpart of a rendered object from the
+// target cluster, and whether it exists. With client-go, it is typically
+// implemented with a dynamic client and a REST mapper:
+//
+//	func(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+//	    gvk := obj.GroupVersionKind()
+//	    mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+//	    if err != nil {
+//	        return nil, false, err
+//	    }
+//	    live, err := client.Resource(mapping.Resource).Namespace(obj.GetNamespace()).Get(ctx, obj.GetName(), metav1.GetOptions{})
+//	    if apierrors.IsNotFound(err) {
+//	        return nil, false, nil
+//	    }
+//	    return live, err == nil, err
+//	}
+type ObjectGetter func(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, bool, error)
+
+// immutableCheck compares a rendered object with its live counterpart.
+type immutableCheck func(rendered *unstructured.Unstructured, live *unstructured.Unstructured) []immutableChange
+
+// immutableChange is a rejected change to the field at path.
+type immutableChange struct {
+	path    string
+	message string
+}
+
+// immutableChecks lists the checks run by ImmutableFields per kind.
+var immutableChecks = map[schema.GroupKind]immutableCheck{
+	{Kind: "Service"}:                    checkServiceClusterIP,
+	{Kind: "PersistentVolumeClaim"}:      checkClaimSpec,
+	{Group: "apps", Kind: "Deployment"}:  checkSelector,
+	{Group: "apps", Kind: "ReplicaSet"}:  checkSelector,
+	{Group: "apps", Kind: "DaemonSet"}:   checkSelector,
+	{Group: "apps", Kind: "StatefulSet"}: checkStatefulSet,
+	{Group: "batch", Kind: "Job"}:        checkSelector,
+}
+
+// ImmutableFields returns a Validator comparing objects with their live
+// counterparts returned by get, and reporting changes to immutable fields
+// that would make applying them fail: the cluster IP of Services, the
+// storage class of PersistentVolumeClaims and decreases of their requested
+// storage, the selectors of workloads and the volume claim templates of
+// StatefulSets. Objects that do not exist yet are not checked, and fields
+// left unset in the rendered object are not compared, since the API server
+// keeps or defaults them.
+func ImmutableFields(get ObjectGetter) Validator {
+	return Func(func(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+		var findings Findings
+
+		for i := range objects {
+			obj := &objects[i]
+
+			check := immutableChecks[obj.GroupVersionKind().GroupKind()]
+			if check == nil {
+				continue
+			}
+
+			live, found, err := get(ctx, obj)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get live object %s: %w", k8s.KeyOf(obj), err)
+			}
+
+			if !found {
+				continue
+			}
+
+			for _, c := range check(obj, live) {
+				findings = append(findings, Finding{
+					Rule:     RuleImmutableField,
+					Severity: SeverityError,
+					Object:   k8s.KeyOf(obj),
+					Path:     c.path,
+					Message:  c.message,
+				})
+			}
+		}
+
+		return findings, nil
+	})
+}
+
+func checkServiceClusterIP(rendered *unstructured.Unstructured, live *unstructured.Unstructured) []immutableChange {
+	want, _, _ := unstructured.NestedString(rendered.Object, "spec", "clusterIP")
+	have, _, _ := unstructured.NestedString(live.Object, "spec", "clusterIP")
+
+	if want == "" || have == "" || want == have {
+		return nil
+	}
+
+	return []immutableChange{{
+		path:    "spec.clusterIP",
+		message: fmt.Sprintf("cannot change the cluster IP from %q to %q", have, want),
+	}}
+}
+
+func checkClaimSpec(rendered *unstructured.Unstructured, live *unstructured.Unstructured) []immutableChange {
+	var changes []immutableChange
+
+	want, found, _ := unstructured.NestedString(rendered.Object, "spec", "storageClassName")
+	have, _, _ := unstructured.NestedString(live.Object, "spec", "storageClassName")
+
+	if found && want != have {
+		changes = append(changes, immutableChange{
+			path:    "spec.storageClassName",
+			message: fmt.Sprintf("cannot change the storage class from %q to %q", have, want),
+		})
+	}
+
+	wantSize, wantOK := storageRequest(rendered)
+	haveSize, haveOK := storageRequest(live)
+
+	if wantOK && haveOK && wantSize.Cmp(haveSize) < 0 {
+		changes = append(changes, immutableChange{
+			path:    "spec.resources.requests.storage",
+			message: fmt.Sprintf("cannot decrease the requested storage from %s to %s", haveSize.String(), wantSize.String()),
+		})
+	}
+
+	return changes
+}
+
+// storageRequest returns the storage requested by a PersistentVolumeClaim.
+func storageRequest(claim *unstructured.Unstructured) (resource.Quantity, bool) {
+	value, _, _ := unstructured.NestedFieldNoCopy(claim.Object, "spec", "resources", "requests", "storage")
+
+	return quantity(value)
+}
+
+func checkSelector(rendered *unstructured.Unstructured, live *unstructured.Unstructured) []immutableChange {
+	want, found, _ := unstructured.NestedFieldNoCopy(rendered.Object, "spec", "selector")
+	have, _, _ := unstructured.NestedFieldNoCopy(live.Object, "spec", "selector")
+
+	if !found || reflect.DeepEqual(want, have) {
+		return nil
+	}
+
+	return []immutableChange{{path: "spec.selector", message: "cannot change the selector"}}
+}
+
+func checkStatefulSet(rendered *unstructured.Unstructured, live *unstructured.Unstructured) []immutableChange {
+	changes := checkSelector(rendered, live)
+
+	want, found, _ := unstructured.NestedSlice(rendered.Object, "spec", "volumeClaimTemplates")
+	have, _, _ := unstructured.NestedSlice(live.Object, "spec", "volumeClaimTemplates")
+
+	if !found {
+		return changes
+	}
+
+	if len(want) != len(have) {
+		return append(changes, immutableChange{
+			path:    "spec.volumeClaimTemplates",
+			message: fmt.Sprintf("cannot change the number of volume claim templates from %d to %d", len(have), len(want)),
+		})
+	}
+
+	for j := range want {
+		if !contained(want[j], have[j]) {
+			changes = append(changes, immutableChange{
+				path:    "spec.volumeClaimTemplates[" + strconv.Itoa(j) + "]",
+				message: "cannot change volume claim templates",
+			})
+		}
+	}
+
+	return changes
+}
+
+// contained reports whether every field set in rendered has the same value in
+// live, ignoring the fields the API server defaults.
+func contained(rendered any, live any) bool {
+	switch r := rendered.(type) {
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			return false
+		}
+
+		for k, v := range r {
+			if !contained(v, l[k]) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		l, ok := live.([]any)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+
+		for i := range r {
+			if !contained(r[i], l[i]) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return fmt.Sprint(rendered) == fmt.Sprint(live)
+	}
+}
@@ -0,0 +1,143 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+var errGetFailed = errors.New("get failed")
+
+// liveObjects returns an ObjectGetter serving objects by key.
+func liveObjects(objects ...unstructured.Unstructured) validate.ObjectGetter {
+	live := make(map[k8s.ResourceKey]*unstructured.Unstructured)
+	for i := range objects {
+		live[k8s.KeyOf(&objects[i])] = &objects[i]
+	}
+
+	return func(_ context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+		l, ok := live[k8s.KeyOf(obj)]
+
+		return l, ok, nil
+	}
+}
+
+func claimTemplate(storage string) map[string]any {
+	return map[string]any{
+		"metadata": map[string]any{"name": "data"},
+		"spec": map[string]any{
+			"accessModes": []any{"ReadWriteOnce"},
+			"resources":   map[string]any{"requests": map[string]any{"storage": storage}},
+		},
+	}
+}
+
+func TestImmutableFields(t *testing.T) {
+	selector := func(app string) map[string]any {
+		return map[string]any{"selector": map[string]any{"matchLabels": map[string]any{"app": app}}}
+	}
+
+	live := liveObjects(
+		newObject("v1", "Service", "web", map[string]any{
+			"spec": map[string]any{"clusterIP": "10.0.0.10", "type": "ClusterIP"},
+		}),
+		newObject("v1", "PersistentVolumeClaim", "data", map[string]any{
+			"spec": map[string]any{
+				"storageClassName": "standard",
+				"volumeMode":       "Filesystem",
+				"resources":        map[string]any{"requests": map[string]any{"storage": "10Gi"}},
+			},
+		}),
+		newObject("apps/v1", "Deployment", "web", map[string]any{"spec": selector("web")}),
+		newObject("apps/v1", "StatefulSet", "db", map[string]any{
+			"spec": map[string]any{
+				"selector": map[string]any{"matchLabels": map[string]any{"app": "db"}},
+				"volumeClaimTemplates": []any{map[string]any{
+					"apiVersion": "v1",
+					"kind":       "PersistentVolumeClaim",
+					"metadata":   map[string]any{"name": "data"},
+					"spec": map[string]any{
+						"accessModes": []any{"ReadWriteOnce"},
+						"resources":   map[string]any{"requests": map[string]any{"storage": "1Gi"}},
+						"volumeMode":  "Filesystem",
+					},
+				}},
+			},
+		}),
+	)
+
+	t.Run("should accept unchanged and defaulted fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := validate.ImmutableFields(live).Validate(t.Context(), []unstructured.Unstructured{
+			newObject("v1", "Service", "web", map[string]any{"spec": map[string]any{"type": "ClusterIP"}}),
+			newObject("v1", "PersistentVolumeClaim", "data", map[string]any{
+				"spec": map[string]any{"resources": map[string]any{"requests": map[string]any{"storage": "20Gi"}}},
+			}),
+			newObject("apps/v1", "Deployment", "web", map[string]any{"spec": selector("web")}),
+			newObject("apps/v1", "StatefulSet", "db", map[string]any{
+				"spec": map[string]any{"volumeClaimTemplates": []any{claimTemplate("1Gi")}},
+			}),
+			newObject("apps/v1", "Deployment", "new", map[string]any{"spec": selector("new")}),
+		})
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(BeEmpty())
+	})
+
+	t.Run("should report changes to immutable fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := validate.ImmutableFields(live).Validate(t.Context(), []unstructured.Unstructured{
+			newObject("v1", "Service", "web", map[string]any{"spec": map[string]any{"clusterIP": "None"}}),
+			newObject("v1", "PersistentVolumeClaim", "data", map[string]any{
+				"spec": map[string]any{
+					"storageClassName": "fast",
+					"resources":        map[string]any{"requests": map[string]any{"storage": "5Gi"}},
+				},
+			}),
+			newObject("apps/v1", "Deployment", "web", map[string]any{"spec": selector("frontend")}),
+			newObject("apps/v1", "StatefulSet", "db", map[string]any{
+				"spec": map[string]any{"volumeClaimTemplates": []any{claimTemplate("2Gi")}},
+			}),
+		})
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveEach(And(
+			HaveField("Rule", validate.RuleImmutableField),
+			HaveField("Severity", validate.SeverityError),
+		)))
+		g.Expect(findings).Should(HaveExactElements(
+			And(
+				HaveField("Object.Kind", "Service"),
+				HaveField("Path", "spec.clusterIP"),
+				HaveField("Message", `cannot change the cluster IP from "10.0.0.10" to "None"`),
+			),
+			HaveField("Message", `cannot change the storage class from "standard" to "fast"`),
+			HaveField("Message", "cannot decrease the requested storage from 10Gi to 5Gi"),
+			And(HaveField("Object.Kind", "Deployment"), HaveField("Path", "spec.selector")),
+			And(HaveField("Object.Kind", "StatefulSet"), HaveField("Path", "spec.volumeClaimTemplates[0]")),
+		))
+	})
+
+	t.Run("should fail when live objects cannot be read", func(t *testing.T) {
+		g := NewWithT(t)
+
+		failing := func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+			return nil, false, errGetFailed
+		}
+
+		_, err := validate.ImmutableFields(failing).Validate(t.Context(), []unstructured.Unstructured{
+			newObject("v1", "Service", "web", nil),
+		})
+
+		g.Expect(err).Should(MatchError(errGetFailed))
+	})
+}
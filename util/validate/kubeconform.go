@@ -0,0 +1,206 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/jsonschema"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const (
+	// RuleSchema identifies findings reporting objects not matching their schema.
+	RuleSchema = "schema"
+
+	// RuleSchemaMissing identifies findings reporting objects without a schema.
+	RuleSchemaMissing = "schema-missing"
+
+	// masterVersion is the schema directory used when no Kubernetes version is given.
+	masterVersion = "master"
+)
+
+// Kubeconform returns a Validator checking objects against the JSON schemas
+// found in schemaDirs, laid out as for kubeconform, so that rendered output
+// is validated offline against the OpenAPI schemas of a Kubernetes release.
+//
+// For each object, the schema directories are searched in order for:
+//
+//   - <dir>/<version>-standalone[-strict]/<kind>[-<group>]-<apiversion>.json,
+//     the layout of the kubernetes-json-schema repository, where group is the
+//     first label of the API group (e.g. "networking" for networking.k8s.io)
+//   - <dir>/<group>/<kind>_<apiversion>.json, the layout of the CRDs catalog
+//
+// with kind lowercased. An empty k8sVersion selects the "master" schemas, and
+// a missing "v" prefix is added. Objects without a schema produce a
+// RuleSchemaMissing finding unless WithIgnoreMissingSchemas is given; schemas
+// are compiled once and reused across calls.
+func Kubeconform(schemaDirs []string, k8sVersion string, opts ...KubeconformOption) Validator {
+	options := KubeconformOptions{}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	version := k8sVersion
+	switch {
+	case version == "":
+		version = masterVersion
+	case version != masterVersion && !strings.HasPrefix(version, "v"):
+		version = "v" + version
+	}
+
+	return &kubeconform{
+		dirs:    schemaDirs,
+		version: version,
+		options: options,
+		schemas: make(map[schema.GroupVersionKind]*jsonschema.Schema),
+	}
+}
+
+type kubeconform struct {
+	dirs    []string
+	version string
+	options KubeconformOptions
+
+	mu sync.Mutex
+	// schemas caches compiled schemas by GVK; nil records a missing schema.
+	schemas map[schema.GroupVersionKind]*jsonschema.Schema
+}
+
+func (k *kubeconform) Validate(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+	var findings Findings
+
+	for i := range objects {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		obj := &objects[i]
+		key := k8s.KeyOf(obj)
+		gvk := obj.GroupVersionKind()
+
+		if gvk.Version == "" || gvk.Kind == "" {
+			findings = append(findings, Finding{
+				Rule:     RuleSchema,
+				Severity: SeverityError,
+				Object:   key,
+				Message:  "missing apiVersion or kind",
+			})
+
+			continue
+		}
+
+		s, found, err := k.schema(gvk)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			if !k.options.IgnoreMissingSchemas {
+				findings = append(findings, Finding{
+					Rule:     RuleSchemaMissing,
+					Severity: SeverityError,
+					Object:   key,
+					Message:  fmt.Sprintf("no schema found for %s, version %s", gvk.String(), k.version),
+				})
+			}
+
+			continue
+		}
+
+		err = s.Validate(obj.Object)
+
+		var verr *jsonschema.ValidationError
+		if errors.As(err, &verr) {
+			for _, fe := range verr.Errors {
+				findings = append(findings, Finding{
+					Rule:     RuleSchema,
+					Severity: SeverityError,
+					Object:   key,
+					Path:     fieldPath(fe.Path),
+					Message:  fe.Message,
+				})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+func (k *kubeconform) schema(gvk schema.GroupVersionKind) (*jsonschema.Schema, bool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if s, ok := k.schemas[gvk]; ok {
+		return s, s != nil, nil
+	}
+
+	for _, dir := range k.dirs {
+		for _, location := range k.locations(dir, gvk) {
+			data, err := os.ReadFile(location)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			if err != nil {
+				return nil, false, fmt.Errorf("unable to read schema %s: %w", location, err)
+			}
+
+			s, err := jsonschema.Compile(data)
+			if err != nil {
+				return nil, false, fmt.Errorf("unable to compile schema %s: %w", location, err)
+			}
+
+			k.schemas[gvk] = s
+
+			return s, true, nil
+		}
+	}
+
+	k.schemas[gvk] = nil
+
+	return nil, false, nil
+}
+
+func (k *kubeconform) locations(dir string, gvk schema.GroupVersionKind) []string {
+	kind := strings.ToLower(gvk.Kind)
+
+	suffix := "-standalone"
+	if k.options.Strict {
+		suffix += "-strict"
+	}
+
+	name := kind + "-" + gvk.Version
+	if gvk.Group != "" {
+		name = kind + "-" + strings.Split(gvk.Group, ".")[0] + "-" + gvk.Version
+	}
+
+	locations := []string{
+		filepath.Join(dir, k.version+suffix, name+".json"),
+	}
+
+	if gvk.Group != "" {
+		locations = append(locations, filepath.Join(dir, gvk.Group, kind+"_"+gvk.Version+".json"))
+	}
+
+	return locations
+}
+
+// fieldPath converts a jsonschema path to a finding path, where the object
+// itself is addressed by the empty path.
+func fieldPath(path string) string {
+	if path == "(root)" {
+		return ""
+	}
+
+	return path
+}
@@ -0,0 +1,44 @@
+package validate
+
+import (
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// KubeconformOption is a generic option for Kubeconform.
+type KubeconformOption = util.Option[KubeconformOptions]
+
+// KubeconformOptions is a struct-based option that can set schema validation options.
+type KubeconformOptions struct {
+	// Strict selects the strict schemas, rejecting unknown fields.
+	Strict bool
+
+	// IgnoreMissingSchemas skips objects without a schema instead of
+	// reporting them.
+	IgnoreMissingSchemas bool
+}
+
+// ApplyTo applies the schema validation options to the target configuration.
+func (opts KubeconformOptions) ApplyTo(target *KubeconformOptions) {
+	if opts.Strict {
+		target.Strict = true
+	}
+
+	if opts.IgnoreMissingSchemas {
+		target.IgnoreMissingSchemas = true
+	}
+}
+
+// WithStrict selects the strict schemas, rejecting unknown fields.
+func WithStrict() KubeconformOption {
+	return util.FunctionalOption[KubeconformOptions](func(opts *KubeconformOptions) {
+		opts.Strict = true
+	})
+}
+
+// WithIgnoreMissingSchemas skips objects without a schema instead of
+// reporting them.
+func WithIgnoreMissingSchemas() KubeconformOption {
+	return util.FunctionalOption[KubeconformOptions](func(opts *KubeconformOptions) {
+		opts.IgnoreMissingSchemas = true
+	})
+}
@@ -0,0 +1,213 @@
+package validate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+const testDeploymentSchema = `{
+  "type": "object",
+  "properties": {
+    "apiVersion": {"type": "string"},
+    "kind": {"type": "string"},
+    "metadata": {"type": "object"},
+    "spec": {
+      "type": "object",
+      "required": ["selector"],
+      "properties": {
+        "replicas": {"type": "integer", "minimum": 0},
+        "selector": {"type": "object"}
+      }
+    }
+  }
+}`
+
+const testStrictConfigMapSchema = `{
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "apiVersion": {"type": "string"},
+    "kind": {"type": "string"},
+    "metadata": {"type": "object"},
+    "data": {"type": "object", "additionalProperties": {"type": "string"}}
+  }
+}`
+
+const testWidgetSchema = `{
+  "type": "object",
+  "properties": {
+    "spec": {
+      "type": "object",
+      "properties": {"size": {"enum": ["small", "large"]}}
+    }
+  }
+}`
+
+func writeSchema(t *testing.T, dir string, path string, content string) {
+	t.Helper()
+
+	p := filepath.Join(dir, path)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newObject(apiVersion string, kind string, name string, fields map[string]any) unstructured.Unstructured {
+	obj := map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name, "namespace": "default"},
+	}
+
+	for k, v := range fields {
+		obj[k] = v
+	}
+
+	return unstructured.Unstructured{Object: obj}
+}
+
+func TestKubeconform(t *testing.T) {
+	t.Run("should accept valid objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		writeSchema(t, dir, "v1.31.0-standalone/deployment-apps-v1.json", testDeploymentSchema)
+
+		objects := []unstructured.Unstructured{
+			newObject("apps/v1", "Deployment", "web", map[string]any{
+				"spec": map[string]any{"replicas": int64(2), "selector": map[string]any{}},
+			}),
+		}
+
+		findings, err := validate.Kubeconform([]string{dir}, "v1.31.0").Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(BeEmpty())
+	})
+
+	t.Run("should report per-field errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		writeSchema(t, dir, "v1.31.0-standalone/deployment-apps-v1.json", testDeploymentSchema)
+
+		objects := []unstructured.Unstructured{
+			newObject("apps/v1", "Deployment", "web", map[string]any{
+				"spec": map[string]any{"replicas": "two"},
+			}),
+		}
+
+		findings, err := validate.Kubeconform([]string{dir}, "1.31.0").Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveLen(2))
+
+		key := k8s.ResourceKey{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "web"}
+		g.Expect(findings).Should(ContainElement(And(
+			HaveField("Rule", validate.RuleSchema),
+			HaveField("Severity", validate.SeverityError),
+			HaveField("Object", key),
+			HaveField("Path", "spec.replicas"),
+		)))
+		g.Expect(findings).Should(ContainElement(And(
+			HaveField("Path", "spec.selector"),
+			HaveField("Message", ContainSubstring("required")),
+		)))
+	})
+
+	t.Run("should use strict schemas", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		writeSchema(t, dir, "master-standalone-strict/configmap-v1.json", testStrictConfigMapSchema)
+
+		objects := []unstructured.Unstructured{
+			newObject("v1", "ConfigMap", "settings", map[string]any{"extra": true}),
+		}
+
+		findings, err := validate.Kubeconform([]string{dir}, "", validate.WithStrict()).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveLen(1))
+		g.Expect(findings[0].Path).Should(Equal("extra"))
+	})
+
+	t.Run("should find CRD schemas in catalog layout", func(t *testing.T) {
+		g := NewWithT(t)
+
+		builtin := t.TempDir()
+		crds := t.TempDir()
+		writeSchema(t, crds, "example.com/widget_v1alpha1.json", testWidgetSchema)
+
+		objects := []unstructured.Unstructured{
+			newObject("example.com/v1alpha1", "Widget", "w", map[string]any{
+				"spec": map[string]any{"size": "medium"},
+			}),
+		}
+
+		findings, err := validate.Kubeconform([]string{builtin, crds}, "v1.31.0").Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveLen(1))
+		g.Expect(findings[0].Path).Should(Equal("spec.size"))
+	})
+
+	t.Run("should report missing schemas", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := []unstructured.Unstructured{
+			newObject("example.com/v1", "Gadget", "g", nil),
+		}
+
+		findings, err := validate.Kubeconform([]string{t.TempDir()}, "v1.31.0").Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveLen(1))
+		g.Expect(findings[0].Rule).Should(Equal(validate.RuleSchemaMissing))
+		g.Expect(findings[0].Message).Should(ContainSubstring("v1.31.0"))
+	})
+
+	t.Run("should ignore missing schemas when requested", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := []unstructured.Unstructured{
+			newObject("example.com/v1", "Gadget", "g", nil),
+		}
+
+		findings, err := validate.Kubeconform(
+			[]string{t.TempDir()},
+			"v1.31.0",
+			validate.WithIgnoreMissingSchemas(),
+		).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(BeEmpty())
+	})
+
+	t.Run("should fail on invalid schemas", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		writeSchema(t, dir, "master-standalone/configmap-v1.json", `{"pattern": "(["}`)
+
+		objects := []unstructured.Unstructured{
+			newObject("v1", "ConfigMap", "settings", nil),
+		}
+
+		_, err := validate.Kubeconform([]string{dir}, "").Validate(t.Context(), objects)
+
+		g.Expect(err).Should(MatchError(ContainSubstring("configmap-v1.json")))
+	})
+}
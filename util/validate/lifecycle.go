@@ -0,0 +1,231 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const (
+	// RuleAPIDeprecated identifies findings reporting objects using an API
+	// deprecated in the target version.
+	RuleAPIDeprecated = "api-deprecated"
+
+	// RuleAPIRemoved identifies findings reporting objects using an API no
+	// longer served by the target version.
+	RuleAPIRemoved = "api-removed"
+)
+
+// APIDeprecation describes the lifecycle of a deprecated API.
+type APIDeprecation struct {
+	// GroupVersionKind is the deprecated API.
+	GroupVersionKind schema.GroupVersionKind
+
+	// DeprecatedIn is the Kubernetes version deprecating the API, e.g. "v1.19".
+	DeprecatedIn string
+
+	// RemovedIn is the Kubernetes version no longer serving the API, if any.
+	RemovedIn string
+
+	// Replacement is the apiVersion to migrate to; empty when the API is
+	// removed without replacement.
+	Replacement string
+}
+
+// apiDeprecations follows the Kubernetes deprecated API migration guide.
+var apiDeprecations = []APIDeprecation{
+	deprecation("extensions", "v1beta1", "DaemonSet", "v1.9", "v1.16", "apps/v1"),
+	deprecation("extensions", "v1beta1", "Deployment", "v1.9", "v1.16", "apps/v1"),
+	deprecation("extensions", "v1beta1", "ReplicaSet", "v1.9", "v1.16", "apps/v1"),
+	deprecation("extensions", "v1beta1", "NetworkPolicy", "v1.9", "v1.16", "networking.k8s.io/v1"),
+	deprecation("extensions", "v1beta1", "PodSecurityPolicy", "v1.11", "v1.16", "policy/v1beta1"),
+	deprecation("extensions", "v1beta1", "Ingress", "v1.14", "v1.22", "networking.k8s.io/v1"),
+	deprecation("apps", "v1beta1", "Deployment", "v1.9", "v1.16", "apps/v1"),
+	deprecation("apps", "v1beta1", "StatefulSet", "v1.9", "v1.16", "apps/v1"),
+	deprecation("apps", "v1beta1", "ControllerRevision", "v1.9", "v1.16", "apps/v1"),
+	deprecation("apps", "v1beta2", "DaemonSet", "v1.9", "v1.16", "apps/v1"),
+	deprecation("apps", "v1beta2", "Deployment", "v1.9", "v1.16", "apps/v1"),
+	deprecation("apps", "v1beta2", "ReplicaSet", "v1.9", "v1.16", "apps/v1"),
+	deprecation("apps", "v1beta2", "StatefulSet", "v1.9", "v1.16", "apps/v1"),
+	deprecation("apps", "v1beta2", "ControllerRevision", "v1.9", "v1.16", "apps/v1"),
+	deprecation("admissionregistration.k8s.io", "v1beta1", "MutatingWebhookConfiguration", "v1.16", "v1.22",
+		"admissionregistration.k8s.io/v1"),
+	deprecation("admissionregistration.k8s.io", "v1beta1", "ValidatingWebhookConfiguration", "v1.16", "v1.22",
+		"admissionregistration.k8s.io/v1"),
+	deprecation("apiextensions.k8s.io", "v1beta1", "CustomResourceDefinition", "v1.16", "v1.22", "apiextensions.k8s.io/v1"),
+	deprecation("apiregistration.k8s.io", "v1beta1", "APIService", "v1.19", "v1.22", "apiregistration.k8s.io/v1"),
+	deprecation("certificates.k8s.io", "v1beta1", "CertificateSigningRequest", "v1.19", "v1.22", "certificates.k8s.io/v1"),
+	deprecation("coordination.k8s.io", "v1beta1", "Lease", "v1.19", "v1.22", "coordination.k8s.io/v1"),
+	deprecation("networking.k8s.io", "v1beta1", "Ingress", "v1.19", "v1.22", "networking.k8s.io/v1"),
+	deprecation("networking.k8s.io", "v1beta1", "IngressClass", "v1.19", "v1.22", "networking.k8s.io/v1"),
+	deprecation("rbac.authorization.k8s.io", "v1beta1", "ClusterRole", "v1.17", "v1.22", "rbac.authorization.k8s.io/v1"),
+	deprecation("rbac.authorization.k8s.io", "v1beta1", "ClusterRoleBinding", "v1.17", "v1.22", "rbac.authorization.k8s.io/v1"),
+	deprecation("rbac.authorization.k8s.io", "v1beta1", "Role", "v1.17", "v1.22", "rbac.authorization.k8s.io/v1"),
+	deprecation("rbac.authorization.k8s.io", "v1beta1", "RoleBinding", "v1.17", "v1.22", "rbac.authorization.k8s.io/v1"),
+	deprecation("scheduling.k8s.io", "v1beta1", "PriorityClass", "v1.14", "v1.22", "scheduling.k8s.io/v1"),
+	deprecation("storage.k8s.io", "v1beta1", "CSIDriver", "v1.19", "v1.22", "storage.k8s.io/v1"),
+	deprecation("storage.k8s.io", "v1beta1", "CSINode", "v1.17", "v1.22", "storage.k8s.io/v1"),
+	deprecation("storage.k8s.io", "v1beta1", "StorageClass", "v1.19", "v1.22", "storage.k8s.io/v1"),
+	deprecation("storage.k8s.io", "v1beta1", "VolumeAttachment", "v1.19", "v1.22", "storage.k8s.io/v1"),
+	deprecation("batch", "v1beta1", "CronJob", "v1.21", "v1.25", "batch/v1"),
+	deprecation("discovery.k8s.io", "v1beta1", "EndpointSlice", "v1.21", "v1.25", "discovery.k8s.io/v1"),
+	deprecation("events.k8s.io", "v1beta1", "Event", "v1.21", "v1.25", "events.k8s.io/v1"),
+	deprecation("autoscaling", "v2beta1", "HorizontalPodAutoscaler", "v1.23", "v1.25", "autoscaling/v2"),
+	deprecation("node.k8s.io", "v1beta1", "RuntimeClass", "v1.20", "v1.25", "node.k8s.io/v1"),
+	deprecation("policy", "v1beta1", "PodDisruptionBudget", "v1.21", "v1.25", "policy/v1"),
+	deprecation("policy", "v1beta1", "PodSecurityPolicy", "v1.21", "v1.25", ""),
+	deprecation("autoscaling", "v2beta2", "HorizontalPodAutoscaler", "v1.23", "v1.26", "autoscaling/v2"),
+	deprecation("flowcontrol.apiserver.k8s.io", "v1beta1", "FlowSchema", "v1.23", "v1.26", "flowcontrol.apiserver.k8s.io/v1"),
+	deprecation("flowcontrol.apiserver.k8s.io", "v1beta1", "PriorityLevelConfiguration", "v1.23", "v1.26",
+		"flowcontrol.apiserver.k8s.io/v1"),
+	deprecation("storage.k8s.io", "v1beta1", "CSIStorageCapacity", "v1.24", "v1.27", "storage.k8s.io/v1"),
+	deprecation("flowcontrol.apiserver.k8s.io", "v1beta2", "FlowSchema", "v1.26", "v1.29", "flowcontrol.apiserver.k8s.io/v1"),
+	deprecation("flowcontrol.apiserver.k8s.io", "v1beta2", "PriorityLevelConfiguration", "v1.26", "v1.29",
+		"flowcontrol.apiserver.k8s.io/v1"),
+	deprecation("flowcontrol.apiserver.k8s.io", "v1beta3", "FlowSchema", "v1.29", "v1.32", "flowcontrol.apiserver.k8s.io/v1"),
+	deprecation("flowcontrol.apiserver.k8s.io", "v1beta3", "PriorityLevelConfiguration", "v1.29", "v1.32",
+		"flowcontrol.apiserver.k8s.io/v1"),
+}
+
+func deprecation(
+	group string,
+	apiVersion string,
+	kind string,
+	deprecatedIn string,
+	removedIn string,
+	replacement string,
+) APIDeprecation {
+	return APIDeprecation{
+		GroupVersionKind: schema.GroupVersionKind{Group: group, Version: apiVersion, Kind: kind},
+		DeprecatedIn:     deprecatedIn,
+		RemovedIn:        removedIn,
+		Replacement:      replacement,
+	}
+}
+
+// APIDeprecations returns the built-in table of deprecated Kubernetes APIs,
+// following the Kubernetes deprecated API migration guide.
+func APIDeprecations() []APIDeprecation {
+	return append([]APIDeprecation(nil), apiDeprecations...)
+}
+
+// APILifecycle returns a Validator flagging objects that use APIs deprecated
+// or removed in the targetVersion of Kubernetes (e.g. "v1.29" or "1.29.3").
+//
+// Objects using a removed API produce a RuleAPIRemoved error, and objects
+// using a deprecated API a RuleAPIDeprecated warning, or an error with
+// WithStrictAPILifecycle. Messages name the replacement apiVersion to migrate
+// to. The built-in table, returned by APIDeprecations, can be extended with
+// WithAPIDeprecation, e.g. for CRD versions.
+func APILifecycle(targetVersion string, opts ...APILifecycleOption) Validator {
+	options := APILifecycleOptions{
+		Deprecations: APIDeprecations(),
+	}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	target, err := version.ParseGeneric(targetVersion)
+	if err != nil {
+		return failing(fmt.Errorf("invalid target version %q: %w", targetVersion, err))
+	}
+
+	type lifecycle struct {
+		APIDeprecation
+
+		deprecated bool
+		removed    bool
+	}
+
+	lifecycles := make(map[schema.GroupVersionKind]lifecycle, len(options.Deprecations))
+
+	for _, d := range options.Deprecations {
+		deprecated, err := reached(target, d.DeprecatedIn)
+		if err != nil {
+			return failing(fmt.Errorf("invalid deprecation version of %s: %w", d.GroupVersionKind, err))
+		}
+
+		removed, err := reached(target, d.RemovedIn)
+		if err != nil {
+			return failing(fmt.Errorf("invalid removal version of %s: %w", d.GroupVersionKind, err))
+		}
+
+		lifecycles[d.GroupVersionKind] = lifecycle{APIDeprecation: d, deprecated: deprecated, removed: removed}
+	}
+
+	deprecatedSeverity := SeverityWarning
+	if options.Strict {
+		deprecatedSeverity = SeverityError
+	}
+
+	return Func(func(_ context.Context, objects []unstructured.Unstructured) (Findings, error) {
+		var findings Findings
+
+		for i := range objects {
+			l, ok := lifecycles[objects[i].GroupVersionKind()]
+			if !ok {
+				continue
+			}
+
+			finding := Finding{Object: k8s.KeyOf(&objects[i]), Path: "apiVersion"}
+
+			switch {
+			case l.removed:
+				finding.Rule = RuleAPIRemoved
+				finding.Severity = SeverityError
+				finding.Message = fmt.Sprintf("%s %s is no longer served since %s",
+					objects[i].GetAPIVersion(), l.GroupVersionKind.Kind, l.RemovedIn)
+			case l.deprecated:
+				finding.Rule = RuleAPIDeprecated
+				finding.Severity = deprecatedSeverity
+				finding.Message = fmt.Sprintf("%s %s is deprecated since %s",
+					objects[i].GetAPIVersion(), l.GroupVersionKind.Kind, l.DeprecatedIn)
+
+				if l.RemovedIn != "" {
+					finding.Message += " and removed in " + l.RemovedIn
+				}
+			default:
+				continue
+			}
+
+			if l.Replacement != "" {
+				finding.Message += "; use " + l.Replacement
+			} else {
+				finding.Message += "; no replacement is available"
+			}
+
+			findings = append(findings, finding)
+		}
+
+		return findings, nil
+	})
+}
+
+// reached reports whether target is at or after the lifecycle version v; an
+// empty v is never reached.
+func reached(target *version.Version, v string) (bool, error) {
+	if v == "" {
+		return false, nil
+	}
+
+	parsed, err := version.ParseGeneric(v)
+	if err != nil {
+		return false, fmt.Errorf("%q: %w", v, err)
+	}
+
+	return target.AtLeast(parsed), nil
+}
+
+// failing returns a Validator always failing with err, for constructors
+// reporting invalid configuration.
+func failing(err error) Validator {
+	return Func(func(_ context.Context, _ []unstructured.Unstructured) (Findings, error) {
+		return nil, err
+	})
+}
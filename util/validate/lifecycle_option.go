@@ -0,0 +1,41 @@
+package validate
+
+import (
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// APILifecycleOption is a generic option for APILifecycle.
+type APILifecycleOption = util.Option[APILifecycleOptions]
+
+// APILifecycleOptions is a struct-based option that can set API lifecycle options.
+type APILifecycleOptions struct {
+	// Deprecations is the table of deprecated APIs; entries added later
+	// override earlier entries for the same GroupVersionKind.
+	Deprecations []APIDeprecation
+
+	// Strict reports deprecated APIs as errors.
+	Strict bool
+}
+
+// ApplyTo applies the API lifecycle options to the target configuration.
+func (opts APILifecycleOptions) ApplyTo(target *APILifecycleOptions) {
+	target.Deprecations = append(target.Deprecations, opts.Deprecations...)
+
+	if opts.Strict {
+		target.Strict = true
+	}
+}
+
+// WithAPIDeprecation adds an entry to the table of deprecated APIs.
+func WithAPIDeprecation(deprecation APIDeprecation) APILifecycleOption {
+	return util.FunctionalOption[APILifecycleOptions](func(opts *APILifecycleOptions) {
+		opts.Deprecations = append(opts.Deprecations, deprecation)
+	})
+}
+
+// WithStrictAPILifecycle reports deprecated APIs as errors, failing the render.
+func WithStrictAPILifecycle() APILifecycleOption {
+	return util.FunctionalOption[APILifecycleOptions](func(opts *APILifecycleOptions) {
+		opts.Strict = true
+	})
+}
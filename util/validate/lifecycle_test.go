@@ -0,0 +1,88 @@
+package validate_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAPILifecycle(t *testing.T) {
+	objects := []unstructured.Unstructured{
+		newObject("batch/v1beta1", "CronJob", "cleanup", nil),
+		newObject("flowcontrol.apiserver.k8s.io/v1beta3", "FlowSchema", "system", nil),
+		newObject("apps/v1", "Deployment", "web", nil),
+	}
+
+	t.Run("should flag removed and deprecated APIs", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := validate.APILifecycle("v1.29").Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(
+			And(
+				HaveField("Rule", validate.RuleAPIRemoved),
+				HaveField("Severity", validate.SeverityError),
+				HaveField("Object.Name", "cleanup"),
+				HaveField("Path", "apiVersion"),
+				HaveField("Message", "batch/v1beta1 CronJob is no longer served since v1.25; use batch/v1"),
+			),
+			And(
+				HaveField("Rule", validate.RuleAPIDeprecated),
+				HaveField("Severity", validate.SeverityWarning),
+				HaveField("Message", ContainSubstring("deprecated since v1.29 and removed in v1.32")),
+			),
+		))
+	})
+
+	t.Run("should ignore APIs deprecated after the target version", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := validate.APILifecycle("1.20.4").Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(BeEmpty())
+	})
+
+	t.Run("should report deprecated APIs as errors in strict mode", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := validate.APILifecycle("v1.22", validate.WithStrictAPILifecycle()).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(And(
+			HaveField("Rule", validate.RuleAPIDeprecated),
+			HaveField("Severity", validate.SeverityError),
+			HaveField("Object.Name", "cleanup"),
+		)))
+	})
+
+	t.Run("should use additional deprecations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := validate.APILifecycle("v1.30", validate.WithAPIDeprecation(validate.APIDeprecation{
+			GroupVersionKind: schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "Widget"},
+			DeprecatedIn:     "v1.28",
+		})).Validate(t.Context(), []unstructured.Unstructured{
+			newObject("example.com/v1alpha1", "Widget", "w", nil),
+		})
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(
+			HaveField("Message", "example.com/v1alpha1 Widget is deprecated since v1.28; no replacement is available"),
+		))
+	})
+
+	t.Run("should reject invalid target versions", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := validate.APILifecycle("latest").Validate(t.Context(), objects)
+
+		g.Expect(err).Should(MatchError(ContainSubstring(`invalid target version "latest"`)))
+	})
+}
@@ -0,0 +1,272 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/jsonschema"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// openAPIIndexPath is the discovery path of the OpenAPI v3 documents.
+const openAPIIndexPath = "/openapi/v3"
+
+// gvkExtension lists the kinds a schema of an OpenAPI document describes.
+const gvkExtension = "x-kubernetes-group-version-kind"
+
+// OpenAPIFetcher returns the document served by the target cluster at a
+// server-relative path, such as "/openapi/v3" or
+// "/openapi/v3/apis/apps/v1?hash=...". With client-go, it is typically
+// implemented with the discovery REST client:
+//
+//	func(ctx context.Context, path string) ([]byte, error) {
+//	    return client.Discovery().RESTClient().Get().AbsPath(path).Do(ctx).Raw()
+//	}
+type OpenAPIFetcher func(ctx context.Context, path string) ([]byte, error)
+
+// OpenAPI returns a Validator checking objects against the OpenAPI v3 schemas
+// served by the target cluster, including the schemas of installed CRDs, so
+// that validation matches what the cluster will accept.
+//
+// The discovery index and the documents of the group versions in use are
+// fetched on first use and cached for the lifetime of the validator, as are
+// compiled schemas; create a new validator to pick up changes to the cluster.
+// Objects of kinds the cluster does not serve produce a RuleSchemaMissing
+// finding. Null fields are dropped before validation, as the API server
+// does when decoding objects.
+func OpenAPI(fetch OpenAPIFetcher) Validator {
+	return &openAPI{
+		fetch:    fetch,
+		versions: make(map[schema.GroupVersion]map[string]any),
+		schemas:  make(map[schema.GroupVersionKind]*jsonschema.Schema),
+	}
+}
+
+type openAPI struct {
+	fetch OpenAPIFetcher
+
+	mu sync.Mutex
+	// index maps discovery paths such as "apis/apps/v1" to document URLs.
+	index map[string]string
+	// versions caches the component schemas of each group version.
+	versions map[schema.GroupVersion]map[string]any
+	// schemas caches compiled schemas by GVK; nil records a missing schema.
+	schemas map[schema.GroupVersionKind]*jsonschema.Schema
+}
+
+func (o *openAPI) Validate(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+	var findings Findings
+
+	for i := range objects {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		obj := &objects[i]
+		key := k8s.KeyOf(obj)
+		gvk := obj.GroupVersionKind()
+
+		if gvk.Version == "" || gvk.Kind == "" {
+			findings = append(findings, Finding{
+				Rule:     RuleSchema,
+				Severity: SeverityError,
+				Object:   key,
+				Message:  "missing apiVersion or kind",
+			})
+
+			continue
+		}
+
+		s, found, err := o.schema(ctx, gvk)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			findings = append(findings, Finding{
+				Rule:     RuleSchemaMissing,
+				Severity: SeverityError,
+				Object:   key,
+				Message:  fmt.Sprintf("the cluster does not serve %s", gvk.String()),
+			})
+
+			continue
+		}
+
+		err = s.Validate(withoutNulls(obj.Object))
+
+		var verr *jsonschema.ValidationError
+		if errors.As(err, &verr) {
+			for _, fe := range verr.Errors {
+				findings = append(findings, Finding{
+					Rule:     RuleSchema,
+					Severity: SeverityError,
+					Object:   key,
+					Path:     fieldPath(fe.Path),
+					Message:  fe.Message,
+				})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+func (o *openAPI) schema(ctx context.Context, gvk schema.GroupVersionKind) (*jsonschema.Schema, bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if s, ok := o.schemas[gvk]; ok {
+		return s, s != nil, nil
+	}
+
+	components, err := o.components(ctx, gvk.GroupVersion())
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, ok := findComponent(components, gvk)
+	if !ok {
+		o.schemas[gvk] = nil
+
+		return nil, false, nil
+	}
+
+	s, err := jsonschema.New(map[string]any{
+		"$ref":       "#/components/schemas/" + name,
+		"components": map[string]any{"schemas": components},
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to compile schema %s: %w", name, err)
+	}
+
+	o.schemas[gvk] = s
+
+	return s, true, nil
+}
+
+// components returns the component schemas of the OpenAPI document of gv,
+// which are empty when the cluster does not serve gv.
+func (o *openAPI) components(ctx context.Context, gv schema.GroupVersion) (map[string]any, error) {
+	if components, ok := o.versions[gv]; ok {
+		return components, nil
+	}
+
+	if o.index == nil {
+		index, err := o.fetchIndex(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		o.index = index
+	}
+
+	path := "apis/" + gv.Group + "/" + gv.Version
+	if gv.Group == "" {
+		path = "api/" + gv.Version
+	}
+
+	url, ok := o.index[path]
+	if !ok {
+		o.versions[gv] = map[string]any{}
+
+		return o.versions[gv], nil
+	}
+
+	data, err := o.fetch(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch OpenAPI document %s: %w", path, err)
+	}
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unable to decode OpenAPI document %s: %w", path, err)
+	}
+
+	o.versions[gv] = doc.Components.Schemas
+
+	return doc.Components.Schemas, nil
+}
+
+func (o *openAPI) fetchIndex(ctx context.Context) (map[string]string, error) {
+	data, err := o.fetch(ctx, openAPIIndexPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch OpenAPI index: %w", err)
+	}
+
+	var index struct {
+		Paths map[string]struct {
+			ServerRelativeURL string `json:"serverRelativeURL"`
+		} `json:"paths"`
+	}
+
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("unable to decode OpenAPI index: %w", err)
+	}
+
+	result := make(map[string]string, len(index.Paths))
+	for path, entry := range index.Paths {
+		result[path] = entry.ServerRelativeURL
+	}
+
+	return result, nil
+}
+
+// findComponent returns the name of the component schema describing gvk.
+func findComponent(components map[string]any, gvk schema.GroupVersionKind) (string, bool) {
+	for name, component := range components {
+		c, ok := component.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		kinds, _ := c[gvkExtension].([]any)
+		for _, kind := range kinds {
+			k, ok := kind.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if k["group"] == gvk.Group && k["version"] == gvk.Version && k["kind"] == gvk.Kind {
+				return name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// withoutNulls returns a copy of value without null map entries.
+func withoutNulls(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			if item != nil {
+				result[key] = withoutNulls(item)
+			}
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = withoutNulls(item)
+		}
+
+		return result
+	default:
+		return value
+	}
+}
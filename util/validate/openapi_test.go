@@ -0,0 +1,168 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+const testOpenAPIIndex = `{
+  "paths": {
+    "api/v1": {"serverRelativeURL": "/openapi/v3/api/v1?hash=CORE"},
+    "apis/apps/v1": {"serverRelativeURL": "/openapi/v3/apis/apps/v1?hash=APPS"}
+  }
+}`
+
+const testOpenAPIApps = `{
+  "openapi": "3.0.0",
+  "components": {
+    "schemas": {
+      "io.k8s.api.apps.v1.Deployment": {
+        "type": "object",
+        "properties": {
+          "apiVersion": {"type": "string"},
+          "kind": {"type": "string"},
+          "metadata": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}]},
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}]}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.DeploymentSpec": {
+        "type": "object",
+        "required": ["selector"],
+        "properties": {
+          "replicas": {"type": "integer", "format": "int32"},
+          "selector": {"type": "object"}
+        }
+      },
+      "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "namespace": {"type": "string"},
+          "creationTimestamp": {"type": "string", "format": "date-time"}
+        }
+      }
+    }
+  }
+}`
+
+var errFetchFailed = errors.New("fetch failed")
+
+type fakeCluster struct {
+	documents map[string]string
+	fetches   []string
+}
+
+func (c *fakeCluster) fetch(_ context.Context, path string) ([]byte, error) {
+	c.fetches = append(c.fetches, path)
+
+	doc, ok := c.documents[path]
+	if !ok {
+		return nil, errFetchFailed
+	}
+
+	return []byte(doc), nil
+}
+
+func newFakeCluster() *fakeCluster {
+	return &fakeCluster{
+		documents: map[string]string{
+			"/openapi/v3":                        testOpenAPIIndex,
+			"/openapi/v3/apis/apps/v1?hash=APPS": testOpenAPIApps,
+		},
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	t.Run("should validate objects against cluster schemas", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newFakeCluster()
+		objects := []unstructured.Unstructured{
+			newObject("apps/v1", "Deployment", "web", map[string]any{
+				"spec": map[string]any{"replicas": "two", "selector": map[string]any{}},
+			}),
+		}
+
+		findings, err := validate.OpenAPI(cluster.fetch).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(And(
+			HaveField("Rule", validate.RuleSchema),
+			HaveField("Path", "spec.replicas"),
+		)))
+	})
+
+	t.Run("should ignore null fields", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newFakeCluster()
+		obj := newObject("apps/v1", "Deployment", "web", map[string]any{
+			"spec": map[string]any{"selector": map[string]any{}},
+		})
+		obj.Object["metadata"].(map[string]any)["creationTimestamp"] = nil
+
+		findings, err := validate.OpenAPI(cluster.fetch).Validate(t.Context(), []unstructured.Unstructured{obj})
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(BeEmpty())
+		g.Expect(obj.Object["metadata"]).Should(HaveKey("creationTimestamp"))
+	})
+
+	t.Run("should report kinds the cluster does not serve", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newFakeCluster()
+		objects := []unstructured.Unstructured{
+			newObject("apps/v1", "StatefulSet", "db", nil),
+			newObject("example.com/v1", "Widget", "w", nil),
+		}
+
+		findings, err := validate.OpenAPI(cluster.fetch).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(
+			HaveField("Rule", validate.RuleSchemaMissing),
+			HaveField("Rule", validate.RuleSchemaMissing),
+		))
+	})
+
+	t.Run("should cache discovery documents", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newFakeCluster()
+		v := validate.OpenAPI(cluster.fetch)
+		objects := []unstructured.Unstructured{
+			newObject("apps/v1", "Deployment", "a", map[string]any{"spec": map[string]any{"selector": map[string]any{}}}),
+			newObject("apps/v1", "Deployment", "b", map[string]any{"spec": map[string]any{"selector": map[string]any{}}}),
+		}
+
+		_, err := v.Validate(t.Context(), objects)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = v.Validate(t.Context(), objects)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(cluster.fetches).Should(Equal([]string{"/openapi/v3", "/openapi/v3/apis/apps/v1?hash=APPS"}))
+	})
+
+	t.Run("should fail when documents cannot be fetched", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newFakeCluster()
+		objects := []unstructured.Unstructured{
+			newObject("v1", "ConfigMap", "settings", nil),
+		}
+
+		_, err := validate.OpenAPI(cluster.fetch).Validate(t.Context(), objects)
+
+		g.Expect(err).Should(MatchError(errFetchFailed))
+	})
+}
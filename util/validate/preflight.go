@@ -0,0 +1,355 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+const (
+	// RuleNamespaceMissing identifies findings reporting namespaces that
+	// neither exist in the target cluster nor are created by the render.
+	RuleNamespaceMissing = "namespace-missing"
+
+	// RuleQuotaExceeded identifies findings reporting ResourceQuotas the
+	// render does not fit in.
+	RuleQuotaExceeded = "quota-exceeded"
+)
+
+// ObjectLister returns the live objects of a kind in a namespace of the target
+// cluster. With client-go, it is typically implemented with a dynamic client:
+//
+//	func(ctx context.Context, gvk schema.GroupVersionKind, namespace string) ([]unstructured.Unstructured, error) {
+//	    mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    list, err := client.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return list.Items, nil
+//	}
+type ObjectLister func(ctx context.Context, gvk schema.GroupVersionKind, namespace string) ([]unstructured.Unstructured, error)
+
+// Preflight returns a Validator running the checks against the target cluster
+// that catch failures before anything is applied: ImmutableFields, Namespaces
+// and ResourceQuotas.
+func Preflight(get ObjectGetter, list ObjectLister) Validator {
+	return All(ImmutableFields(get), Namespaces(get), ResourceQuotas(list))
+}
+
+// Namespaces returns a Validator reporting the namespaces objects target that
+// neither exist in the target cluster, as returned by get, nor are created by
+// the render. Findings are reported once per namespace, in name order.
+func Namespaces(get ObjectGetter) Validator {
+	return Func(func(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+		targets := make(map[string]int)
+		created := make(map[string]bool)
+
+		for i := range objects {
+			if ns := objects[i].GetNamespace(); ns != "" {
+				targets[ns]++
+			}
+
+			if objects[i].GroupVersionKind().GroupKind() == (schema.GroupKind{Kind: "Namespace"}) {
+				created[objects[i].GetName()] = true
+			}
+		}
+
+		var findings Findings
+
+		for _, ns := range slices.Sorted(maps.Keys(targets)) {
+			if created[ns] {
+				continue
+			}
+
+			namespace := unstructured.Unstructured{}
+			namespace.SetAPIVersion("v1")
+			namespace.SetKind("Namespace")
+			namespace.SetName(ns)
+
+			_, found, err := get(ctx, &namespace)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get namespace %s: %w", ns, err)
+			}
+
+			if !found {
+				findings = append(findings, Finding{
+					Rule:     RuleNamespaceMissing,
+					Severity: SeverityError,
+					Object:   k8s.KeyOf(&namespace),
+					Message:  fmt.Sprintf("does not exist and is not created by the render; %d objects target it", targets[ns]),
+				})
+			}
+		}
+
+		return findings, nil
+	})
+}
+
+// ResourceQuotas returns a Validator checking that the resources requested by
+// the objects of each namespace fit in what remains of the ResourceQuotas
+// returned by list, that is their hard limits minus their current usage.
+//
+// Compute resources (cpu, memory and ephemeral-storage, as requests or
+// limits) are summed over the pods of the built-in workloads: the replicas of
+// Deployments, ReplicaSets, StatefulSets and ReplicationControllers, the
+// parallelism of Jobs and CronJobs, and one pod per Pod and DaemonSet. Pod
+// and object counts cover pods, services, configmaps, secrets and
+// persistentvolumeclaims, and requests.storage sums the storage requested by
+// PersistentVolumeClaims. Other quota resources and scoped quotas are not
+// checked.
+//
+// The whole render is counted against the remaining quota, so objects
+// replacing existing ones are counted twice; the check is most accurate for
+// first installations.
+func ResourceQuotas(list ObjectLister) Validator {
+	return Func(func(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+		usage := quotaUsage(objects)
+
+		var findings Findings
+
+		for _, ns := range slices.Sorted(maps.Keys(usage)) {
+			quotas, err := list(ctx, schema.GroupVersionKind{Version: "v1", Kind: "ResourceQuota"}, ns)
+			if err != nil {
+				return nil, fmt.Errorf("unable to list resource quotas of namespace %s: %w", ns, err)
+			}
+
+			for i := range quotas {
+				findings = append(findings, checkQuota(&quotas[i], usage[ns])...)
+			}
+		}
+
+		return findings, nil
+	})
+}
+
+// checkQuota returns the resources of quota the usage does not fit in.
+func checkQuota(quota *unstructured.Unstructured, usage map[string]resource.Quantity) Findings {
+	if scopes, _, _ := unstructured.NestedSlice(quota.Object, "spec", "scopes"); len(scopes) > 0 {
+		return nil
+	}
+
+	if _, scoped, _ := unstructured.NestedMap(quota.Object, "spec", "scopeSelector"); scoped {
+		return nil
+	}
+
+	hard, _, _ := unstructured.NestedStringMap(quota.Object, "status", "hard")
+	if len(hard) == 0 {
+		hard, _, _ = unstructured.NestedStringMap(quota.Object, "spec", "hard")
+	}
+
+	used, _, _ := unstructured.NestedStringMap(quota.Object, "status", "used")
+
+	var findings Findings
+
+	for _, name := range slices.Sorted(maps.Keys(hard)) {
+		need, ok := usage[name]
+		if !ok || need.IsZero() {
+			continue
+		}
+
+		limit, err := resource.ParseQuantity(hard[name])
+		if err != nil {
+			continue
+		}
+
+		remaining := limit.DeepCopy()
+
+		if u, err := resource.ParseQuantity(used[name]); err == nil {
+			remaining.Sub(u)
+		}
+
+		if need.Cmp(remaining) > 0 {
+			findings = append(findings, Finding{
+				Rule:     RuleQuotaExceeded,
+				Severity: SeverityError,
+				Object:   k8s.KeyOf(quota),
+				Path:     "spec.hard",
+				Message: fmt.Sprintf("the render needs %s %s but only %s of %s remain",
+					need.String(), name, remaining.String(), limit.String()),
+			})
+		}
+	}
+
+	return findings
+}
+
+// quotaWorkload locates the pod spec of a workload kind, and the field holding
+// its number of pods, which defaults to one.
+type quotaWorkload struct {
+	spec []string
+	pods []string
+}
+
+// quotaWorkloads lists the built-in workload kinds counted against quotas.
+var quotaWorkloads = map[schema.GroupKind]quotaWorkload{
+	{Kind: "Pod"}:                        {spec: []string{"spec"}},
+	{Kind: "ReplicationController"}:      {spec: []string{"spec", "template", "spec"}, pods: []string{"spec", "replicas"}},
+	{Group: "apps", Kind: "Deployment"}:  {spec: []string{"spec", "template", "spec"}, pods: []string{"spec", "replicas"}},
+	{Group: "apps", Kind: "ReplicaSet"}:  {spec: []string{"spec", "template", "spec"}, pods: []string{"spec", "replicas"}},
+	{Group: "apps", Kind: "StatefulSet"}: {spec: []string{"spec", "template", "spec"}, pods: []string{"spec", "replicas"}},
+	{Group: "apps", Kind: "DaemonSet"}:   {spec: []string{"spec", "template", "spec"}},
+	{Group: "batch", Kind: "Job"}:        {spec: []string{"spec", "template", "spec"}, pods: []string{"spec", "parallelism"}},
+	{Group: "batch", Kind: "CronJob"}: {
+		spec: []string{"spec", "jobTemplate", "spec", "template", "spec"},
+		pods: []string{"spec", "jobTemplate", "spec", "parallelism"},
+	},
+}
+
+// quotaCounts maps the object count resources of quotas to their kinds.
+var quotaCounts = map[schema.GroupKind]string{
+	{Kind: "Service"}:               "services",
+	{Kind: "ConfigMap"}:             "configmaps",
+	{Kind: "Secret"}:                "secrets",
+	{Kind: "PersistentVolumeClaim"}: "persistentvolumeclaims",
+}
+
+// quotaUsage returns the quota resources the objects use, by namespace.
+func quotaUsage(objects []unstructured.Unstructured) map[string]map[string]resource.Quantity {
+	usage := make(map[string]map[string]resource.Quantity)
+
+	add := func(ns string, name string, q resource.Quantity) {
+		if usage[ns] == nil {
+			usage[ns] = make(map[string]resource.Quantity)
+		}
+
+		total := usage[ns][name]
+		total.Add(q)
+		usage[ns][name] = total
+	}
+
+	for i := range objects {
+		obj := &objects[i]
+
+		ns := obj.GetNamespace()
+		if ns == "" {
+			continue
+		}
+
+		gk := obj.GroupVersionKind().GroupKind()
+
+		if name, ok := quotaCounts[gk]; ok {
+			add(ns, name, *resource.NewQuantity(1, resource.DecimalSI))
+		}
+
+		if gk == (schema.GroupKind{Kind: "PersistentVolumeClaim"}) {
+			if storage, ok := storageRequest(obj); ok {
+				add(ns, "requests.storage", storage)
+			}
+		}
+
+		workload, ok := quotaWorkloads[gk]
+		if !ok {
+			continue
+		}
+
+		spec, found, _ := unstructured.NestedMap(obj.Object, workload.spec...)
+		if !found {
+			continue
+		}
+
+		pods := int64(1)
+		if len(workload.pods) > 0 {
+			if n, found, err := unstructured.NestedInt64(obj.Object, workload.pods...); found && err == nil {
+				pods = n
+			}
+		}
+
+		add(ns, "pods", *resource.NewQuantity(pods, resource.DecimalSI))
+
+		for name, q := range podResources(spec) {
+			q.Mul(pods)
+			add(ns, name, q)
+		}
+	}
+
+	return usage
+}
+
+// podResources returns the compute resources of a pod spec under their quota
+// names: the sum over containers, or the largest init container when larger.
+// Containers without requests request their limits.
+func podResources(spec map[string]any) map[string]resource.Quantity {
+	result := make(map[string]resource.Quantity)
+
+	for _, field := range []string{"containers", "initContainers"} {
+		sums := make(map[string]resource.Quantity)
+
+		items, _ := spec[field].([]any)
+		for _, item := range items {
+			container, _ := item.(map[string]any)
+
+			for name, q := range containerResources(container) {
+				if field == "initContainers" {
+					if q.Cmp(sums[name]) > 0 {
+						sums[name] = q
+					}
+
+					continue
+				}
+
+				total := sums[name]
+				total.Add(q)
+				sums[name] = total
+			}
+		}
+
+		for name, q := range sums {
+			if q.Cmp(result[name]) > 0 {
+				result[name] = q
+			}
+		}
+	}
+
+	return result
+}
+
+// containerResources returns the compute resources of a container under their
+// quota names.
+func containerResources(container map[string]any) map[string]resource.Quantity {
+	limits, _, _ := unstructured.NestedMap(container, "resources", "limits")
+	requests, _, _ := unstructured.NestedMap(container, "resources", "requests")
+
+	result := make(map[string]resource.Quantity)
+
+	for _, name := range []string{"cpu", "memory", "ephemeral-storage"} {
+		if q, ok := quantity(limits[name]); ok {
+			result["limits."+name] = q
+		}
+
+		q, ok := quantity(requests[name])
+		if !ok {
+			q, ok = quantity(limits[name])
+		}
+
+		if ok {
+			result["requests."+name] = q
+			result[name] = q
+		}
+	}
+
+	return result
+}
+
+// quantity parses a resource quantity of an unstructured object.
+func quantity(value any) (resource.Quantity, bool) {
+	if value == nil {
+		return resource.Quantity{}, false
+	}
+
+	q, err := resource.ParseQuantity(fmt.Sprint(value))
+	if err != nil {
+		return resource.Quantity{}, false
+	}
+
+	return q, true
+}
@@ -0,0 +1,195 @@
+package validate_test
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+// liveQuotas returns an ObjectLister serving ResourceQuotas by namespace.
+func liveQuotas(quotas ...unstructured.Unstructured) validate.ObjectLister {
+	return func(_ context.Context, gvk schema.GroupVersionKind, namespace string) ([]unstructured.Unstructured, error) {
+		var result []unstructured.Unstructured
+
+		for _, q := range quotas {
+			if q.GetKind() == gvk.Kind && q.GetNamespace() == namespace {
+				result = append(result, q)
+			}
+		}
+
+		return result, nil
+	}
+}
+
+func newNamespaced(namespace string, obj unstructured.Unstructured) unstructured.Unstructured {
+	obj.SetNamespace(namespace)
+
+	return obj
+}
+
+func newQuota(name string, hard map[string]any, used map[string]any) unstructured.Unstructured {
+	return newObject("v1", "ResourceQuota", name, map[string]any{
+		"spec":   map[string]any{"hard": hard},
+		"status": map[string]any{"hard": hard, "used": used},
+	})
+}
+
+func newWorkload(kind string, name string, replicas int64, requests map[string]any) unstructured.Unstructured {
+	return newObject("apps/v1", kind, name, map[string]any{
+		"spec": map[string]any{
+			"replicas": replicas,
+			"template": map[string]any{
+				"spec": map[string]any{
+					"initContainers": []any{map[string]any{
+						"name":      "migrate",
+						"resources": map[string]any{"requests": map[string]any{"cpu": "2"}},
+					}},
+					"containers": []any{
+						map[string]any{"name": "app", "resources": map[string]any{"requests": requests}},
+						map[string]any{"name": "proxy", "resources": map[string]any{"limits": map[string]any{"cpu": "100m"}}},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestNamespaces(t *testing.T) {
+	t.Run("should report namespaces that neither exist nor are created", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := []unstructured.Unstructured{
+			newObject("v1", "ConfigMap", "existing", nil),
+			newNamespaced("shop", newObject("v1", "ConfigMap", "created", nil)),
+			newObject("v1", "Namespace", "shop", map[string]any{"metadata": map[string]any{"name": "shop"}}),
+			newNamespaced("orders", newObject("v1", "ConfigMap", "a", nil)),
+			newNamespaced("orders", newObject("v1", "Secret", "b", nil)),
+		}
+
+		findings, err := validate.Namespaces(liveObjects(
+			newObject("v1", "Namespace", "default", map[string]any{"metadata": map[string]any{"name": "default"}}),
+		)).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(And(
+			HaveField("Rule", validate.RuleNamespaceMissing),
+			HaveField("Severity", validate.SeverityError),
+			HaveField("Object.Kind", "Namespace"),
+			HaveField("Object.Name", "orders"),
+			HaveField("Message", "does not exist and is not created by the render; 2 objects target it"),
+		)))
+	})
+
+	t.Run("should fail when namespaces cannot be read", func(t *testing.T) {
+		g := NewWithT(t)
+
+		failing := func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+			return nil, false, errGetFailed
+		}
+
+		_, err := validate.Namespaces(failing).Validate(t.Context(), []unstructured.Unstructured{
+			newObject("v1", "ConfigMap", "a", nil),
+		})
+
+		g.Expect(err).Should(MatchError(errGetFailed))
+	})
+}
+
+func TestResourceQuotas(t *testing.T) {
+	objects := []unstructured.Unstructured{
+		newWorkload("Deployment", "web", 3, map[string]any{"cpu": "500m", "memory": "256Mi"}),
+		newWorkload("StatefulSet", "db", 1, map[string]any{"cpu": "1", "memory": "1Gi"}),
+		newObject("v1", "PersistentVolumeClaim", "data", map[string]any{
+			"spec": map[string]any{"resources": map[string]any{"requests": map[string]any{"storage": "50Gi"}}},
+		}),
+	}
+
+	t.Run("should accept renders fitting the remaining quota", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := validate.ResourceQuotas(liveQuotas(
+			newQuota("compute",
+				map[string]any{"requests.cpu": "10", "requests.memory": "4Gi", "pods": "10"},
+				map[string]any{"requests.cpu": "2", "requests.memory": "1Gi", "pods": "2"},
+			),
+		)).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(BeEmpty())
+	})
+
+	t.Run("should report shortfalls per quota resource", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := validate.ResourceQuotas(liveQuotas(
+			newQuota("compute",
+				map[string]any{"requests.cpu": "4", "limits.cpu": "300m", "pods": "4"},
+				map[string]any{"requests.cpu": "500m", "pods": "1"},
+			),
+			newQuota("storage",
+				map[string]any{"requests.storage": "100Gi", "persistentvolumeclaims": "5"},
+				map[string]any{"requests.storage": "80Gi", "persistentvolumeclaims": "1"},
+			),
+		)).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(
+			And(
+				HaveField("Rule", validate.RuleQuotaExceeded),
+				HaveField("Severity", validate.SeverityError),
+				HaveField("Object.Name", "compute"),
+				HaveField("Path", "spec.hard"),
+				HaveField("Message", "the render needs 400m limits.cpu but only 300m of 300m remain"),
+			),
+			HaveField("Message", "the render needs 4 pods but only 3 of 4 remain"),
+			HaveField("Message", "the render needs 8 requests.cpu but only 3500m of 4 remain"),
+			And(
+				HaveField("Object.Name", "storage"),
+				HaveField("Message", "the render needs 50Gi requests.storage but only 20Gi of 100Gi remain"),
+			),
+		))
+	})
+
+	t.Run("should skip scoped quotas", func(t *testing.T) {
+		g := NewWithT(t)
+
+		quota := newQuota("best-effort", map[string]any{"pods": "1"}, nil)
+		g.Expect(unstructured.SetNestedStringSlice(quota.Object, []string{"BestEffort"}, "spec", "scopes")).Should(Succeed())
+
+		findings, err := validate.ResourceQuotas(liveQuotas(quota)).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(BeEmpty())
+	})
+}
+
+func TestPreflight(t *testing.T) {
+	t.Run("should run all checks against the cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := []unstructured.Unstructured{
+			newObject("v1", "Service", "web", map[string]any{"spec": map[string]any{"clusterIP": "10.0.0.20"}}),
+			newNamespaced("orders", newWorkload("Deployment", "api", 2, map[string]any{"cpu": "1"})),
+		}
+
+		findings, err := validate.Preflight(
+			liveObjects(
+				newObject("v1", "Service", "web", map[string]any{"spec": map[string]any{"clusterIP": "10.0.0.10"}}),
+				newObject("v1", "Namespace", "default", map[string]any{"metadata": map[string]any{"name": "default"}}),
+			),
+			liveQuotas(),
+		).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(HaveExactElements(
+			HaveField("Rule", validate.RuleImmutableField),
+			HaveField("Rule", validate.RuleNamespaceMissing),
+		))
+	})
+}
@@ -0,0 +1,206 @@
+package validate
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// JSONReportVersion identifies the schema of the documents written by
+// Report.WriteJSON; it changes only on incompatible changes.
+const JSONReportVersion = "v1"
+
+// Report aggregates the findings of a validation run.
+type Report struct {
+	// Findings are sorted by object, field path, rule and message.
+	Findings Findings
+
+	// Errors, Warnings and Infos count the findings by severity, leaving out
+	// exempted findings.
+	Errors   int
+	Warnings int
+	Infos    int
+
+	// Exempted counts the findings waived by an exemption.
+	Exempted int
+}
+
+// NewReport returns the report of findings.
+func NewReport(findings Findings) Report {
+	r := Report{Findings: findings.Sorted()}
+
+	for _, f := range r.Findings {
+		if f.Exempted {
+			r.Exempted++
+
+			continue
+		}
+
+		switch f.Severity {
+		case SeverityError:
+			r.Errors++
+		case SeverityWarning:
+			r.Warnings++
+		case SeverityInfo:
+			r.Infos++
+		}
+	}
+
+	return r
+}
+
+// Run validates objects with the given validators and reports their findings.
+func Run(ctx context.Context, objects []unstructured.Unstructured, validators ...Validator) (Report, error) {
+	findings, err := All(validators...).Validate(ctx, objects)
+	if err != nil {
+		return Report{}, err
+	}
+
+	return NewReport(findings), nil
+}
+
+// Passed reports whether no finding is as severe as threshold or more.
+func (r Report) Passed(threshold Severity) bool {
+	return len(r.Findings.AtLeast(threshold)) == 0
+}
+
+// Err returns a *FailedError listing the findings as severe as threshold or
+// more, or nil when the report passed.
+func (r Report) Err(threshold Severity) error {
+	failed := r.Findings.AtLeast(threshold)
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return &FailedError{Findings: failed}
+}
+
+// WriteText writes the report to w for humans and CI logs: one line per
+// finding followed by a summary line, which counts exempted findings when
+// there are any.
+func (r Report) WriteText(w io.Writer) error {
+	for _, f := range r.Findings {
+		if _, err := fmt.Fprintln(w, f.String()); err != nil {
+			return fmt.Errorf("unable to write report: %w", err)
+		}
+	}
+
+	summary := fmt.Sprintf("Errors: %d, warnings: %d, info: %d", r.Errors, r.Warnings, r.Infos)
+	if r.Exempted > 0 {
+		summary += fmt.Sprintf(", exempted: %d", r.Exempted)
+	}
+
+	if _, err := fmt.Fprintln(w, summary+"."); err != nil {
+		return fmt.Errorf("unable to write report: %w", err)
+	}
+
+	return nil
+}
+
+type jsonReport struct {
+	Version  string        `json:"version"`
+	Summary  jsonSummary   `json:"summary"`
+	Findings []jsonFinding `json:"findings"`
+}
+
+type jsonSummary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Infos    int `json:"infos"`
+	Exempted int `json:"exempted"`
+}
+
+type jsonFinding struct {
+	Rule          string     `json:"rule"`
+	Severity      Severity   `json:"severity"`
+	Object        jsonObject `json:"object"`
+	Path          string     `json:"path,omitempty"`
+	Message       string     `json:"message"`
+	Exempted      bool       `json:"exempted,omitempty"`
+	Justification string     `json:"justification,omitempty"`
+}
+
+type jsonObject struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// WriteJSON writes the report to w as a JSON document for tools, versioned by
+// JSONReportVersion:
+//
+//	{
+//	  "version": "v1",
+//	  "summary": {"errors": 1, "warnings": 0, "infos": 0, "exempted": 0},
+//	  "findings": [{
+//	    "rule": "schema",
+//	    "severity": "error",
+//	    "object": {"group": "apps", "kind": "Deployment", "namespace": "shop", "name": "api"},
+//	    "path": "spec.replicas",
+//	    "message": "..."
+//	  }]
+//	}
+//
+// Empty fields are omitted, except for findings, which is always a list.
+func (r Report) WriteJSON(w io.Writer) error {
+	doc := jsonReport{
+		Version: JSONReportVersion,
+		Summary: jsonSummary{
+			Errors:   r.Errors,
+			Warnings: r.Warnings,
+			Infos:    r.Infos,
+			Exempted: r.Exempted,
+		},
+		Findings: make([]jsonFinding, 0, len(r.Findings)),
+	}
+
+	for _, f := range r.Findings {
+		doc.Findings = append(doc.Findings, jsonFinding{
+			Rule:     f.Rule,
+			Severity: f.Severity,
+			Object: jsonObject{
+				Group:     f.Object.Group,
+				Kind:      f.Object.Kind,
+				Namespace: f.Object.Namespace,
+				Name:      f.Object.Name,
+			},
+			Path:          f.Path,
+			Message:       f.Message,
+			Exempted:      f.Exempted,
+			Justification: f.Justification,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("unable to write report: %w", err)
+	}
+
+	return nil
+}
+
+// Sorted returns a copy of the findings sorted by object, field path, rule
+// and message.
+func (f Findings) Sorted() Findings {
+	sorted := slices.Clone(f)
+
+	slices.SortStableFunc(sorted, func(a Finding, b Finding) int {
+		return cmp.Or(
+			cmp.Compare(a.Object.String(), b.Object.String()),
+			cmp.Compare(a.Path, b.Path),
+			cmp.Compare(a.Rule, b.Rule),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+
+	return sorted
+}
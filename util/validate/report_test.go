@@ -0,0 +1,167 @@
+package validate_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+const expectedTextReport = `error apps/Deployment/default/api spec.replicas: is invalid (schema)
+warning apps/Deployment/default/web: has no probes (probes)
+info core/ConfigMap/default/settings: is large (size)
+Errors: 1, warnings: 1, info: 1.
+`
+
+const expectedJSONReport = `{
+  "version": "v1",
+  "summary": {"errors": 1, "warnings": 1, "infos": 1, "exempted": 0},
+  "findings": [
+    {
+      "rule": "schema",
+      "severity": "error",
+      "object": {"group": "apps", "kind": "Deployment", "namespace": "default", "name": "api"},
+      "path": "spec.replicas",
+      "message": "is invalid"
+    },
+    {
+      "rule": "probes",
+      "severity": "warning",
+      "object": {"group": "apps", "kind": "Deployment", "namespace": "default", "name": "web"},
+      "message": "has no probes"
+    },
+    {
+      "rule": "size",
+      "severity": "info",
+      "object": {"kind": "ConfigMap", "namespace": "default", "name": "settings"},
+      "message": "is large"
+    }
+  ]
+}`
+
+func TestReport(t *testing.T) {
+	web := k8s.ResourceKey{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "web"}
+	api := k8s.ResourceKey{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "api"}
+	settings := k8s.ResourceKey{Kind: "ConfigMap", Namespace: "default", Name: "settings"}
+
+	findings := validate.Findings{
+		{Rule: "size", Severity: validate.SeverityInfo, Object: settings, Message: "is large"},
+		{Rule: "probes", Severity: validate.SeverityWarning, Object: web, Message: "has no probes"},
+		{Rule: "schema", Severity: validate.SeverityError, Object: api, Path: "spec.replicas", Message: "is invalid"},
+	}
+
+	t.Run("should sort and count findings", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := validate.NewReport(findings)
+
+		g.Expect(report.Findings).Should(HaveExactElements(
+			HaveField("Object", api),
+			HaveField("Object", web),
+			HaveField("Object", settings),
+		))
+		g.Expect(report.Errors).Should(Equal(1))
+		g.Expect(report.Warnings).Should(Equal(1))
+		g.Expect(report.Infos).Should(Equal(1))
+		g.Expect(findings[0].Object).Should(Equal(settings))
+	})
+
+	t.Run("should check the threshold", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report := validate.NewReport(findings[:2])
+
+		g.Expect(report.Passed(validate.SeverityError)).Should(BeTrue())
+		g.Expect(report.Err(validate.SeverityError)).ShouldNot(HaveOccurred())
+		g.Expect(report.Passed(validate.SeverityWarning)).Should(BeFalse())
+		g.Expect(report.Err(validate.SeverityWarning)).Should(MatchError(validate.ErrValidationFailed))
+	})
+
+	t.Run("should write text", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(validate.NewReport(findings).WriteText(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(Equal(expectedTextReport))
+	})
+
+	t.Run("should write JSON", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(validate.NewReport(findings).WriteJSON(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(MatchJSON(expectedJSONReport))
+
+		buf.Reset()
+		g.Expect(validate.NewReport(nil).WriteJSON(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(MatchJSON(`{
+			"version": "v1",
+			"summary": {"errors": 0, "warnings": 0, "infos": 0, "exempted": 0},
+			"findings": []
+		}`))
+	})
+
+	t.Run("should count exempted findings apart", func(t *testing.T) {
+		g := NewWithT(t)
+
+		exempted := validate.Finding{
+			Rule:          "host-network",
+			Severity:      validate.SeverityError,
+			Object:        web,
+			Message:       "uses the host network",
+			Exempted:      true,
+			Justification: "node agent",
+		}
+
+		report := validate.NewReport(append(validate.Findings{exempted}, findings[:2]...))
+
+		g.Expect(report.Errors).Should(Equal(0))
+		g.Expect(report.Exempted).Should(Equal(1))
+		g.Expect(report.Passed(validate.SeverityError)).Should(BeTrue())
+
+		var buf bytes.Buffer
+		g.Expect(report.WriteText(&buf)).Should(Succeed())
+		g.Expect(buf.String()).Should(ContainSubstring(
+			"error apps/Deployment/default/web: uses the host network (host-network) [exempted: node agent]\n"))
+		g.Expect(buf.String()).Should(HaveSuffix("Errors: 0, warnings: 1, info: 1, exempted: 1.\n"))
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should report the findings of all validators", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := []unstructured.Unstructured{
+			newObject("v1", "ConfigMap", "b", nil),
+			newObject("v1", "ConfigMap", "a", nil),
+		}
+
+		perObject := validate.Func(func(_ context.Context, objects []unstructured.Unstructured) (validate.Findings, error) {
+			var findings validate.Findings
+			for i := range objects {
+				findings = append(findings, validate.Finding{
+					Rule:     "named",
+					Severity: validate.SeverityWarning,
+					Object:   k8s.KeyOf(&objects[i]),
+				})
+			}
+
+			return findings, nil
+		})
+
+		report, err := validate.Run(t.Context(), objects, perObject, validate.APILifecycle("v1.30"))
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(report.Warnings).Should(Equal(2))
+		g.Expect(report.Findings).Should(HaveExactElements(
+			HaveField("Object.Name", "a"),
+			HaveField("Object.Name", "b"),
+		))
+	})
+}
@@ -0,0 +1,181 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+const (
+	// SARIFVersion is the SARIF specification version written by
+	// Report.WriteSARIF.
+	SARIFVersion = "2.1.0"
+
+	// DefaultSARIFToolName names the tool in SARIF logs unless WithSARIFTool
+	// is given.
+	DefaultSARIFToolName = "k8s-manifest-kit"
+
+	// sarifSchema is the JSON schema of SARIF 2.1.0 logs.
+	sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string        `json:"id"`
+	ShortDescription *sarifMessage `json:"shortDescription,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// sarifLevels maps severities to SARIF result levels.
+var sarifLevels = map[Severity]string{
+	SeverityError:   "error",
+	SeverityWarning: "warning",
+	SeverityInfo:    "note",
+}
+
+// WriteSARIF writes the report to w as a SARIF 2.1.0 log, as consumed by
+// GitHub code scanning and other CI annotation systems. Each finding is a
+// result of its rule, located by the object key and field path, and by the
+// file returned by the WithArtifactLocator locator when one is given; code
+// scanning services only annotate results with a file. Exempted findings are
+// reported as suppressed results carrying their justification. The log does
+// not depend on time or environment, so the same report produces the same log.
+func (r Report) WriteSARIF(w io.Writer, opts ...SARIFOption) error {
+	options := SARIFOptions{ToolName: DefaultSARIFToolName}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	ids := make(map[string]int)
+	for _, f := range r.Findings {
+		ids[f.Rule] = 0
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:    options.ToolName,
+			Version: options.ToolVersion,
+			Rules:   make([]sarifRule, 0, len(ids)),
+		}},
+		Results: make([]sarifResult, 0, len(r.Findings)),
+	}
+
+	for i, id := range slices.Sorted(maps.Keys(ids)) {
+		ids[id] = i
+
+		rule := sarifRule{ID: id}
+		if description := options.Descriptions[id]; description != "" {
+			rule.ShortDescription = &sarifMessage{Text: description}
+		}
+
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+	}
+
+	for _, f := range r.Findings {
+		run.Results = append(run.Results, f.sarif(ids[f.Rule], options.Locator))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(sarifLog{Schema: sarifSchema, Version: SARIFVersion, Runs: []sarifRun{run}}); err != nil {
+		return fmt.Errorf("unable to write SARIF log: %w", err)
+	}
+
+	return nil
+}
+
+func (f Finding) sarif(ruleIndex int, locator ArtifactLocator) sarifResult {
+	name := f.Object.String()
+	if f.Path != "" {
+		name += "#" + f.Path
+	}
+
+	location := sarifLocation{
+		LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: name, Kind: "object"}},
+	}
+
+	if locator != nil {
+		if uri := locator(f.Object); uri != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}
+		}
+	}
+
+	level := sarifLevels[f.Severity]
+	if level == "" {
+		level = "none"
+	}
+
+	result := sarifResult{
+		RuleID:    f.Rule,
+		RuleIndex: ruleIndex,
+		Level:     level,
+		Message:   sarifMessage{Text: f.Object.String() + ": " + f.Message},
+		Locations: []sarifLocation{location},
+	}
+
+	if f.Exempted {
+		result.Suppressions = []sarifSuppression{{Kind: "external", Justification: f.Justification}}
+	}
+
+	return result
+}
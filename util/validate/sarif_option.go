@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"maps"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// SARIFOption is a generic option for Report.WriteSARIF.
+type SARIFOption = util.Option[SARIFOptions]
+
+// ArtifactLocator returns the URI of the file an object is rendered to or
+// defined in, relative to the repository root, e.g. "deploy/web.yaml"; empty
+// when unknown.
+type ArtifactLocator func(key k8s.ResourceKey) string
+
+// SARIFOptions is a struct-based option that can set SARIF options.
+type SARIFOptions struct {
+	// ToolName and ToolVersion identify the tool in the log; the name defaults
+	// to DefaultSARIFToolName.
+	ToolName    string
+	ToolVersion string
+
+	// Descriptions describes rules by ID, e.g. from lint.Rule.Description.
+	Descriptions map[string]string
+
+	// Locator maps objects to files, which code scanning services need to
+	// annotate results.
+	Locator ArtifactLocator
+}
+
+// ApplyTo applies the SARIF options to the target configuration.
+func (opts SARIFOptions) ApplyTo(target *SARIFOptions) {
+	if opts.ToolName != "" {
+		target.ToolName = opts.ToolName
+	}
+
+	if opts.ToolVersion != "" {
+		target.ToolVersion = opts.ToolVersion
+	}
+
+	if len(opts.Descriptions) > 0 {
+		if target.Descriptions == nil {
+			target.Descriptions = make(map[string]string, len(opts.Descriptions))
+		}
+
+		maps.Copy(target.Descriptions, opts.Descriptions)
+	}
+
+	if opts.Locator != nil {
+		target.Locator = opts.Locator
+	}
+}
+
+// WithSARIFTool records the name and version of the tool in the log.
+func WithSARIFTool(name string, version string) SARIFOption {
+	return util.FunctionalOption[SARIFOptions](func(opts *SARIFOptions) {
+		opts.ToolName = name
+		opts.ToolVersion = version
+	})
+}
+
+// WithRuleDescriptions describes rules by ID.
+func WithRuleDescriptions(descriptions map[string]string) SARIFOption {
+	return util.FunctionalOption[SARIFOptions](func(opts *SARIFOptions) {
+		if opts.Descriptions == nil {
+			opts.Descriptions = make(map[string]string, len(descriptions))
+		}
+
+		maps.Copy(opts.Descriptions, descriptions)
+	})
+}
+
+// WithArtifactLocator maps objects to the files reported as the locations of
+// their findings.
+func WithArtifactLocator(locator ArtifactLocator) SARIFOption {
+	return util.FunctionalOption[SARIFOptions](func(opts *SARIFOptions) {
+		opts.Locator = locator
+	})
+}
@@ -0,0 +1,118 @@
+package validate_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+const expectedSARIF = `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {
+      "driver": {
+        "name": "platform-render",
+        "version": "1.4.0",
+        "rules": [
+          {"id": "host-network", "shortDescription": {"text": "Pods do not use the host network."}},
+          {"id": "schema"}
+        ]
+      }
+    },
+    "results": [
+      {
+        "ruleId": "schema",
+        "ruleIndex": 1,
+        "level": "error",
+        "message": {"text": "apps/Deployment/shop/api: is invalid"},
+        "locations": [{
+          "physicalLocation": {"artifactLocation": {"uri": "deploy/shop.yaml"}},
+          "logicalLocations": [{"fullyQualifiedName": "apps/Deployment/shop/api#spec.replicas", "kind": "object"}]
+        }]
+      },
+      {
+        "ruleId": "host-network",
+        "ruleIndex": 0,
+        "level": "warning",
+        "message": {"text": "apps/DaemonSet/shop/agent: pods use the host network"},
+        "locations": [{
+          "physicalLocation": {"artifactLocation": {"uri": "deploy/shop.yaml"}},
+          "logicalLocations": [{"fullyQualifiedName": "apps/DaemonSet/shop/agent", "kind": "object"}]
+        }],
+        "suppressions": [{"kind": "external", "justification": "node agent"}]
+      },
+      {
+        "ruleId": "schema",
+        "ruleIndex": 1,
+        "level": "note",
+        "message": {"text": "core/Namespace/shop: is new"},
+        "locations": [{
+          "logicalLocations": [{"fullyQualifiedName": "core/Namespace/shop", "kind": "object"}]
+        }]
+      }
+    ]
+  }]
+}`
+
+func TestWriteSARIF(t *testing.T) {
+	findings := validate.Findings{
+		{
+			Rule:     "schema",
+			Severity: validate.SeverityError,
+			Object:   k8s.ResourceKey{Group: "apps", Kind: "Deployment", Namespace: "shop", Name: "api"},
+			Path:     "spec.replicas",
+			Message:  "is invalid",
+		},
+		{
+			Rule:          "host-network",
+			Severity:      validate.SeverityWarning,
+			Object:        k8s.ResourceKey{Group: "apps", Kind: "DaemonSet", Namespace: "shop", Name: "agent"},
+			Message:       "pods use the host network",
+			Exempted:      true,
+			Justification: "node agent",
+		},
+		{
+			Rule:     "schema",
+			Severity: validate.SeverityInfo,
+			Object:   k8s.ResourceKey{Kind: "Namespace", Name: "shop"},
+			Message:  "is new",
+		},
+	}
+
+	t.Run("should write rules and results", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(validate.Report{Findings: findings}.WriteSARIF(&buf,
+			validate.WithSARIFTool("platform-render", "1.4.0"),
+			validate.WithRuleDescriptions(map[string]string{"host-network": "Pods do not use the host network."}),
+			validate.WithArtifactLocator(func(key k8s.ResourceKey) string {
+				if key.Namespace == "" {
+					return ""
+				}
+
+				return "deploy/" + key.Namespace + ".yaml"
+			}),
+		)).Should(Succeed())
+
+		g.Expect(buf.String()).Should(MatchJSON(expectedSARIF))
+	})
+
+	t.Run("should write an empty run without findings", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(validate.NewReport(nil).WriteSARIF(&buf)).Should(Succeed())
+
+		g.Expect(buf.String()).Should(MatchJSON(`{
+			"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+			"version": "2.1.0",
+			"runs": [{"tool": {"driver": {"name": "k8s-manifest-kit", "rules": []}}, "results": []}]
+		}`))
+	})
+}
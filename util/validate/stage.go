@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/transform"
+)
+
+// Stage returns a Transformer running v on the rendered objects, so that
+// validation can run as a pipeline stage before results are returned.
+//
+// The objects are passed through unchanged. Findings are handed to the
+// handler set with WithFindingsHandler and, unless WithWarnOnly is given, the
+// stage fails with a *FailedError listing the findings at or above the
+// WithFailOn threshold (SeverityError by default).
+//
+// Example:
+//
+//	transform.Chain(
+//	    transform.Patches(patches...),
+//	    validate.Stage(validate.Kubeconform([]string{"/schemas"}, "v1.31.0")),
+//	)
+func Stage(v Validator, opts ...StageOption) transform.Transformer {
+	options := StageOptions{
+		FailOn: SeverityError,
+	}
+
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	return transform.Func(func(ctx context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		findings, err := v.Validate(ctx, objects)
+		if err != nil {
+			return nil, fmt.Errorf("unable to validate objects: %w", err)
+		}
+
+		if options.Handler != nil && len(findings) > 0 {
+			options.Handler(ctx, findings)
+		}
+
+		if options.WarnOnly {
+			return objects, nil
+		}
+
+		if failed := findings.AtLeast(options.FailOn); len(failed) > 0 {
+			return nil, &FailedError{Findings: failed}
+		}
+
+		return objects, nil
+	})
+}
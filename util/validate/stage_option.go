@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"context"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// StageOption is a generic option for Stage.
+type StageOption = util.Option[StageOptions]
+
+// StageOptions is a struct-based option that can set validation stage options.
+type StageOptions struct {
+	// FailOn is the lowest severity failing the stage.
+	FailOn Severity
+
+	// WarnOnly reports findings without ever failing the stage.
+	WarnOnly bool
+
+	// Handler receives the findings of every run, e.g. to log warnings.
+	Handler func(ctx context.Context, findings Findings)
+}
+
+// ApplyTo applies the stage options to the target configuration.
+func (opts StageOptions) ApplyTo(target *StageOptions) {
+	if opts.FailOn != "" {
+		target.FailOn = opts.FailOn
+	}
+
+	if opts.WarnOnly {
+		target.WarnOnly = true
+	}
+
+	if opts.Handler != nil {
+		target.Handler = opts.Handler
+	}
+}
+
+// WithFailOn sets the lowest severity failing the stage.
+func WithFailOn(severity Severity) StageOption {
+	return util.FunctionalOption[StageOptions](func(opts *StageOptions) {
+		opts.FailOn = severity
+	})
+}
+
+// WithWarnOnly makes the stage report findings without failing.
+func WithWarnOnly() StageOption {
+	return util.FunctionalOption[StageOptions](func(opts *StageOptions) {
+		opts.WarnOnly = true
+	})
+}
+
+// WithFindingsHandler sets a function receiving the findings of every run.
+func WithFindingsHandler(handler func(ctx context.Context, findings Findings)) StageOption {
+	return util.FunctionalOption[StageOptions](func(opts *StageOptions) {
+		opts.Handler = handler
+	})
+}
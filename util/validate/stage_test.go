@@ -0,0 +1,107 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+var errValidatorBroken = errors.New("validator broken")
+
+func fixedValidator(findings ...validate.Finding) validate.Validator {
+	return validate.Func(func(_ context.Context, _ []unstructured.Unstructured) (validate.Findings, error) {
+		return findings, nil
+	})
+}
+
+func TestStage(t *testing.T) {
+	objects := []unstructured.Unstructured{
+		newObject("v1", "ConfigMap", "settings", nil),
+	}
+
+	warning := validate.Finding{
+		Rule:     "example",
+		Severity: validate.SeverityWarning,
+		Object:   k8s.KeyOf(&objects[0]),
+		Message:  "looks odd",
+	}
+
+	failure := validate.Finding{
+		Rule:     "example",
+		Severity: validate.SeverityError,
+		Object:   k8s.KeyOf(&objects[0]),
+		Path:     "data",
+		Message:  "is wrong",
+	}
+
+	t.Run("should pass objects through without errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := validate.Stage(fixedValidator(warning)).Transform(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(Equal(objects))
+	})
+
+	t.Run("should fail on errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := validate.Stage(fixedValidator(warning, failure)).Transform(t.Context(), objects)
+
+		g.Expect(err).Should(MatchError(validate.ErrValidationFailed))
+
+		var failed *validate.FailedError
+		g.Expect(errors.As(err, &failed)).Should(BeTrue())
+		g.Expect(failed.Findings).Should(Equal(validate.Findings{failure}))
+		g.Expect(err.Error()).Should(ContainSubstring("core/ConfigMap/default/settings data: is wrong (example)"))
+	})
+
+	t.Run("should fail on warnings when requested", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := validate.Stage(
+			fixedValidator(warning),
+			validate.WithFailOn(validate.SeverityWarning),
+		).Transform(t.Context(), objects)
+
+		g.Expect(err).Should(MatchError(validate.ErrValidationFailed))
+	})
+
+	t.Run("should only report findings in warn-only mode", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var reported validate.Findings
+
+		result, err := validate.Stage(
+			fixedValidator(warning, failure),
+			validate.WithWarnOnly(),
+			validate.WithFindingsHandler(func(_ context.Context, findings validate.Findings) {
+				reported = findings
+			}),
+		).Transform(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(Equal(objects))
+		g.Expect(reported).Should(Equal(validate.Findings{warning, failure}))
+	})
+
+	t.Run("should propagate validator errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		broken := validate.Func(func(_ context.Context, _ []unstructured.Unstructured) (validate.Findings, error) {
+			return nil, errValidatorBroken
+		})
+
+		_, err := validate.Stage(broken).Transform(t.Context(), objects)
+
+		g.Expect(err).Should(MatchError(errValidatorBroken))
+		g.Expect(err).ShouldNot(MatchError(validate.ErrValidationFailed))
+	})
+}
@@ -0,0 +1,179 @@
+// Package validate checks rendered objects before they are returned or
+// applied, reporting structured findings that locate each problem by object
+// and field path.
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+)
+
+// ErrValidationFailed is matched by every *FailedError.
+var ErrValidationFailed = errors.New("validation failed")
+
+// Severity is the importance of a finding.
+type Severity string
+
+const (
+	// SeverityInfo reports a finding that needs no action.
+	SeverityInfo Severity = "info"
+
+	// SeverityWarning reports a finding that should be looked at.
+	SeverityWarning Severity = "warning"
+
+	// SeverityError reports an object that must not be applied.
+	SeverityError Severity = "error"
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityError:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is as severe as threshold or more.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s.rank() >= threshold.rank()
+}
+
+// Finding is a problem found in a rendered object.
+type Finding struct {
+	// Rule identifies the check that produced the finding, e.g. "schema".
+	Rule string
+
+	// Severity is the importance of the finding.
+	Severity Severity
+
+	// Object is the object the finding is about.
+	Object k8s.ResourceKey
+
+	// Path locates the offending field, e.g. "spec.replicas"; empty when the
+	// finding is about the object as a whole.
+	Path string
+
+	// Message describes the problem.
+	Message string
+
+	// Exempted marks a finding waived by an exemption: it is still reported,
+	// but never fails validation.
+	Exempted bool
+
+	// Justification records why an exempted finding is waived.
+	Justification string
+}
+
+// String returns the finding in the form
+// "severity group/Kind/namespace/name path: message (rule)", followed by
+// "[exempted: justification]" for exempted findings.
+func (f Finding) String() string {
+	location := f.Object.String()
+	if f.Path != "" {
+		location += " " + f.Path
+	}
+
+	s := fmt.Sprintf("%s %s: %s (%s)", f.Severity, location, f.Message, f.Rule)
+
+	switch {
+	case f.Exempted && f.Justification != "":
+		s += " [exempted: " + f.Justification + "]"
+	case f.Exempted:
+		s += " [exempted]"
+	}
+
+	return s
+}
+
+// Findings is a list of findings.
+type Findings []Finding
+
+// AtLeast returns the findings as severe as threshold or more, leaving out
+// exempted findings.
+func (f Findings) AtLeast(threshold Severity) Findings {
+	var result Findings
+
+	for _, finding := range f {
+		if !finding.Exempted && finding.Severity.AtLeast(threshold) {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
+// Validator checks rendered objects.
+//
+// Validators report problems in the objects as findings; the returned error is
+// reserved for failures of the validator itself, such as an unreadable schema.
+// Validators must not modify the objects.
+type Validator interface {
+	Validate(ctx context.Context, objects []unstructured.Unstructured) (Findings, error)
+}
+
+// Func adapts a plain function to the Validator interface.
+type Func func(ctx context.Context, objects []unstructured.Unstructured) (Findings, error)
+
+// Validate calls f(ctx, objects).
+func (f Func) Validate(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+	return f(ctx, objects)
+}
+
+// All returns a Validator running the given validators in order and
+// concatenating their findings, so that built-in and custom validators
+// compose into one report. Nil validators are skipped.
+func All(validators ...Validator) Validator {
+	return Func(func(ctx context.Context, objects []unstructured.Unstructured) (Findings, error) {
+		var findings Findings
+
+		for i, v := range validators {
+			if v == nil {
+				continue
+			}
+
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("validator[%d]: %w", i, err)
+			}
+
+			result, err := v.Validate(ctx, objects)
+			if err != nil {
+				return nil, fmt.Errorf("validator[%d]: %w", i, err)
+			}
+
+			findings = append(findings, result...)
+		}
+
+		return findings, nil
+	})
+}
+
+// FailedError is returned when validation produces findings at or above the
+// failure threshold.
+type FailedError struct {
+	Findings Findings
+}
+
+func (e *FailedError) Error() string {
+	messages := make([]string, len(e.Findings))
+	for i, f := range e.Findings {
+		messages[i] = f.String()
+	}
+
+	return fmt.Sprintf("%s: %s", ErrValidationFailed.Error(), strings.Join(messages, "; "))
+}
+
+// Unwrap allows errors.Is(err, ErrValidationFailed).
+func (e *FailedError) Unwrap() error {
+	return ErrValidationFailed
+}
@@ -0,0 +1,81 @@
+package validate_test
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8s-manifest-kit/pkg/util/k8s"
+	"github.com/k8s-manifest-kit/pkg/util/validate"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSeverity(t *testing.T) {
+	t.Run("should order severities", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(validate.SeverityError.AtLeast(validate.SeverityWarning)).Should(BeTrue())
+		g.Expect(validate.SeverityWarning.AtLeast(validate.SeverityWarning)).Should(BeTrue())
+		g.Expect(validate.SeverityInfo.AtLeast(validate.SeverityWarning)).Should(BeFalse())
+	})
+}
+
+func TestFindings(t *testing.T) {
+	key := k8s.ResourceKey{Kind: "Namespace", Name: "team-a"}
+
+	t.Run("should filter by severity", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings := validate.Findings{
+			{Rule: "a", Severity: validate.SeverityInfo, Object: key},
+			{Rule: "b", Severity: validate.SeverityError, Object: key},
+			{Rule: "c", Severity: validate.SeverityWarning, Object: key},
+		}
+
+		g.Expect(findings.AtLeast(validate.SeverityWarning)).Should(HaveExactElements(
+			HaveField("Rule", "b"),
+			HaveField("Rule", "c"),
+		))
+	})
+
+	t.Run("should format findings", func(t *testing.T) {
+		g := NewWithT(t)
+
+		f := validate.Finding{Rule: "schema", Severity: validate.SeverityError, Object: key, Message: "bad"}
+
+		g.Expect(f.String()).Should(Equal("error core/Namespace/team-a: bad (schema)"))
+	})
+}
+
+func TestAll(t *testing.T) {
+	objects := []unstructured.Unstructured{
+		newObject("v1", "ConfigMap", "settings", nil),
+	}
+
+	first := validate.Finding{Rule: "first", Severity: validate.SeverityInfo, Object: k8s.KeyOf(&objects[0])}
+	second := validate.Finding{Rule: "second", Severity: validate.SeverityError, Object: k8s.KeyOf(&objects[0])}
+
+	t.Run("should concatenate findings in order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		findings, err := validate.All(fixedValidator(first), nil, fixedValidator(second)).Validate(t.Context(), objects)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(findings).Should(Equal(validate.Findings{first, second}))
+	})
+
+	t.Run("should fail on validator errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		broken := validate.Func(func(_ context.Context, _ []unstructured.Unstructured) (validate.Findings, error) {
+			return nil, errValidatorBroken
+		})
+
+		_, err := validate.All(fixedValidator(first), broken).Validate(t.Context(), objects)
+
+		g.Expect(err).Should(MatchError(errValidatorBroken))
+		g.Expect(err.Error()).Should(HavePrefix("validator[1]: "))
+	})
+}
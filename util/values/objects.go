@@ -0,0 +1,186 @@
+package values
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/k8s-manifest-kit/pkg/util/cache"
+	"github.com/k8s-manifest-kit/pkg/util/maps"
+)
+
+// DefaultValuesKey is the data key holding values when ObjectRef.Key is empty.
+const DefaultValuesKey = "values.yaml"
+
+var (
+	// ErrInvalidObjectRef is returned for a malformed values object reference.
+	ErrInvalidObjectRef = errors.New("invalid values object reference")
+
+	// ErrValuesNotFound is returned when a referenced object has no values key.
+	ErrValuesNotFound = errors.New("values not found")
+)
+
+// ObjectKind is the kind of a Kubernetes object holding values.
+type ObjectKind string
+
+const (
+	// ConfigMapKind reads values from a ConfigMap.
+	ConfigMapKind ObjectKind = "ConfigMap"
+
+	// SecretKind reads values from a Secret.
+	SecretKind ObjectKind = "Secret"
+)
+
+// ObjectRef locates a YAML values document stored in a ConfigMap or Secret,
+// in the style of the valuesFrom of a Flux HelmRelease.
+type ObjectRef struct {
+	Kind      ObjectKind
+	Namespace string
+	Name      string
+
+	// Key is the data key holding the values; defaults to DefaultValuesKey.
+	Key string
+}
+
+// String returns the reference as <kind>/<namespace>/<name>#<key>.
+func (r ObjectRef) String() string {
+	return string(r.Kind) + "/" + r.Namespace + "/" + r.Name + "#" + r.key()
+}
+
+func (r ObjectRef) key() string {
+	if r.Key == "" {
+		return DefaultValuesKey
+	}
+
+	return r.Key
+}
+
+// ConfigMapGetter returns the data of a Kubernetes ConfigMap.
+type ConfigMapGetter func(ctx context.Context, namespace string, name string) (map[string]string, error)
+
+// ObjectSource reads values from ConfigMaps and Secrets through caller-supplied
+// getters, typically backed by a Kubernetes client, so that values can live in
+// the cluster. Fetched values are cached and refreshed once their TTL expires.
+type ObjectSource struct {
+	refs       []ObjectRef
+	configMaps ConfigMapGetter
+	secrets    SecretGetter
+	cache      cache.Interface[map[string]any]
+}
+
+// NewObjectSource creates a source reading the referenced objects with the
+// given options.
+//
+// Example:
+//
+//	source, err := values.NewObjectSource(
+//	    []values.ObjectRef{{Kind: values.ConfigMapKind, Namespace: "apps", Name: "web-values"}},
+//	    values.WithConfigMapGetter(getConfigMap),
+//	    values.WithRefreshInterval(time.Minute),
+//	)
+//	merged, err := source.Values(ctx)
+func NewObjectSource(refs []ObjectRef, opts ...ObjectSourceOption) (*ObjectSource, error) {
+	options := ObjectSourceOptions{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	for _, ref := range refs {
+		if ref.Namespace == "" || ref.Name == "" {
+			return nil, fmt.Errorf("%w: %s: namespace and name are required", ErrInvalidObjectRef, ref)
+		}
+
+		switch {
+		case ref.Kind == ConfigMapKind && options.ConfigMaps == nil:
+			return nil, fmt.Errorf("%w: %s: no ConfigMap getter", ErrInvalidObjectRef, ref)
+		case ref.Kind == SecretKind && options.Secrets == nil:
+			return nil, fmt.Errorf("%w: %s: no Secret getter", ErrInvalidObjectRef, ref)
+		case ref.Kind != ConfigMapKind && ref.Kind != SecretKind:
+			return nil, fmt.Errorf("%w: %s: unsupported kind", ErrInvalidObjectRef, ref)
+		}
+	}
+
+	var cacheOpts []cache.Option
+	if options.RefreshInterval > 0 {
+		cacheOpts = append(cacheOpts, cache.WithTTL(options.RefreshInterval))
+	}
+
+	return &ObjectSource{
+		refs:       refs,
+		configMaps: options.ConfigMaps,
+		secrets:    options.Secrets,
+		cache:      cache.New[map[string]any](cacheOpts...),
+	}, nil
+}
+
+// Values returns the values of the referenced objects, each document deep
+// merged over the previous ones in order, so that later references override
+// earlier ones. The result is a copy and can be modified freely.
+func (s *ObjectSource) Values(ctx context.Context) (map[string]any, error) {
+	result := map[string]any{}
+
+	for _, ref := range s.refs {
+		values, err := s.load(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		result = maps.DeepMerge(result, values)
+	}
+
+	return result, nil
+}
+
+// load returns the values of ref, from the cache while they are fresh.
+func (s *ObjectSource) load(ctx context.Context, ref ObjectRef) (map[string]any, error) {
+	key := ref.String()
+
+	if values, ok := s.cache.Get(key); ok {
+		return values, nil
+	}
+
+	content, err := s.fetch(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]any{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("unable to decode values of %s: %w", ref, err)
+	}
+
+	s.cache.Set(key, values)
+
+	return values, nil
+}
+
+// fetch reads the values document of ref from the cluster.
+func (s *ObjectSource) fetch(ctx context.Context, ref ObjectRef) ([]byte, error) {
+	if ref.Kind == SecretKind {
+		data, err := s.secrets(ctx, ref.Namespace, ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get secret %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+
+		content, ok := data[ref.key()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrValuesNotFound, ref)
+		}
+
+		return content, nil
+	}
+
+	data, err := s.configMaps(ctx, ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get config map %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	content, ok := data[ref.key()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrValuesNotFound, ref)
+	}
+
+	return []byte(content), nil
+}
@@ -0,0 +1,62 @@
+package values
+
+import (
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// ObjectSourceOption is a generic option for ObjectSource.
+type ObjectSourceOption = util.Option[ObjectSourceOptions]
+
+// ObjectSourceOptions is a struct-based option that can set multiple source options at once.
+type ObjectSourceOptions struct {
+	// ConfigMaps reads the referenced ConfigMaps; required for ConfigMapKind
+	// references.
+	ConfigMaps ConfigMapGetter
+
+	// Secrets reads the referenced Secrets; required for SecretKind
+	// references.
+	Secrets SecretGetter
+
+	// RefreshInterval is how long fetched values are cached before they are
+	// read again; defaults to the cache package default.
+	RefreshInterval time.Duration
+}
+
+// ApplyTo applies the source options to the target configuration.
+func (opts ObjectSourceOptions) ApplyTo(target *ObjectSourceOptions) {
+	if opts.ConfigMaps != nil {
+		target.ConfigMaps = opts.ConfigMaps
+	}
+
+	if opts.Secrets != nil {
+		target.Secrets = opts.Secrets
+	}
+
+	if opts.RefreshInterval > 0 {
+		target.RefreshInterval = opts.RefreshInterval
+	}
+}
+
+// WithConfigMapGetter sets the getter reading the referenced ConfigMaps.
+func WithConfigMapGetter(get ConfigMapGetter) ObjectSourceOption {
+	return util.FunctionalOption[ObjectSourceOptions](func(opts *ObjectSourceOptions) {
+		opts.ConfigMaps = get
+	})
+}
+
+// WithSecretGetter sets the getter reading the referenced Secrets.
+func WithSecretGetter(get SecretGetter) ObjectSourceOption {
+	return util.FunctionalOption[ObjectSourceOptions](func(opts *ObjectSourceOptions) {
+		opts.Secrets = get
+	})
+}
+
+// WithRefreshInterval sets how long fetched values are cached before they are
+// read again.
+func WithRefreshInterval(interval time.Duration) ObjectSourceOption {
+	return util.FunctionalOption[ObjectSourceOptions](func(opts *ObjectSourceOptions) {
+		opts.RefreshInterval = interval
+	})
+}
@@ -0,0 +1,155 @@
+package values_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util/values"
+
+	. "github.com/onsi/gomega"
+)
+
+const testBaseValues = `
+replicas: 2
+image:
+  repository: example.com/web
+  tag: v1
+`
+
+const testOverrideValues = `
+image:
+  tag: v2
+`
+
+func TestObjectSource(t *testing.T) {
+	configMaps := func(_ context.Context, namespace string, name string) (map[string]string, error) {
+		if namespace != "apps" || name != "web-values" {
+			return nil, errTestBackend
+		}
+
+		return map[string]string{values.DefaultValuesKey: testBaseValues}, nil
+	}
+
+	secrets := func(_ context.Context, _ string, _ string) (map[string][]byte, error) {
+		return map[string][]byte{"override.yaml": []byte(testOverrideValues)}, nil
+	}
+
+	t.Run("merges values in reference order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		source, err := values.NewObjectSource(
+			[]values.ObjectRef{
+				{Kind: values.ConfigMapKind, Namespace: "apps", Name: "web-values"},
+				{Kind: values.SecretKind, Namespace: "apps", Name: "web-override", Key: "override.yaml"},
+			},
+			values.WithConfigMapGetter(configMaps),
+			values.WithSecretGetter(secrets),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		merged, err := source.Values(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(merged).Should(Equal(map[string]any{
+			"replicas": 2,
+			"image": map[string]any{
+				"repository": "example.com/web",
+				"tag":        "v2",
+			},
+		}))
+	})
+
+	t.Run("refreshes values once cached values expire", func(t *testing.T) {
+		g := NewWithT(t)
+
+		replicas := "1"
+		calls := 0
+
+		source, err := values.NewObjectSource(
+			[]values.ObjectRef{{Kind: values.ConfigMapKind, Namespace: "apps", Name: "web-values"}},
+			values.WithConfigMapGetter(func(context.Context, string, string) (map[string]string, error) {
+				calls++
+
+				return map[string]string{values.DefaultValuesKey: "replicas: " + replicas}, nil
+			}),
+			values.WithRefreshInterval(50*time.Millisecond),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		merged, err := source.Values(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(merged).Should(HaveKeyWithValue("replicas", 1))
+
+		replicas = "3"
+
+		merged, err = source.Values(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(merged).Should(HaveKeyWithValue("replicas", 1))
+		g.Expect(calls).Should(Equal(1))
+
+		g.Eventually(func() (map[string]any, error) {
+			return source.Values(t.Context())
+		}).Should(HaveKeyWithValue("replicas", 3))
+	})
+
+	t.Run("does not let callers modify cached values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		source, err := values.NewObjectSource(
+			[]values.ObjectRef{{Kind: values.ConfigMapKind, Namespace: "apps", Name: "web-values"}},
+			values.WithConfigMapGetter(configMaps),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		merged, err := source.Values(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		merged["image"].(map[string]any)["tag"] = "modified"
+
+		merged, err = source.Values(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(merged).Should(HaveKeyWithValue("image", HaveKeyWithValue("tag", "v1")))
+	})
+
+	t.Run("fails when the values key is missing", func(t *testing.T) {
+		g := NewWithT(t)
+
+		source, err := values.NewObjectSource(
+			[]values.ObjectRef{{Kind: values.SecretKind, Namespace: "apps", Name: "web-override"}},
+			values.WithSecretGetter(secrets),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = source.Values(t.Context())
+		g.Expect(err).Should(MatchError(values.ErrValuesNotFound))
+	})
+
+	t.Run("fails when the getter fails", func(t *testing.T) {
+		g := NewWithT(t)
+
+		source, err := values.NewObjectSource(
+			[]values.ObjectRef{{Kind: values.ConfigMapKind, Namespace: "apps", Name: "missing"}},
+			values.WithConfigMapGetter(configMaps),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = source.Values(t.Context())
+		g.Expect(err).Should(MatchError(errTestBackend))
+	})
+
+	t.Run("rejects references without a getter", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := values.NewObjectSource(
+			[]values.ObjectRef{{Kind: values.SecretKind, Namespace: "apps", Name: "web-override"}},
+			values.WithConfigMapGetter(configMaps),
+		)
+		g.Expect(err).Should(MatchError(values.ErrInvalidObjectRef))
+
+		_, err = values.NewObjectSource(
+			[]values.ObjectRef{{Kind: "Pod", Namespace: "apps", Name: "web"}},
+			values.WithConfigMapGetter(configMaps),
+		)
+		g.Expect(err).Should(MatchError(values.ErrInvalidObjectRef))
+	})
+}
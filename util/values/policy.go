@@ -0,0 +1,54 @@
+// Package values provides helpers for computing render values.
+package values
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/k8s-manifest-kit/pkg/util/maps"
+)
+
+// ErrUnknownPolicy is returned for an unsupported values policy.
+var ErrUnknownPolicy = errors.New("unknown values policy")
+
+// Policy selects how the values of an upgrade are computed from the chart
+// defaults, the values of the previous render and the new overrides. The
+// policies mirror the flags of helm upgrade.
+type Policy string
+
+const (
+	// PolicyReset discards the previous values: defaults, then overrides.
+	// This is the behavior of helm upgrade --reset-values and the default.
+	PolicyReset Policy = "reset"
+
+	// PolicyReuse starts from the previous values and ignores the current
+	// defaults: previous, then overrides. This is helm upgrade --reuse-values.
+	PolicyReuse Policy = "reuse"
+
+	// PolicyResetThenReuse layers the previous values on the current defaults:
+	// defaults, then previous, then overrides. New defaults introduced by an
+	// upgraded chart are picked up while earlier customizations are kept. This
+	// is helm upgrade --reset-then-reuse-values.
+	PolicyResetThenReuse Policy = "reset-then-reuse"
+)
+
+// Merge computes the values to render with according to the policy. previous
+// is the snapshot of the values used by the previous render; when it is nil,
+// for example on a first install, PolicyReuse falls back to the defaults.
+// The inputs are never modified.
+func (p Policy) Merge(defaults map[string]any, previous map[string]any, overrides map[string]any) (map[string]any, error) {
+	switch p {
+	case "", PolicyReset:
+		return maps.DeepMerge(defaults, overrides), nil
+	case PolicyReuse:
+		if previous == nil {
+			return maps.DeepMerge(defaults, overrides), nil
+		}
+
+		return maps.DeepMerge(previous, overrides), nil
+	case PolicyResetThenReuse:
+		return maps.DeepMerge(maps.DeepMerge(defaults, previous), overrides), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, string(p))
+	}
+}
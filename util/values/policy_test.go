@@ -0,0 +1,113 @@
+package values_test
+
+import (
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/values"
+
+	. "github.com/onsi/gomega"
+)
+
+func testDefaults() map[string]any {
+	return map[string]any{
+		"replicas": 1,
+		"image":    map[string]any{"repository": "nginx", "tag": "2.0"},
+		"metrics":  map[string]any{"enabled": true},
+	}
+}
+
+func testPrevious() map[string]any {
+	return map[string]any{
+		"replicas": 3,
+		"image":    map[string]any{"repository": "nginx", "tag": "1.0"},
+	}
+}
+
+func testOverrides() map[string]any {
+	return map[string]any{
+		"image": map[string]any{"tag": "1.1"},
+	}
+}
+
+func TestPolicyMerge(t *testing.T) {
+	t.Run("reset ignores the previous values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := values.PolicyReset.Merge(testDefaults(), testPrevious(), testOverrides())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(Equal(map[string]any{
+			"replicas": 1,
+			"image":    map[string]any{"repository": "nginx", "tag": "1.1"},
+			"metrics":  map[string]any{"enabled": true},
+		}))
+	})
+
+	t.Run("empty policy behaves like reset", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var policy values.Policy
+
+		result, err := policy.Merge(testDefaults(), testPrevious(), testOverrides())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		expected, err := values.PolicyReset.Merge(testDefaults(), testPrevious(), testOverrides())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(Equal(expected))
+	})
+
+	t.Run("reuse ignores the current defaults", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := values.PolicyReuse.Merge(testDefaults(), testPrevious(), testOverrides())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(Equal(map[string]any{
+			"replicas": 3,
+			"image":    map[string]any{"repository": "nginx", "tag": "1.1"},
+		}))
+	})
+
+	t.Run("reuse falls back to defaults without previous values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := values.PolicyReuse.Merge(testDefaults(), nil, testOverrides())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveKeyWithValue("replicas", 1))
+	})
+
+	t.Run("reset-then-reuse layers previous values on the defaults", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := values.PolicyResetThenReuse.Merge(testDefaults(), testPrevious(), testOverrides())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(Equal(map[string]any{
+			"replicas": 3,
+			"image":    map[string]any{"repository": "nginx", "tag": "1.1"},
+			"metrics":  map[string]any{"enabled": true},
+		}))
+	})
+
+	t.Run("does not modify the inputs", func(t *testing.T) {
+		g := NewWithT(t)
+
+		defaults := testDefaults()
+		previous := testPrevious()
+
+		_, err := values.PolicyResetThenReuse.Merge(defaults, previous, testOverrides())
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(defaults).Should(Equal(testDefaults()))
+		g.Expect(previous).Should(Equal(testPrevious()))
+	})
+
+	t.Run("rejects unknown policies", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := values.Policy("merge").Merge(nil, nil, nil)
+
+		g.Expect(err).Should(MatchError(values.ErrUnknownPolicy))
+	})
+}
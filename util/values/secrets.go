@@ -0,0 +1,292 @@
+package values
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/k8s-manifest-kit/pkg/util/cache"
+	"github.com/k8s-manifest-kit/pkg/util/maps"
+)
+
+const (
+	// SecretRefPrefix starts every secret reference in values.
+	SecretRefPrefix = "secretref+"
+
+	// Redacted replaces secret values in redacted text.
+	Redacted = "<redacted>"
+
+	secretSchemeSeparator = "://"
+)
+
+var (
+	// ErrInvalidSecretRef is returned for a malformed secret reference.
+	ErrInvalidSecretRef = errors.New("invalid secret reference")
+
+	// ErrUnknownSecretScheme is returned when no provider handles the scheme of a reference.
+	ErrUnknownSecretScheme = errors.New("unknown secret scheme")
+
+	// ErrSecretNotFound is returned by providers when a referenced secret does not exist.
+	ErrSecretNotFound = errors.New("secret not found")
+)
+
+// SecretRef is a parsed reference of the form secretref+<scheme>://<path>#<key>,
+// e.g. secretref+vault://secret/data/app#password.
+type SecretRef struct {
+	// Scheme selects the provider, e.g. "vault" or "k8s".
+	Scheme string
+
+	// Path locates the secret within the provider.
+	Path string
+
+	// Key optionally selects an entry of the secret.
+	Key string
+}
+
+// String returns the reference in its textual form.
+func (r SecretRef) String() string {
+	s := SecretRefPrefix + r.Scheme + secretSchemeSeparator + r.Path
+	if r.Key != "" {
+		s += "#" + r.Key
+	}
+
+	return s
+}
+
+// ParseSecretRef parses a secret reference. The boolean result is false when
+// s is not a secret reference at all, in which case it is a plain value.
+func ParseSecretRef(s string) (SecretRef, bool, error) {
+	rest, ok := strings.CutPrefix(s, SecretRefPrefix)
+	if !ok {
+		return SecretRef{}, false, nil
+	}
+
+	scheme, location, ok := strings.Cut(rest, secretSchemeSeparator)
+	if !ok || scheme == "" {
+		return SecretRef{}, true, fmt.Errorf("%w: %q: missing scheme", ErrInvalidSecretRef, s)
+	}
+
+	path, key, _ := strings.Cut(location, "#")
+	if path == "" {
+		return SecretRef{}, true, fmt.Errorf("%w: %q: missing path", ErrInvalidSecretRef, s)
+	}
+
+	return SecretRef{Scheme: scheme, Path: path, Key: key}, true, nil
+}
+
+// SecretsProvider resolves secret references of one scheme.
+// Implementations must be safe for concurrent use.
+type SecretsProvider interface {
+	// Resolve returns the secret value for ref. It returns an error wrapping
+	// ErrSecretNotFound when the secret or key does not exist.
+	Resolve(ctx context.Context, ref SecretRef) (string, error)
+}
+
+// SecretsProviderFunc is a function implementing SecretsProvider.
+type SecretsProviderFunc func(ctx context.Context, ref SecretRef) (string, error)
+
+// Resolve calls f.
+func (f SecretsProviderFunc) Resolve(ctx context.Context, ref SecretRef) (string, error) {
+	return f(ctx, ref)
+}
+
+// EnvSecretsProvider resolves secretref+env://NAME references from the
+// environment of the process. Keys are not supported.
+func EnvSecretsProvider() SecretsProvider {
+	return SecretsProviderFunc(func(_ context.Context, ref SecretRef) (string, error) {
+		if ref.Key != "" {
+			return "", fmt.Errorf("%w: %s: env references do not support keys", ErrInvalidSecretRef, ref)
+		}
+
+		value, ok := os.LookupEnv(ref.Path)
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrSecretNotFound, ref)
+		}
+
+		return value, nil
+	})
+}
+
+// SecretGetter returns the data of a Kubernetes Secret.
+type SecretGetter func(ctx context.Context, namespace string, name string) (map[string][]byte, error)
+
+// KubernetesSecretsProvider resolves secretref+k8s://<namespace>/<name>#<key>
+// references through get, typically backed by a Kubernetes client.
+func KubernetesSecretsProvider(get SecretGetter) SecretsProvider {
+	return SecretsProviderFunc(func(ctx context.Context, ref SecretRef) (string, error) {
+		namespace, name, ok := strings.Cut(ref.Path, "/")
+		if !ok || namespace == "" || name == "" || ref.Key == "" {
+			return "", fmt.Errorf("%w: %s: expected k8s://<namespace>/<name>#<key>", ErrInvalidSecretRef, ref)
+		}
+
+		data, err := get(ctx, namespace, name)
+		if err != nil {
+			return "", fmt.Errorf("unable to get secret %s/%s: %w", namespace, name, err)
+		}
+
+		value, ok := data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrSecretNotFound, ref)
+		}
+
+		return string(value), nil
+	})
+}
+
+// SecretsResolver replaces secret references in values with the secret
+// values returned by the providers registered for their schemes. Resolved
+// secrets are cached and remembered for redaction.
+type SecretsResolver struct {
+	providers map[string]SecretsProvider
+	cache     cache.Interface[string]
+
+	mu      sync.RWMutex
+	secrets []string
+}
+
+// NewSecretsResolver creates a resolver with the given options.
+//
+// Example:
+//
+//	resolver := values.NewSecretsResolver(
+//	    values.WithSecretsProvider("env", values.EnvSecretsProvider()),
+//	    values.WithSecretsProvider("vault", vaultProvider),
+//	)
+//	resolved, err := resolver.Resolve(ctx, merged)
+func NewSecretsResolver(opts ...SecretsOption) *SecretsResolver {
+	options := SecretsOptions{}
+	for _, opt := range opts {
+		opt.ApplyTo(&options)
+	}
+
+	var cacheOpts []cache.Option
+	if options.CacheTTL > 0 {
+		cacheOpts = append(cacheOpts, cache.WithTTL(options.CacheTTL))
+	}
+
+	return &SecretsResolver{
+		providers: options.Providers,
+		cache:     cache.New[string](cacheOpts...),
+	}
+}
+
+// Resolve returns a copy of values where every string that is a secret
+// reference is replaced by the secret value. The input is never modified.
+// Errors name the path of the offending value but never a secret value.
+func (r *SecretsResolver) Resolve(ctx context.Context, values map[string]any) (map[string]any, error) {
+	result := maps.DeepCloneMap(values)
+
+	if err := r.resolveMap(ctx, "", result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Redact replaces every secret value resolved so far that occurs in s, so
+// that s can be logged safely.
+func (r *SecretsResolver) Redact(s string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+
+	return s
+}
+
+func (r *SecretsResolver) resolveMap(ctx context.Context, prefix string, m map[string]any) error {
+	for k, v := range m {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		resolved, err := r.resolveValue(ctx, path, v)
+		if err != nil {
+			return err
+		}
+
+		m[k] = resolved
+	}
+
+	return nil
+}
+
+func (r *SecretsResolver) resolveValue(ctx context.Context, path string, v any) (any, error) {
+	switch val := v.(type) {
+	case map[string]any:
+		return val, r.resolveMap(ctx, path, val)
+	case []any:
+		for i := range val {
+			resolved, err := r.resolveValue(ctx, path+"["+strconv.Itoa(i)+"]", val[i])
+			if err != nil {
+				return nil, err
+			}
+
+			val[i] = resolved
+		}
+
+		return val, nil
+	case string:
+		ref, ok, err := ParseSecretRef(val)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if !ok {
+			return val, nil
+		}
+
+		secret, err := r.resolveRef(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		return secret, nil
+	default:
+		return v, nil
+	}
+}
+
+func (r *SecretsResolver) resolveRef(ctx context.Context, ref SecretRef) (string, error) {
+	key := ref.String()
+
+	if secret, ok := r.cache.Get(key); ok {
+		return secret, nil
+	}
+
+	provider, ok := r.providers[ref.Scheme]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownSecretScheme, ref.Scheme)
+	}
+
+	secret, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve %s: %w", ref, err)
+	}
+
+	r.cache.Set(key, secret)
+	r.remember(secret)
+
+	return secret, nil
+}
+
+func (r *SecretsResolver) remember(secret string) {
+	if secret == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !slices.Contains(r.secrets, secret) {
+		r.secrets = append(r.secrets, secret)
+	}
+}
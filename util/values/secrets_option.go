@@ -0,0 +1,52 @@
+package values
+
+import (
+	"time"
+
+	"github.com/k8s-manifest-kit/pkg/util"
+)
+
+// SecretsOption is a generic option for SecretsResolver.
+type SecretsOption = util.Option[SecretsOptions]
+
+// SecretsOptions is a struct-based option that can set multiple resolver options at once.
+type SecretsOptions struct {
+	// Providers maps reference schemes to the providers resolving them.
+	Providers map[string]SecretsProvider
+
+	// CacheTTL is how long resolved secrets are cached; defaults to the
+	// cache package default.
+	CacheTTL time.Duration
+}
+
+// ApplyTo applies the resolver options to the target configuration.
+func (opts SecretsOptions) ApplyTo(target *SecretsOptions) {
+	for scheme, p := range opts.Providers {
+		if target.Providers == nil {
+			target.Providers = make(map[string]SecretsProvider)
+		}
+
+		target.Providers[scheme] = p
+	}
+	if opts.CacheTTL > 0 {
+		target.CacheTTL = opts.CacheTTL
+	}
+}
+
+// WithSecretsProvider registers the provider resolving references of scheme.
+func WithSecretsProvider(scheme string, provider SecretsProvider) SecretsOption {
+	return util.FunctionalOption[SecretsOptions](func(opts *SecretsOptions) {
+		if opts.Providers == nil {
+			opts.Providers = make(map[string]SecretsProvider)
+		}
+
+		opts.Providers[scheme] = provider
+	})
+}
+
+// WithSecretsCacheTTL sets how long resolved secrets are cached.
+func WithSecretsCacheTTL(ttl time.Duration) SecretsOption {
+	return util.FunctionalOption[SecretsOptions](func(opts *SecretsOptions) {
+		opts.CacheTTL = ttl
+	})
+}
@@ -0,0 +1,202 @@
+package values_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/k8s-manifest-kit/pkg/util/values"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testVaultRef    = "secretref+vault://secret/data/app#password"
+	testVaultSecret = "s3cr3t"
+)
+
+var errTestBackend = errors.New("backend unavailable")
+
+// countingProvider returns a fixed secret and counts its invocations.
+func countingProvider(calls *atomic.Int32) values.SecretsProvider {
+	return values.SecretsProviderFunc(func(_ context.Context, ref values.SecretRef) (string, error) {
+		calls.Add(1)
+
+		if ref.Path != "secret/data/app" || ref.Key != "password" {
+			return "", values.ErrSecretNotFound
+		}
+
+		return testVaultSecret, nil
+	})
+}
+
+func TestParseSecretRef(t *testing.T) {
+	t.Run("parses scheme, path and key", func(t *testing.T) {
+		g := NewWithT(t)
+
+		ref, ok, err := values.ParseSecretRef(testVaultRef)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ok).Should(BeTrue())
+		g.Expect(ref).Should(Equal(values.SecretRef{Scheme: "vault", Path: "secret/data/app", Key: "password"}))
+		g.Expect(ref.String()).Should(Equal(testVaultRef))
+	})
+
+	t.Run("ignores plain values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, ok, err := values.ParseSecretRef("vault://not-a-ref")
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ok).Should(BeFalse())
+	})
+
+	t.Run("rejects malformed references", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for _, s := range []string{"secretref+vault", "secretref+://path", "secretref+vault://#key"} {
+			_, ok, err := values.ParseSecretRef(s)
+
+			g.Expect(ok).Should(BeTrue(), s)
+			g.Expect(err).Should(MatchError(values.ErrInvalidSecretRef), s)
+		}
+	})
+}
+
+func TestSecretsResolver(t *testing.T) {
+	t.Run("replaces references at any depth", func(t *testing.T) {
+		g := NewWithT(t)
+
+		calls := &atomic.Int32{}
+		resolver := values.NewSecretsResolver(values.WithSecretsProvider("vault", countingProvider(calls)))
+
+		input := map[string]any{
+			"replicas": 2,
+			"db":       map[string]any{"password": testVaultRef, "user": "app"},
+			"extra":    []any{testVaultRef, "plain"},
+		}
+
+		result, err := resolver.Resolve(t.Context(), input)
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(Equal(map[string]any{
+			"replicas": 2,
+			"db":       map[string]any{"password": testVaultSecret, "user": "app"},
+			"extra":    []any{testVaultSecret, "plain"},
+		}))
+		g.Expect(input["db"]).Should(HaveKeyWithValue("password", testVaultRef))
+	})
+
+	t.Run("caches resolved secrets", func(t *testing.T) {
+		g := NewWithT(t)
+
+		calls := &atomic.Int32{}
+		resolver := values.NewSecretsResolver(values.WithSecretsProvider("vault", countingProvider(calls)))
+
+		for range 3 {
+			_, err := resolver.Resolve(t.Context(), map[string]any{"a": testVaultRef, "b": testVaultRef})
+			g.Expect(err).ShouldNot(HaveOccurred())
+		}
+
+		g.Expect(calls.Load()).Should(Equal(int32(1)))
+	})
+
+	t.Run("redacts resolved secrets", func(t *testing.T) {
+		g := NewWithT(t)
+
+		calls := &atomic.Int32{}
+		resolver := values.NewSecretsResolver(values.WithSecretsProvider("vault", countingProvider(calls)))
+
+		_, err := resolver.Resolve(t.Context(), map[string]any{"password": testVaultRef})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(resolver.Redact("connecting with password=s3cr3t")).Should(Equal("connecting with password=<redacted>"))
+	})
+
+	t.Run("reports the path of failing references", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resolver := values.NewSecretsResolver(values.WithSecretsProvider("vault",
+			values.SecretsProviderFunc(func(context.Context, values.SecretRef) (string, error) {
+				return "", errTestBackend
+			}),
+		))
+
+		_, err := resolver.Resolve(t.Context(), map[string]any{"db": map[string]any{"password": testVaultRef}})
+
+		g.Expect(err).Should(MatchError(errTestBackend))
+		g.Expect(err).Should(MatchError(ContainSubstring("db.password")))
+	})
+
+	t.Run("rejects unknown schemes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resolver := values.NewSecretsResolver()
+
+		_, err := resolver.Resolve(t.Context(), map[string]any{"password": testVaultRef})
+
+		g.Expect(err).Should(MatchError(values.ErrUnknownSecretScheme))
+	})
+}
+
+func TestEnvSecretsProvider(t *testing.T) {
+	t.Run("resolves environment variables", func(t *testing.T) {
+		g := NewWithT(t)
+
+		t.Setenv("TEST_DB_PASSWORD", "from-env")
+
+		resolver := values.NewSecretsResolver(values.WithSecretsProvider("env", values.EnvSecretsProvider()))
+
+		result, err := resolver.Resolve(t.Context(), map[string]any{"password": "secretref+env://TEST_DB_PASSWORD"})
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result).Should(HaveKeyWithValue("password", "from-env"))
+	})
+
+	t.Run("fails for unset variables", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := values.EnvSecretsProvider().Resolve(t.Context(), values.SecretRef{Scheme: "env", Path: "TEST_UNSET_VARIABLE"})
+
+		g.Expect(err).Should(MatchError(values.ErrSecretNotFound))
+	})
+}
+
+func TestKubernetesSecretsProvider(t *testing.T) {
+	get := func(_ context.Context, namespace string, name string) (map[string][]byte, error) {
+		if namespace == "apps" && name == "db" {
+			return map[string][]byte{"password": []byte("from-secret")}, nil
+		}
+
+		return nil, values.ErrSecretNotFound
+	}
+
+	t.Run("resolves secret keys", func(t *testing.T) {
+		g := NewWithT(t)
+
+		value, err := values.KubernetesSecretsProvider(get).Resolve(t.Context(),
+			values.SecretRef{Scheme: "k8s", Path: "apps/db", Key: "password"})
+
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(value).Should(Equal("from-secret"))
+	})
+
+	t.Run("fails for missing keys", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := values.KubernetesSecretsProvider(get).Resolve(t.Context(),
+			values.SecretRef{Scheme: "k8s", Path: "apps/db", Key: "user"})
+
+		g.Expect(err).Should(MatchError(values.ErrSecretNotFound))
+	})
+
+	t.Run("rejects references without namespace or key", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := values.KubernetesSecretsProvider(get).Resolve(t.Context(),
+			values.SecretRef{Scheme: "k8s", Path: "db"})
+
+		g.Expect(err).Should(MatchError(values.ErrInvalidSecretRef))
+	})
+}